@@ -0,0 +1,122 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/tools/issue"
+	"github.com/raohwork/forgejo-mcp/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export owner/repo",
+	Short: "Export the issues of a repository",
+	Long: `Export the issues of a repository with their comments, labels,
+milestones and dependency/blocking edges, like the export_issues tool.
+
+Formats:
+  - json:  a single document with labels, milestones and issues
+  - jsonl: one issue per line
+  - csv:   one issue per row, comments are only counted
+
+Example:
+  forgejo-mcp export owner/repo --format csv --output issues.csv --server https://git.example.com --token your_token`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		owner, repo, ok := strings.Cut(args[0], "/")
+		if !ok || owner == "" || repo == "" {
+			return fmt.Errorf("invalid repository %q, expected owner/repo", args[0])
+		}
+
+		f := cmd.Flags()
+		p := issue.ExportIssuesParams{Owner: owner, Repo: repo}
+		p.Format, _ = f.GetString("format")
+		p.State, _ = f.GetString("state")
+		p.Labels, _ = f.GetString("labels")
+		p.Milestones, _ = f.GetString("milestones")
+		p.SkipComments, _ = f.GetBool("skip-comments")
+		p.SkipDependencies, _ = f.GetBool("skip-dependencies")
+		output, _ := f.GetString("output")
+		switch p.Format {
+		case types.ExportJSON, types.ExportJSONL, types.ExportCSV:
+		default:
+			return fmt.Errorf("unsupported format %q, use json, jsonl or csv", p.Format)
+		}
+
+		cl, err := cliClient()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer cancel()
+		export, err := issue.ExportIssues(ctx, cl, p)
+		if err != nil {
+			return err
+		}
+
+		if output == "" || output == "-" {
+			if err := export.Write(os.Stdout, p.Format); err != nil {
+				return err
+			}
+		} else {
+			file, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			if err := export.Write(file, p.Format); err != nil {
+				file.Close()
+				return err
+			}
+			if err := file.Close(); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(os.Stderr, "Exported %s\n", export.Summary())
+		return nil
+	},
+}
+
+// cliClient creates a client for commands other than the MCP servers, which
+// require both --server and --token.
+func cliClient() (*tools.Client, error) {
+	base := viper.GetString("server")
+	token := viper.GetString("token")
+	if base == "" || token == "" {
+		return nil, errors.New("--server and --token (or FORGEJOMCP_SERVER and FORGEJOMCP_TOKEN) are required")
+	}
+
+	cl, err := tools.NewClient(base, token, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SDK client: %w", err)
+	}
+	return cl, nil
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	f := exportCmd.Flags()
+	f.String("format", "json", "Output format: json, jsonl or csv")
+	f.StringP("output", "o", "", "Output file, - or empty for standard output")
+	f.String("state", "all", "Export only issues in this state: open, closed or all")
+	f.String("labels", "", "Export only issues with these comma-separated label names")
+	f.String("milestones", "", "Export only issues in these comma-separated milestone names")
+	f.Bool("skip-comments", false, "Leave out comments")
+	f.Bool("skip-dependencies", false, "Leave out dependency and blocking edges")
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/raohwork/forgejo-mcp/resources"
 	"github.com/raohwork/forgejo-mcp/tools"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -34,6 +35,12 @@ The server can operate in two modes:
     header of each request. This allows the server to act as a gateway for
     multiple users.
 
+Resource subscriptions are supported when --webhook-secret is set. Add a
+webhook in Forgejo (repository, organization or system wide) pointing to
+the --webhook-path endpoint with the same secret, and subscribed clients
+will receive resources/updated notifications when issues, pull requests
+or repositories change.
+
 This HTTP mode is ideal for:
   - Web-based clients and services.
   - Remote access to the Forgejo instance through MCP.
@@ -48,6 +55,8 @@ Example:
 		if addr == "" {
 			addr = ":8080"
 		}
+		secret := viper.GetString("webhook-secret")
+		hookPath := viper.GetString("webhook-path")
 
 		if base == "" {
 			cmd.Help()
@@ -66,10 +75,16 @@ Example:
 		} else {
 			cl, _ = tools.NewClient(base, "", "9", nil)
 		}
+		cl.DryRun = dryRun
+
+		var hub *resources.Hub
+		if secret != "" {
+			hub = resources.NewHub()
+		}
 
 		getServer := func(q *http.Request) *mcp.Server {
 			if singleMode {
-				return createServer(cl)
+				return createServer(cl, hub)
 			}
 
 			mycl := cl
@@ -80,16 +95,31 @@ Example:
 				}
 				c, err := tools.NewClient(base, myToken, "", nil)
 				if err == nil {
+					c.DryRun = dryRun
 					mycl = c
 				}
 			}
 
-			return createServer(mycl)
+			return createServer(mycl, hub)
 		}
 
 		mux := http.NewServeMux()
 		mux.Handle("/sse", mcp.NewSSEHandler(getServer))
 		mux.Handle("/", mcp.NewStreamableHTTPHandler(getServer, nil))
+		if hub != nil {
+			mux.Handle(hookPath, resources.WebhookHandler(hub, secret))
+			fmt.Printf("Accepting Forgejo webhooks on %s\n", hookPath)
+		}
+
+		if secretEnvPrefix != "" {
+			fmt.Printf("Warning: every client may set Actions secrets from environment variables starting with %s\n", secretEnvPrefix)
+		}
+		if secretDir != "" {
+			fmt.Printf("Warning: every client may set Actions secrets from files in %s\n", secretDir)
+		}
+		if exportDir != "" {
+			fmt.Printf("Warning: every client may read and write issue exports in %s\n", exportDir)
+		}
 
 		mode := "single"
 		if !singleMode {
@@ -109,5 +139,7 @@ func init() {
 
 	f := httpCmd.Flags()
 	f.String("address", ":8080", "Address to listen on for incoming connections")
+	f.String("webhook-secret", "", "Secret to verify Forgejo webhook deliveries, enables resource subscriptions (env: FORGEJOMCP_WEBHOOK_SECRET)")
+	f.String("webhook-path", "/webhook", "Path of the endpoint accepting Forgejo webhook deliveries")
 	viper.BindPFlags(f)
 }
@@ -0,0 +1,85 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/raohwork/forgejo-mcp/tools/issue"
+	"github.com/spf13/cobra"
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import owner/repo input",
+	Short: "Import issues into a repository",
+	Long: `Import issues into a repository, like the import_issues tool.
+
+The input is a file written by the export command (json, jsonl or csv), or a
+JSON array of GitHub issues (github) from the REST API or
+"gh issue list --json number,title,body,state,author,labels,milestone,assignees,createdAt,closedAt,comments".
+Missing labels and milestones are created, issues and comments are recreated
+with a line naming the original author and time, and dependencies are
+restored.
+
+Use --mapping to record the imported issues, so a rerun after a failure
+skips them instead of creating duplicates. Use --dry-run to preview.
+
+Example:
+  forgejo-mcp import owner/repo issues.json --mapping issues.map.json --server https://git.example.com --token your_token`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		owner, repo, ok := strings.Cut(args[0], "/")
+		if !ok || owner == "" || repo == "" {
+			return fmt.Errorf("invalid repository %q, expected owner/repo", args[0])
+		}
+
+		cl, err := cliClient()
+		if err != nil {
+			return err
+		}
+		cl.DryRun = dryRun
+
+		f := cmd.Flags()
+		p := issue.ImportIssuesParams{Owner: owner, Repo: repo, Input: args[1]}
+		p.Format, _ = f.GetString("format")
+		p.Source, _ = f.GetString("source")
+		p.Mapping, _ = f.GetString("mapping")
+
+		plan, err := issue.PlanImport(cl, p)
+		if err != nil {
+			return err
+		}
+		if cl.IsDryRun(false) {
+			fmt.Print(plan.Preview())
+			return nil
+		}
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer cancel()
+		text, err := plan.Run(ctx, cl)
+		if err != nil {
+			return err
+		}
+		fmt.Print(text)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+
+	f := importCmd.Flags()
+	f.String("format", "", "Input format: json, jsonl, csv or github (default from the file extension, or json)")
+	f.String("source", "", "The owner/repo the issues were exported from (default from json exports)")
+	f.String("mapping", "", "File recording the imported issues, reruns skip them")
+}
@@ -7,11 +7,15 @@
 package cmd
 
 import (
+	"github.com/raohwork/forgejo-mcp/completion"
+	"github.com/raohwork/forgejo-mcp/prompts"
+	"github.com/raohwork/forgejo-mcp/resources"
 	"github.com/raohwork/forgejo-mcp/tools"
 	"github.com/raohwork/forgejo-mcp/tools/action"
 	"github.com/raohwork/forgejo-mcp/tools/issue"
 	"github.com/raohwork/forgejo-mcp/tools/label"
 	"github.com/raohwork/forgejo-mcp/tools/milestone"
+	"github.com/raohwork/forgejo-mcp/tools/notification"
 	"github.com/raohwork/forgejo-mcp/tools/pullreq"
 	"github.com/raohwork/forgejo-mcp/tools/release"
 	"github.com/raohwork/forgejo-mcp/tools/repo"
@@ -27,11 +31,18 @@ func registerCommands(s *mcp.Server, cl *tools.Client) {
 	tools.Register(s, &issue.GetIssueImpl{Client: cl})
 	tools.Register(s, &issue.CreateIssueImpl{Client: cl})
 	tools.Register(s, &issue.EditIssueImpl{Client: cl})
+	tools.Register(s, &issue.BulkEditIssuesImpl{Client: cl})
+	tools.Register(s, &issue.MoveIssueImpl{Client: cl})
+	tools.Register(s, &issue.ExportIssuesImpl{Client: cl, Dir: exportDir})
+	tools.Register(s, &issue.ImportIssuesImpl{Client: cl, Dir: exportDir})
+	tools.Register(s, &issue.SearchIssuesImpl{Client: cl})
+	tools.Register(s, &issue.GetIssueTimelineImpl{Client: cl})
+	tools.Register(s, &issue.ListIssueTemplatesImpl{Client: cl})
 
 	// Issue label tools
 	tools.Register(s, &issue.AddIssueLabelsImpl{Client: cl})
 	tools.Register(s, &issue.RemoveIssueLabelImpl{Client: cl})
-	tools.Register(s, &issue.ReplaceIssueLabelsImpl{Client: cl})
+	tools.Register(s, &issue.ReplaceIssueLabelsImpl{Client: cl, Confirm: confirmer})
 
 	// Issue comment tools
 	tools.Register(s, &issue.ListIssueCommentsImpl{Client: cl})
@@ -53,24 +64,55 @@ func registerCommands(s *mcp.Server, cl *tools.Client) {
 	tools.Register(s, &issue.ListIssueBlockingImpl{Client: cl})
 	tools.Register(s, &issue.AddIssueBlockingImpl{Client: cl})
 	tools.Register(s, &issue.RemoveIssueBlockingImpl{Client: cl})
+	tools.Register(s, &issue.GetDependencyGraphImpl{Client: cl})
+
+	// Issue lock and pin tools
+	tools.Register(s, &issue.LockIssueImpl{Client: cl})
+	tools.Register(s, &issue.UnlockIssueImpl{Client: cl})
+	tools.Register(s, &issue.ListPinnedIssuesImpl{Client: cl})
+	tools.Register(s, &issue.PinIssueImpl{Client: cl})
+	tools.Register(s, &issue.UnpinIssueImpl{Client: cl})
+	tools.Register(s, &issue.MoveIssuePinImpl{Client: cl})
+
+	// Issue subscription tools
+	tools.Register(s, &issue.ListIssueSubscribersImpl{Client: cl})
+	tools.Register(s, &issue.CheckIssueSubscriptionImpl{Client: cl})
+	tools.Register(s, &issue.SubscribeIssueImpl{Client: cl})
+	tools.Register(s, &issue.UnsubscribeIssueImpl{Client: cl})
+
+	// Issue reaction tools
+	tools.Register(s, &issue.ListIssueReactionsImpl{Client: cl})
+	tools.Register(s, &issue.AddIssueReactionImpl{Client: cl})
+	tools.Register(s, &issue.RemoveIssueReactionImpl{Client: cl})
+	tools.Register(s, &issue.ListMostUpvotedIssuesImpl{Client: cl})
+
+	// Issue time tracking tools
+	tools.Register(s, &issue.ListTrackedTimesImpl{Client: cl})
+	tools.Register(s, &issue.AddTrackedTimeImpl{Client: cl})
+	tools.Register(s, &issue.DeleteTrackedTimeImpl{Client: cl})
+	tools.Register(s, &issue.ListMyStopwatchesImpl{Client: cl})
+	tools.Register(s, &issue.StartIssueStopwatchImpl{Client: cl})
+	tools.Register(s, &issue.StopIssueStopwatchImpl{Client: cl})
+	tools.Register(s, &issue.CancelIssueStopwatchImpl{Client: cl})
+	tools.Register(s, &issue.GetMilestoneTimeReportImpl{Client: cl})
 
 	// Label tools
 	tools.Register(s, &label.ListRepoLabelsImpl{Client: cl})
 	tools.Register(s, &label.CreateLabelImpl{Client: cl})
 	tools.Register(s, &label.EditLabelImpl{Client: cl})
-	tools.Register(s, &label.DeleteLabelImpl{Client: cl})
+	tools.Register(s, &label.DeleteLabelImpl{Client: cl, Confirm: confirmer})
 
 	// Milestone tools
 	tools.Register(s, &milestone.ListRepoMilestonesImpl{Client: cl})
 	tools.Register(s, &milestone.CreateMilestoneImpl{Client: cl})
 	tools.Register(s, &milestone.EditMilestoneImpl{Client: cl})
-	tools.Register(s, &milestone.DeleteMilestoneImpl{Client: cl})
+	tools.Register(s, &milestone.DeleteMilestoneImpl{Client: cl, Confirm: confirmer})
 
 	// Release tools
 	tools.Register(s, &release.ListReleasesImpl{Client: cl})
 	tools.Register(s, &release.CreateReleaseImpl{Client: cl})
 	tools.Register(s, &release.EditReleaseImpl{Client: cl})
-	tools.Register(s, &release.DeleteReleaseImpl{Client: cl})
+	tools.Register(s, &release.DeleteReleaseImpl{Client: cl, Confirm: confirmer})
 
 	// Release attachment tools
 	tools.Register(s, &release.ListReleaseAttachmentsImpl{Client: cl})
@@ -87,27 +129,103 @@ func registerCommands(s *mcp.Server, cl *tools.Client) {
 	tools.Register(s, &repo.ListMyRepositoriesImpl{Client: cl})
 	tools.Register(s, &repo.ListOrgRepositoriesImpl{Client: cl})
 	tools.Register(s, &repo.GetRepositoryImpl{Client: cl})
+	tools.Register(s, &repo.CheckRepoWatchImpl{Client: cl})
+	tools.Register(s, &repo.WatchRepoImpl{Client: cl})
+	tools.Register(s, &repo.UnwatchRepoImpl{Client: cl})
+
+	// Notification tools
+	tools.Register(s, &notification.ListNotificationsImpl{Client: cl})
+	tools.Register(s, &notification.GetNotificationThreadImpl{Client: cl})
+	tools.Register(s, &notification.MarkNotificationThreadImpl{Client: cl})
+	tools.Register(s, &notification.MarkNotificationsImpl{Client: cl})
 
 	// Wiki tools
 	tools.Register(s, &wiki.GetWikiPageImpl{Client: cl})
 	tools.Register(s, &wiki.CreateWikiPageImpl{Client: cl})
 	tools.Register(s, &wiki.EditWikiPageImpl{Client: cl})
-	tools.Register(s, &wiki.DeleteWikiPageImpl{Client: cl})
+	tools.Register(s, &wiki.DeleteWikiPageImpl{Client: cl, Confirm: confirmer})
 	tools.Register(s, &wiki.ListWikiPagesImpl{Client: cl})
 
 	// Action tools
 	tools.Register(s, &action.ListActionTasksImpl{Client: cl})
+	tools.Register(s, &action.ListActionRunsImpl{Client: cl})
+	tools.Register(s, &action.ListActionRunJobsImpl{Client: cl})
+	tools.Register(s, &action.GetActionJobLogImpl{Client: cl})
+	tools.Register(s, &action.DispatchWorkflowImpl{Client: cl})
+	tools.Register(s, &action.RerunActionRunImpl{Client: cl})
+	tools.Register(s, &action.CancelActionRunImpl{Client: cl})
+	tools.Register(s, &action.ListActionSecretsImpl{Client: cl})
+	if secretEnvPrefix != "" || secretDir != "" {
+		tools.Register(s, &action.SetActionSecretImpl{Client: cl, EnvPrefix: secretEnvPrefix, Dir: secretDir})
+	}
+	tools.Register(s, &action.DeleteActionSecretImpl{Client: cl, Confirm: confirmer})
+	tools.Register(s, &action.ListActionVariablesImpl{Client: cl})
+	tools.Register(s, &action.GetActionVariableImpl{Client: cl})
+	tools.Register(s, &action.CreateActionVariableImpl{Client: cl})
+	tools.Register(s, &action.UpdateActionVariableImpl{Client: cl})
+	tools.Register(s, &action.DeleteActionVariableImpl{Client: cl, Confirm: confirmer})
+	tools.Register(s, &action.ListActionArtifactsImpl{Client: cl})
+	tools.Register(s, &action.GetActionArtifactImpl{Client: cl})
+	tools.Register(s, &action.ListActionRunnersImpl{Client: cl})
+	tools.Register(s, &action.GetRunnerRegistrationTokenImpl{Client: cl})
+	tools.Register(s, &action.DeleteActionRunnerImpl{Client: cl, Confirm: confirmer})
+	tools.Register(s, &action.SummarizeCIFailuresImpl{Client: cl})
+	tools.Register(s, &action.DetectFlakyWorkflowsImpl{Client: cl})
 }
 
-func createServer(cl *tools.Client) *mcp.Server {
-	server := mcp.NewServer(&mcp.Implementation{
-		Title:   "Forgejo MCP Server",
-		Version: types.VERSION[1:], // strip leading 'v'
-	}, &mcp.ServerOptions{
+// customPrompts holds the prompt templates loaded from --prompts-dir.
+var customPrompts []*prompts.Template
+
+// dryRun forces every mutating tool into dry-run mode, see --dry-run.
+var dryRun bool
+
+// secretEnvPrefix and secretDir restrict the host environment variables and
+// files set_action_secret may read values from. The tool is only available if
+// one of them is set, see --secret-env-prefix and --secret-dir.
+var secretEnvPrefix, secretDir string
+
+// exportDir is the directory export_issues may write files to and
+// import_issues may read inputs from and keep mapping files in, see
+// --export-dir. Empty means the tools only exchange data with the client.
+var exportDir string
+
+// confirmer asks for confirmation before destructive operations. It is nil
+// unless --confirm-destructive is set.
+var confirmer *tools.Confirmer
+
+func registerPrompts(s *mcp.Server, cl *tools.Client) {
+	prompts.Register(s, &prompts.TriageIssuesImpl{Client: cl})
+	prompts.Register(s, &prompts.ReviewPullRequestImpl{Client: cl})
+	prompts.Register(s, &prompts.DraftReleaseNotesImpl{Client: cl})
+	prompts.Register(s, &prompts.SummarizeMilestoneImpl{Client: cl})
+	prompts.Register(s, &prompts.BugReportImpl{Client: cl})
+
+	// Custom prompts are registered last so they can override built-in ones
+	for _, t := range customPrompts {
+		prompts.Register(s, &prompts.CustomImpl{Template: t, Client: cl})
+	}
+}
+
+// createServer creates an MCP server backed by cl. If hub is not nil, the
+// server accepts resource subscriptions and reports them to the hub.
+func createServer(cl *tools.Client, hub *resources.Hub) *mcp.Server {
+	opts := &mcp.ServerOptions{
 		PageSize:     50,
 		Instructions: "An MCP server to interact with repositories on a Forgejo/Gitea instance.",
-	})
+		// every session has its own server, so the completion cache is per session
+		CompletionHandler: completion.New(cl).Complete,
+	}
+	var server *mcp.Server
+	if hub != nil {
+		hub.Bind(opts, &server)
+	}
+	server = mcp.NewServer(&mcp.Implementation{
+		Title:   "Forgejo MCP Server",
+		Version: types.VERSION[1:], // strip leading 'v'
+	}, opts)
 	registerCommands(server, cl)
+	registerPrompts(server, cl)
+	resources.Register(server, cl)
 
 	return server
 }
@@ -7,8 +7,12 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
+	"strings"
 
+	"github.com/raohwork/forgejo-mcp/prompts"
+	"github.com/raohwork/forgejo-mcp/tools"
 	"github.com/raohwork/forgejo-mcp/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -25,7 +29,7 @@ var rootCmd = &cobra.Command{
 for managing Gitea/Forgejo repositories through MCP-compatible clients.
 
 Supported operations:
-  - Issues (create, edit, comment, close, manage attachments, dependencies/blocking)
+  - Issues (create, edit, comment, close, manage attachments, dependencies/blocking, dependency graphs, export, import)
   - Labels (list, create, edit, delete)
   - Milestones (list, create, edit, delete)
   - Releases (list, create, edit, delete, manage attachments)
@@ -34,6 +38,10 @@ Supported operations:
   - Wiki pages (create, edit, delete, list)
   - Forgejo Actions tasks (list)
 
+Prompts for common workflows (triage issues, review pull requests, draft
+release notes, summarize milestones, write bug reports) are built in.
+Additional prompt templates can be loaded with --prompts-dir.
+
 Available transport modes:
   - stdio: Standard input/output (best for local integration)
   - http: HTTP server with SSE and Streamable HTTP support (best for web apps and remote access)
@@ -44,6 +52,26 @@ Configure your Forgejo instance:
 Environment variables (alternative to command line arguments):
   FORGEJOMCP_SERVER - Forgejo server URL
   FORGEJOMCP_TOKEN  - Access token`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if viper.GetBool("confirm-destructive") {
+			confirmer = tools.NewConfirmer()
+		}
+		dryRun = viper.GetBool("dry-run")
+		secretEnvPrefix = viper.GetString("secret-env-prefix")
+		secretDir = viper.GetString("secret-dir")
+		exportDir = viper.GetString("export-dir")
+
+		dir := viper.GetString("prompts-dir")
+		if dir == "" {
+			return nil
+		}
+		t, err := prompts.LoadDir(dir)
+		if err != nil {
+			return fmt.Errorf("failed to load prompts: %w", err)
+		}
+		customPrompts = t
+		return nil
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -59,8 +87,15 @@ func init() {
 	f := rootCmd.PersistentFlags()
 	f.String("server", "", "Forgejo server URL (env: FORGEJOMCP_SERVER)")
 	f.String("token", "", "Forgejo access token (env: FORGEJOMCP_TOKEN)")
+	f.String("prompts-dir", "", "Directory of custom prompt templates (*.md) (env: FORGEJOMCP_PROMPTS_DIR)")
+	f.Bool("dry-run", false, "Preview changes of mutating tools without sending them (env: FORGEJOMCP_DRY_RUN)")
+	f.String("secret-env-prefix", "", "Let set_action_secret read values from environment variables with this prefix, in http mode for every client (env: FORGEJOMCP_SECRET_ENV_PREFIX)")
+	f.String("secret-dir", "", "Let set_action_secret read values from files in this directory, in http mode for every client (env: FORGEJOMCP_SECRET_DIR)")
+	f.String("export-dir", "", "Let export_issues and import_issues read and write files in this directory, in http mode for every client (env: FORGEJOMCP_EXPORT_DIR)")
+	f.Bool("confirm-destructive", false, "Ask for confirmation before deleting labels, milestones, releases, wiki pages or replacing issue labels (env: FORGEJOMCP_CONFIRM_DESTRUCTIVE)")
 	viper.BindPFlags(f)
 
 	viper.SetEnvPrefix("FORGEJOMCP")
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 	viper.AutomaticEnv()
 }
@@ -10,8 +10,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/raohwork/forgejo-mcp/resources"
 	"github.com/raohwork/forgejo-mcp/tools"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -29,6 +31,10 @@ This transport mode is ideal for:
   - Direct process communication
   - Applications that can spawn child processes
 
+Resource subscriptions are served by polling Forgejo every --poll-interval
+for issues, pull requests and repositories updated since the previous
+round. Set --poll-interval to 0 to disable subscriptions.
+
 Example:
   forgejo-mcp stdio --server https://git.example.com --token your_token`,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -45,9 +51,19 @@ Example:
 			fmt.Fprintf(os.Stderr, "Error creating SDK client: %v\n", err)
 			os.Exit(1)
 		}
+		cl.DryRun = dryRun
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
 
-		server := createServer(cl)
-		err = server.Run(context.TODO(), mcp.NewStdioTransport())
+		var hub *resources.Hub
+		if interval := viper.GetDuration("poll-interval"); interval > 0 {
+			hub = resources.NewHub()
+			go hub.Poll(ctx, cl, interval)
+		}
+
+		server := createServer(cl, hub)
+		err = server.Run(ctx, mcp.NewStdioTransport())
 		fmt.Fprintf(os.Stderr, "Server exited with error: %v\n", err)
 		if err != nil {
 			os.Exit(1)
@@ -57,4 +73,8 @@ Example:
 
 func init() {
 	rootCmd.AddCommand(stdioCmd)
+
+	f := stdioCmd.Flags()
+	f.Duration("poll-interval", time.Minute, "Interval to poll Forgejo for changes of subscribed resources, 0 to disable (env: FORGEJOMCP_POLL_INTERVAL)")
+	viper.BindPFlags(f)
 }
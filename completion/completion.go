@@ -0,0 +1,430 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package completion
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+)
+
+const (
+	// maxValues is the maximum number of suggestions allowed by MCP.
+	maxValues = 100
+	// pageSize and maxPages bound how many items are fetched for a list.
+	pageSize = 50
+	maxPages = 10
+	// cacheTTL is how long fetched lists are reused.
+	cacheTTL = time.Minute
+)
+
+// kind is a category of completable values.
+type kind int
+
+const (
+	kindNone kind = iota
+	kindOwner
+	kindRepo
+	kindLabel
+	kindMilestone
+	kindBranch
+	kindTag
+	kindUser
+	kindWikiPage
+)
+
+// argKinds maps argument names to the kind of value they hold. Names are
+// shared by tools, built-in prompts and resource templates, and custom prompt
+// templates are expected to follow the same naming.
+var argKinds = map[string]kind{
+	"owner":      kindOwner,
+	"org":        kindOwner,
+	"repo":       kindRepo,
+	"label":      kindLabel,
+	"labels":     kindLabel,
+	"milestone":  kindMilestone,
+	"milestones": kindMilestone,
+	"branch":     kindBranch,
+	"base":       kindBranch,
+	"head":       kindBranch,
+	"ref":        kindBranch,
+	"target":     kindBranch,
+	"tag":        kindTag,
+	"user":       kindUser,
+	"username":   kindUser,
+	"assignee":   kindUser,
+	"assignees":  kindUser,
+	"reviewer":   kindUser,
+	"page_name":  kindWikiPage,
+	"wiki_page":  kindWikiPage,
+}
+
+// promptArgKinds overrides argKinds for arguments of specific prompts whose
+// names are too generic, keyed by "prompt/argument".
+var promptArgKinds = map[string]kind{
+	"summarize_milestone/name": kindMilestone,
+}
+
+// Completer answers completion requests using a Forgejo client. It keeps a
+// small cache of fetched lists; as every MCP session gets its own server and
+// thus its own Completer, the cache is per session.
+type Completer struct {
+	Client *tools.Client
+	cache  *cache
+}
+
+// New creates a Completer using cl.
+func New(cl *tools.Client) *Completer {
+	return &Completer{Client: cl, cache: newCache(cacheTTL)}
+}
+
+// Complete implements mcp.ServerOptions.CompletionHandler. Unknown arguments
+// and failures to fetch candidates result in an empty suggestion list rather
+// than an error, so clients can keep accepting free text.
+func (c *Completer) Complete(ctx context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	p := req.Params
+	k := argKinds[p.Argument.Name]
+	if p.Ref != nil && p.Ref.Type == "ref/prompt" {
+		if pk, ok := promptArgKinds[p.Ref.Name+"/"+p.Argument.Name]; ok {
+			k = pk
+		}
+	}
+
+	var args map[string]string
+	if p.Context != nil {
+		args = p.Context.Arguments
+	}
+
+	// list arguments are comma separated, only the last item is completed
+	prefix, value := "", p.Argument.Value
+	if i := strings.LastIndex(value, ","); i >= 0 {
+		prefix, value = value[:i+1], strings.TrimLeft(value[i+1:], " ")
+	}
+
+	candidates, err := c.candidates(k, args["owner"], args["repo"], value)
+	if err != nil {
+		candidates = nil
+	}
+
+	values := match(candidates, value)
+	total := len(values)
+	if len(values) > maxValues {
+		values = values[:maxValues]
+	}
+	for i, v := range values {
+		values[i] = prefix + v
+	}
+
+	return &mcp.CompleteResult{
+		Completion: mcp.CompletionResultDetails{
+			Values:  values,
+			Total:   total,
+			HasMore: total > len(values),
+		},
+	}, nil
+}
+
+// candidates returns all known values of kind k. Repository-scoped kinds
+// need both owner and repo.
+func (c *Completer) candidates(k kind, owner, repo, value string) ([]string, error) {
+	scoped := owner != "" && repo != ""
+	switch k {
+	case kindOwner:
+		return c.owners(value)
+	case kindRepo:
+		return c.repos(owner, value)
+	case kindLabel:
+		if scoped {
+			return c.labels(owner, repo)
+		}
+	case kindMilestone:
+		if scoped {
+			return c.milestones(owner, repo)
+		}
+	case kindBranch:
+		if scoped {
+			return c.branches(owner, repo)
+		}
+	case kindTag:
+		if scoped {
+			return c.tags(owner, repo)
+		}
+	case kindUser:
+		if scoped {
+			return c.assignees(owner, repo)
+		}
+		return c.users(value)
+	case kindWikiPage:
+		if scoped {
+			return c.wikiPages(owner, repo)
+		}
+	}
+	return nil, nil
+}
+
+// collect fetches pages of items until a short page or maxPages is reached.
+func collect(fetch func(opt forgejo.ListOptions) ([]string, error)) ([]string, error) {
+	var ret []string
+	for page := 1; page <= maxPages; page++ {
+		items, err := fetch(forgejo.ListOptions{Page: page, PageSize: pageSize})
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, items...)
+		if len(items) < pageSize {
+			break
+		}
+	}
+	return ret, nil
+}
+
+// owners suggests the current user, the organizations they belong to and
+// owners of their repositories. Other users are searched by keyword when
+// at least two characters are typed.
+func (c *Completer) owners(value string) ([]string, error) {
+	known, err := c.cache.get("owners", func() ([]string, error) {
+		var ret []string
+		if me, _, err := c.Client.GetMyUserInfo(); err == nil {
+			ret = append(ret, me.UserName)
+		}
+		orgs, err := collect(func(opt forgejo.ListOptions) ([]string, error) {
+			list, _, err := c.Client.ListMyOrgs(forgejo.ListOrgsOptions{ListOptions: opt})
+			names := make([]string, 0, len(list))
+			for _, o := range list {
+				names = append(names, o.UserName)
+			}
+			return names, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, orgs...)
+
+		repos, err := c.myRepos()
+		if err != nil {
+			return nil, err
+		}
+		for _, full := range repos {
+			owner, _, _ := strings.Cut(full, "/")
+			ret = append(ret, owner)
+		}
+		return uniq(ret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(value) < 2 || len(match(known, value)) > 0 {
+		return known, nil
+	}
+
+	return c.users(value)
+}
+
+// myRepos lists full names of repositories of the current user.
+func (c *Completer) myRepos() ([]string, error) {
+	return c.cache.get("myrepos", func() ([]string, error) {
+		return collect(func(opt forgejo.ListOptions) ([]string, error) {
+			list, _, err := c.Client.ListMyRepos(forgejo.ListReposOptions{ListOptions: opt})
+			names := make([]string, 0, len(list))
+			for _, r := range list {
+				names = append(names, r.FullName)
+			}
+			return names, err
+		})
+	})
+}
+
+// repos searches the repositories visible to the current user, including
+// public and organization repositories, by the typed value. It suggests
+// repository names of owner, or "owner/name" when owner is not known yet.
+func (c *Completer) repos(owner, value string) ([]string, error) {
+	keyword := strings.ToLower(value)
+	if _, name, ok := strings.Cut(keyword, "/"); ok && owner == "" {
+		keyword = name
+	}
+
+	var ownerID int64
+	if owner != "" {
+		id, err := c.cache.get("owner:"+owner, func() ([]string, error) {
+			u, _, err := c.Client.GetUserInfo(owner)
+			if err != nil {
+				return nil, err
+			}
+			return []string{strconv.FormatInt(u.ID, 10)}, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		ownerID, _ = strconv.ParseInt(id[0], 10, 64)
+	}
+
+	return c.cache.get("repos:"+owner+":"+keyword, func() ([]string, error) {
+		return collect(func(opt forgejo.ListOptions) ([]string, error) {
+			list, _, err := c.Client.SearchRepos(forgejo.SearchRepoOptions{
+				ListOptions: opt,
+				Keyword:     keyword,
+				OwnerID:     ownerID,
+			})
+			names := make([]string, 0, len(list))
+			for _, r := range list {
+				if owner != "" {
+					names = append(names, r.Name)
+				} else {
+					names = append(names, r.FullName)
+				}
+			}
+			return names, err
+		})
+	})
+}
+
+func (c *Completer) labels(owner, repo string) ([]string, error) {
+	return c.cache.get("labels:"+owner+"/"+repo, func() ([]string, error) {
+		return collect(func(opt forgejo.ListOptions) ([]string, error) {
+			list, _, err := c.Client.ListRepoLabels(owner, repo, forgejo.ListLabelsOptions{ListOptions: opt})
+			names := make([]string, 0, len(list))
+			for _, l := range list {
+				names = append(names, l.Name)
+			}
+			return names, err
+		})
+	})
+}
+
+func (c *Completer) milestones(owner, repo string) ([]string, error) {
+	return c.cache.get("milestones:"+owner+"/"+repo, func() ([]string, error) {
+		return collect(func(opt forgejo.ListOptions) ([]string, error) {
+			list, _, err := c.Client.ListRepoMilestones(owner, repo, forgejo.ListMilestoneOption{
+				ListOptions: opt,
+				State:       forgejo.StateAll,
+			})
+			names := make([]string, 0, len(list))
+			for _, m := range list {
+				names = append(names, m.Title)
+			}
+			return names, err
+		})
+	})
+}
+
+func (c *Completer) branches(owner, repo string) ([]string, error) {
+	return c.cache.get("branches:"+owner+"/"+repo, func() ([]string, error) {
+		return collect(func(opt forgejo.ListOptions) ([]string, error) {
+			list, _, err := c.Client.ListRepoBranches(owner, repo, forgejo.ListRepoBranchesOptions{ListOptions: opt})
+			names := make([]string, 0, len(list))
+			for _, b := range list {
+				names = append(names, b.Name)
+			}
+			return names, err
+		})
+	})
+}
+
+func (c *Completer) tags(owner, repo string) ([]string, error) {
+	return c.cache.get("tags:"+owner+"/"+repo, func() ([]string, error) {
+		return collect(func(opt forgejo.ListOptions) ([]string, error) {
+			list, _, err := c.Client.ListRepoTags(owner, repo, forgejo.ListRepoTagsOptions{ListOptions: opt})
+			names := make([]string, 0, len(list))
+			for _, t := range list {
+				names = append(names, t.Name)
+			}
+			return names, err
+		})
+	})
+}
+
+// assignees suggests users who can be assigned to issues of the repository.
+func (c *Completer) assignees(owner, repo string) ([]string, error) {
+	return c.cache.get("assignees:"+owner+"/"+repo, func() ([]string, error) {
+		list, _, err := c.Client.GetAssignees(owner, repo)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(list))
+		for _, u := range list {
+			names = append(names, u.UserName)
+		}
+		return names, nil
+	})
+}
+
+// users searches users by keyword. Nothing is suggested for less than two
+// characters, as that would list nearly every user of the instance.
+func (c *Completer) users(value string) ([]string, error) {
+	if len(value) < 2 {
+		return nil, nil
+	}
+	key := strings.ToLower(value)
+	return c.cache.get("users:"+key, func() ([]string, error) {
+		list, _, err := c.Client.SearchUsers(forgejo.SearchUsersOption{
+			ListOptions: forgejo.ListOptions{PageSize: pageSize},
+			KeyWord:     key,
+		})
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(list))
+		for _, u := range list {
+			names = append(names, u.UserName)
+		}
+		return names, nil
+	})
+}
+
+func (c *Completer) wikiPages(owner, repo string) ([]string, error) {
+	return c.cache.get("wiki:"+owner+"/"+repo, func() ([]string, error) {
+		list, err := c.Client.MyListWikiPages(owner, repo)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(list))
+		for _, p := range list {
+			names = append(names, p.Title)
+		}
+		return names, nil
+	})
+}
+
+// match filters candidates by value, case-insensitively. Prefix matches come
+// first, followed by other substring matches, each group sorted.
+func match(candidates []string, value string) []string {
+	needle := strings.ToLower(value)
+	var prefix, contains []string
+	for _, c := range uniq(candidates) {
+		l := strings.ToLower(c)
+		switch {
+		case strings.HasPrefix(l, needle):
+			prefix = append(prefix, c)
+		case strings.Contains(l, needle):
+			contains = append(contains, c)
+		}
+	}
+	slices.Sort(prefix)
+	slices.Sort(contains)
+	return append(prefix, contains...)
+}
+
+// uniq removes duplicated and empty strings, keeping the first occurrence.
+func uniq(list []string) []string {
+	seen := make(map[string]bool, len(list))
+	ret := make([]string, 0, len(list))
+	for _, s := range list {
+		if s == "" || seen[s] {
+			continue
+		}
+		seen[s] = true
+		ret = append(ret, s)
+	}
+	return ret
+}
@@ -0,0 +1,155 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package completion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+)
+
+func TestMatch(t *testing.T) {
+	candidates := []string{"bug", "Bugfix", "debug", "feature", "bug", ""}
+
+	tests := []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{name: "empty value matches all", value: "", want: []string{"Bugfix", "bug", "debug", "feature"}},
+		{name: "prefix before substring", value: "bug", want: []string{"Bugfix", "bug", "debug"}},
+		{name: "case insensitive", value: "FEA", want: []string{"feature"}},
+		{name: "no match", value: "xyz", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := match(candidates, tt.value)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCache(t *testing.T) {
+	now := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+	c := newCache(time.Minute)
+	c.now = func() time.Time { return now }
+
+	calls := 0
+	fetch := func() ([]string, error) {
+		calls++
+		return []string{"a"}, nil
+	}
+
+	c.get("k", fetch)
+	c.get("k", fetch)
+	if calls != 1 {
+		t.Errorf("Expected cached value to be reused, fetched %d times", calls)
+	}
+
+	now = now.Add(2 * time.Minute)
+	c.get("k", fetch)
+	if calls != 2 {
+		t.Errorf("Expected expired value to be fetched again, fetched %d times", calls)
+	}
+
+	if _, err := c.get("err", func() ([]string, error) { return nil, errors.New("boom") }); err == nil {
+		t.Error("Expected error to be returned")
+	}
+	if _, ok := c.entries["err"]; ok {
+		t.Error("Expected errors not to be cached")
+	}
+}
+
+func TestCompleter_Complete(t *testing.T) {
+	requests := 0
+	var searches []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/users/org":
+			json.NewEncoder(w).Encode(map[string]any{"id": 7, "login": "org"})
+			return
+		case "/api/v1/repos/search":
+			searches = append(searches, r.URL.Query().Get("q")+" uid="+r.URL.Query().Get("uid"))
+			json.NewEncoder(w).Encode(map[string]any{"ok": true, "data": []map[string]any{
+				{"name": "forgejo-mcp", "full_name": "org/forgejo-mcp"},
+			}})
+			return
+		case "/api/v1/repos/owner/repo/labels":
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"id": 1, "name": "bug"},
+			{"id": 2, "name": "feature"},
+			{"id": 3, "name": "priority/high"},
+		})
+	}))
+	defer server.Close()
+
+	cl, err := tools.NewClient(server.URL, "test-token", "11.0.1+gitea-1.22.0", server.Client())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c := New(cl)
+
+	complete := func(arg, value string, ctxArgs map[string]string) []string {
+		res, err := c.Complete(context.Background(), &mcp.CompleteRequest{
+			Params: &mcp.CompleteParams{
+				Ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "custom"},
+				Argument: mcp.CompleteParamsArgument{Name: arg, Value: value},
+				Context:  &mcp.CompleteContext{Arguments: ctxArgs},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return res.Completion.Values
+	}
+	repo := map[string]string{"owner": "owner", "repo": "repo"}
+
+	if got := complete("labels", "b", repo); !slices.Equal(got, []string{"bug"}) {
+		t.Errorf("Expected [bug], got %v", got)
+	}
+	if got := complete("labels", "bug, pri", repo); !slices.Equal(got, []string{"bug,priority/high"}) {
+		t.Errorf("Expected completion of last list item, got %v", got)
+	}
+	if requests != 1 {
+		t.Errorf("Expected labels to be fetched once, got %d requests", requests)
+	}
+	// repositories are searched by the typed value, also those of others
+	if got := complete("repo", "forgejo", map[string]string{"owner": "org"}); !slices.Equal(got, []string{"forgejo-mcp"}) {
+		t.Errorf("Expected [forgejo-mcp], got %v", got)
+	}
+	if got := complete("repo", "org/forg", nil); !slices.Equal(got, []string{"org/forgejo-mcp"}) {
+		t.Errorf("Expected [org/forgejo-mcp], got %v", got)
+	}
+	if want := []string{"forgejo uid=7", "forg uid="}; !slices.Equal(searches, want) {
+		t.Errorf("Expected searches %v, got %v", want, searches)
+	}
+
+	if got := complete("labels", "b", nil); len(got) != 0 {
+		t.Errorf("Expected no suggestion without repository context, got %v", got)
+	}
+	if got := complete("stack_trace", "b", repo); len(got) != 0 {
+		t.Errorf("Expected no suggestion for unknown argument, got %v", got)
+	}
+}
@@ -0,0 +1,8 @@
+// Package completion implements the MCP `completion/complete` request for
+// prompt and resource template arguments.
+//
+// Suggestions are chosen by argument name: repository owners and names,
+// labels, milestones, branches, tags, usernames and wiki page titles are
+// fetched from Forgejo and cached for a short time, so completing while
+// typing does not hit the server on every keystroke.
+package completion
@@ -0,0 +1,361 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/tools/issue"
+	"github.com/raohwork/forgejo-mcp/tools/label"
+	"github.com/raohwork/forgejo-mcp/tools/milestone"
+	"github.com/raohwork/forgejo-mcp/tools/pullreq"
+)
+
+// maxDiffSize limits the size of the pull request diff embedded in prompts.
+const maxDiffSize = 64 << 10
+
+// TriageIssuesImpl implements the `triage_issues` prompt. It pre-loads recent
+// open issues together with the labels and milestones available for them.
+type TriageIssuesImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `triage_issues` prompt.
+func (TriageIssuesImpl) Definition() *mcp.Prompt {
+	return &mcp.Prompt{
+		Name:        "triage_issues",
+		Title:       "Triage New Issues",
+		Description: "Triage new issues in {owner}/{repo}: suggest labels, milestones, priority and duplicates.",
+		Arguments: withRepoArgs(&mcp.PromptArgument{
+			Name:        "limit",
+			Title:       "Limit",
+			Description: "Number of most recent open issues to triage (optional, defaults to 20, max 50)",
+		}),
+	}
+}
+
+// Handler pre-loads issues with `list_repo_issues`, labels with
+// `list_repo_labels` and milestones with `list_repo_milestones`.
+func (impl TriageIssuesImpl) Handler() mcp.PromptHandler {
+	return func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args, err := requireArgs(req, "owner", "repo")
+		if err != nil {
+			return nil, err
+		}
+		owner, repo := args[0], args[1]
+
+		limit := 20
+		if v := req.Params.Arguments["limit"]; v != "" {
+			if limit, err = strconv.Atoi(v); err != nil || limit < 1 || limit > 50 {
+				return nil, fmt.Errorf("invalid limit %q: must be an integer between 1 and 50", v)
+			}
+		}
+
+		issues, err := run(ctx, issue.ListRepoIssuesImpl{Client: impl.Client}, issue.ListRepoIssuesParams{
+			Owner: owner, Repo: repo, State: "open", Sort: "created", Order: "desc", Limit: limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		labels, err := run(ctx, label.ListRepoLabelsImpl{Client: impl.Client}, label.ListRepoLabelsParams{
+			Owner: owner, Repo: repo,
+		})
+		if err != nil {
+			return nil, err
+		}
+		milestones, err := run(ctx, milestone.ListRepoMilestonesImpl{Client: impl.Client}, milestone.ListRepoMilestonesParams{
+			Owner: owner, Repo: repo, State: "open",
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		text := fmt.Sprintf(`Triage the newest open issues of %s/%s.
+
+For each issue:
+1. Suggest labels, using only the existing labels listed below.
+2. Suggest a milestone if one fits.
+3. Estimate the priority (critical, high, medium, low) with a one-line reason.
+4. Point out possible duplicates among the listed issues.
+5. Note issues that lack information needed to act on them.
+
+Summarize the result as a table, then ask before applying any change with the issue tools.
+
+`, owner, repo)
+		text += section("Open issues", issues)
+		text += section("Available labels", labels)
+		text += section("Open milestones", milestones)
+
+		return userPrompt(fmt.Sprintf("Triage new issues in %s/%s", owner, repo), text), nil
+	}
+}
+
+// ReviewPullRequestImpl implements the `review_pull_request` prompt. It
+// pre-loads the pull request, its discussion and its diff.
+type ReviewPullRequestImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `review_pull_request` prompt.
+func (ReviewPullRequestImpl) Definition() *mcp.Prompt {
+	return &mcp.Prompt{
+		Name:        "review_pull_request",
+		Title:       "Review Pull Request",
+		Description: "Review pull request #{index} in {owner}/{repo}.",
+		Arguments: withRepoArgs(&mcp.PromptArgument{
+			Name:        "index",
+			Title:       "Pull Request",
+			Description: "Pull request index number",
+			Required:    true,
+		}),
+	}
+}
+
+// Handler pre-loads the pull request with `get_pull_request`, the discussion
+// with `list_issue_comments` and the diff from the Forgejo API.
+func (impl ReviewPullRequestImpl) Handler() mcp.PromptHandler {
+	return func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args, err := requireArgs(req, "owner", "repo", "index")
+		if err != nil {
+			return nil, err
+		}
+		owner, repo := args[0], args[1]
+		index, err := strconv.Atoi(args[2])
+		if err != nil || index < 1 {
+			return nil, fmt.Errorf("invalid pull request index %q", args[2])
+		}
+
+		pr, err := run(ctx, pullreq.GetPullRequestImpl{Client: impl.Client}, pullreq.GetPullRequestParams{
+			Owner: owner, Repo: repo, Index: index,
+		})
+		if err != nil {
+			return nil, err
+		}
+		comments, err := run(ctx, issue.ListIssueCommentsImpl{Client: impl.Client}, issue.ListIssueCommentsParams{
+			Owner: owner, Repo: repo, Index: index,
+		})
+		if err != nil {
+			return nil, err
+		}
+		diff, _, err := impl.Client.GetPullRequestDiff(owner, repo, int64(index), forgejo.PullRequestDiffOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request diff: %w", err)
+		}
+		diffText := string(diff)
+		if len(diffText) > maxDiffSize {
+			diffText = diffText[:maxDiffSize] + "\n... (diff truncated)"
+		}
+
+		text := fmt.Sprintf(`Review pull request #%d of %s/%s.
+
+Check correctness, error handling, tests, naming and consistency with the surrounding code.
+Take the existing discussion into account and do not repeat points already resolved there.
+List findings ordered by severity, quoting file and line of the diff, and finish with an overall recommendation (approve, request changes or comment).
+
+`, index, owner, repo)
+		text += section("Pull request", pr)
+		text += section("Discussion", comments)
+		text += section("Diff", "```diff\n"+diffText+"\n```")
+
+		return userPrompt(fmt.Sprintf("Review pull request #%d in %s/%s", index, owner, repo), text), nil
+	}
+}
+
+// DraftReleaseNotesImpl implements the `draft_release_notes` prompt. It
+// pre-loads issues and pull requests closed since a tagged release.
+type DraftReleaseNotesImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `draft_release_notes` prompt.
+func (DraftReleaseNotesImpl) Definition() *mcp.Prompt {
+	return &mcp.Prompt{
+		Name:        "draft_release_notes",
+		Title:       "Draft Release Notes",
+		Description: "Draft release notes for {owner}/{repo} covering changes since release {tag}.",
+		Arguments: withRepoArgs(&mcp.PromptArgument{
+			Name:        "tag",
+			Title:       "Previous Tag",
+			Description: "Tag of the previous release",
+			Required:    true,
+		}),
+	}
+}
+
+// Handler finds the release of the tag and pre-loads issues and pull requests
+// closed after it with `list_repo_issues`.
+func (impl DraftReleaseNotesImpl) Handler() mcp.PromptHandler {
+	return func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args, err := requireArgs(req, "owner", "repo", "tag")
+		if err != nil {
+			return nil, err
+		}
+		owner, repo, tag := args[0], args[1], args[2]
+
+		rel, _, err := impl.Client.GetReleaseByTag(owner, repo, tag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get release %s: %w", tag, err)
+		}
+		since := rel.PublishedAt
+		if since.IsZero() {
+			since = rel.CreatedAt
+		}
+		sinceStr := since.Format(time.RFC3339)
+
+		closed, err := run(ctx, issue.ListRepoIssuesImpl{Client: impl.Client}, issue.ListRepoIssuesParams{
+			Owner: owner, Repo: repo, State: "closed", Sort: "updated", Order: "desc", Limit: 50, Since: &sinceStr,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		text := fmt.Sprintf(`Draft release notes for the next release of %s/%s, covering changes since %s (published %s).
+
+Use only issues and pull requests closed after that date. Group entries into Features, Bug Fixes and Other Changes, write one line per entry ending with its reference (#index), and skip items closed without being resolved (duplicates, invalid, won't fix).
+
+`, owner, repo, tag, since.Format("2006-01-02"))
+		text += section("Issues and pull requests closed since "+tag, closed)
+
+		return userPrompt(fmt.Sprintf("Draft release notes for %s/%s since %s", owner, repo, tag), text), nil
+	}
+}
+
+// SummarizeMilestoneImpl implements the `summarize_milestone` prompt. It
+// pre-loads the milestone and all of its issues.
+type SummarizeMilestoneImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `summarize_milestone` prompt.
+func (SummarizeMilestoneImpl) Definition() *mcp.Prompt {
+	return &mcp.Prompt{
+		Name:        "summarize_milestone",
+		Title:       "Summarize Milestone",
+		Description: "Summarize progress and risks of milestone {name} in {owner}/{repo}.",
+		Arguments: withRepoArgs(&mcp.PromptArgument{
+			Name:        "name",
+			Title:       "Milestone",
+			Description: "Milestone title",
+			Required:    true,
+		}),
+	}
+}
+
+// Handler pre-loads the milestone from the Forgejo API and its issues with
+// `list_repo_issues`.
+func (impl SummarizeMilestoneImpl) Handler() mcp.PromptHandler {
+	return func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args, err := requireArgs(req, "owner", "repo", "name")
+		if err != nil {
+			return nil, err
+		}
+		owner, repo, name := args[0], args[1], args[2]
+
+		ms, _, err := impl.Client.GetMilestoneByName(owner, repo, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get milestone %s: %w", name, err)
+		}
+		issues, err := run(ctx, issue.ListRepoIssuesImpl{Client: impl.Client}, issue.ListRepoIssuesParams{
+			Owner: owner, Repo: repo, State: "all", Milestones: name, Sort: "updated", Order: "desc", Limit: 50,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		info := fmt.Sprintf("**%s** (%s)\nOpen: %d | Closed: %d\n", ms.Title, ms.State, ms.OpenIssues, ms.ClosedIssues)
+		if ms.Deadline != nil {
+			info += "Due: " + ms.Deadline.Format("2006-01-02") + "\n"
+		}
+		if ms.Description != "" {
+			info += "\n" + ms.Description
+		}
+
+		text := fmt.Sprintf(`Summarize milestone "%s" of %s/%s.
+
+Report overall progress, what was completed recently, what remains open, and which open issues put the due date at risk (unassigned, stale, or blocked). End with a short list of recommended next actions.
+
+`, name, owner, repo)
+		text += section("Milestone", info)
+		text += section("Issues", issues)
+
+		return userPrompt(fmt.Sprintf("Summarize milestone %s in %s/%s", name, owner, repo), text), nil
+	}
+}
+
+// BugReportImpl implements the `bug_report_from_stack_trace` prompt. It
+// pre-loads the labels of the repository and issues possibly reporting the
+// same problem.
+type BugReportImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `bug_report_from_stack_trace` prompt.
+func (BugReportImpl) Definition() *mcp.Prompt {
+	return &mcp.Prompt{
+		Name:        "bug_report_from_stack_trace",
+		Title:       "Bug Report from Stack Trace",
+		Description: "Write a bug report for {owner}/{repo} from a stack trace, checking for existing reports first.",
+		Arguments: withRepoArgs(&mcp.PromptArgument{
+			Name:        "stack_trace",
+			Title:       "Stack Trace",
+			Description: "The stack trace or error output",
+			Required:    true,
+		}),
+	}
+}
+
+// Handler pre-loads labels with `list_repo_labels` and searches possible
+// duplicates with `list_repo_issues`, using the first line of the trace.
+func (impl BugReportImpl) Handler() mcp.PromptHandler {
+	return func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args, err := requireArgs(req, "owner", "repo", "stack_trace")
+		if err != nil {
+			return nil, err
+		}
+		owner, repo, trace := args[0], args[1], args[2]
+
+		labels, err := run(ctx, label.ListRepoLabelsImpl{Client: impl.Client}, label.ListRepoLabelsParams{
+			Owner: owner, Repo: repo,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		keyword, _, _ := strings.Cut(trace, "\n")
+		keyword = strings.TrimSpace(keyword)
+		if len(keyword) > 100 {
+			keyword = keyword[:100]
+		}
+		similar, err := run(ctx, issue.ListRepoIssuesImpl{Client: impl.Client}, issue.ListRepoIssuesParams{
+			Owner: owner, Repo: repo, State: "all", Q: keyword, Limit: 10,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		text := fmt.Sprintf(`Write a bug report for %s/%s from the stack trace below.
+
+If one of the possibly related issues already reports the same problem, say so and draft a comment for it instead.
+Otherwise draft an issue with a concise title, a summary, the likely failing component, steps to reproduce if they can be inferred, expected and actual behavior, and the relevant part of the trace in a code block. Suggest labels from the available ones.
+Ask for confirmation before creating the issue or comment.
+
+`, owner, repo)
+		text += section("Stack trace", "```\n"+trace+"\n```")
+		text += section("Possibly related issues", similar)
+		text += section("Available labels", labels)
+
+		return userPrompt(fmt.Sprintf("Write a bug report for %s/%s", owner, repo), text), nil
+	}
+}
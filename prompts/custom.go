@@ -0,0 +1,284 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package prompts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"gopkg.in/yaml.v3"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/tools/action"
+	"github.com/raohwork/forgejo-mcp/tools/issue"
+	"github.com/raohwork/forgejo-mcp/tools/label"
+	"github.com/raohwork/forgejo-mcp/tools/milestone"
+	"github.com/raohwork/forgejo-mcp/tools/notification"
+	"github.com/raohwork/forgejo-mcp/tools/pullreq"
+	"github.com/raohwork/forgejo-mcp/tools/release"
+	"github.com/raohwork/forgejo-mcp/tools/repo"
+	"github.com/raohwork/forgejo-mcp/tools/wiki"
+)
+
+// Template is a custom prompt loaded from a markdown file.
+//
+// The file starts with a YAML front matter describing the prompt, followed by
+// the prompt body written in Go text/template syntax:
+//
+//	---
+//	name: weekly_report
+//	title: Weekly Report
+//	description: Summarize the activity of {owner}/{repo} in the past week.
+//	arguments:
+//	  - name: owner
+//	    description: Repository owner
+//	    required: true
+//	  - name: repo
+//	    description: Repository name
+//	    required: true
+//	---
+//	Summarize what happened in {{.owner}}/{{.repo}} this week.
+//
+//	{{tool "list_repo_issues" "owner" .owner "repo" .repo "state" "all" "sort" "updated"}}
+//
+// Arguments are available as fields of the dot value; optional arguments not
+// given by the client are empty strings. The `tool` function calls a
+// read-only tool of this server with key-value arguments and returns its text
+// output, so templates can pre-load data the same way built-in prompts do.
+type Template struct {
+	def  *mcp.Prompt
+	tmpl *template.Template
+}
+
+type templateMeta struct {
+	Name        string `yaml:"name"`
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+	Arguments   []struct {
+		Name        string `yaml:"name"`
+		Title       string `yaml:"title"`
+		Description string `yaml:"description"`
+		Required    bool   `yaml:"required"`
+	} `yaml:"arguments"`
+}
+
+// LoadDir loads every `*.md` file in dir as a prompt template. The prompt name
+// defaults to the file name without extension.
+func LoadDir(dir string) ([]*Template, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.md"))
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*Template, 0, len(files))
+	for _, fn := range files {
+		data, err := os.ReadFile(fn)
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimSuffix(filepath.Base(fn), filepath.Ext(fn))
+		t, err := ParseTemplate(name, data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fn, err)
+		}
+		ret = append(ret, t)
+	}
+	return ret, nil
+}
+
+// ParseTemplate parses a prompt template. See Template for the file format.
+// The name is used when the front matter does not specify one.
+func ParseTemplate(name string, data []byte) (*Template, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	rest, ok := strings.CutPrefix(text, "---\n")
+	if !ok {
+		return nil, errors.New("missing front matter")
+	}
+	front, body, ok := strings.Cut(rest, "\n---\n")
+	if !ok {
+		return nil, errors.New("unterminated front matter")
+	}
+
+	var meta templateMeta
+	if err := yaml.Unmarshal([]byte(front), &meta); err != nil {
+		return nil, fmt.Errorf("invalid front matter: %w", err)
+	}
+	if meta.Name != "" {
+		name = meta.Name
+	}
+
+	def := &mcp.Prompt{
+		Name:        name,
+		Title:       meta.Title,
+		Description: meta.Description,
+	}
+	for _, a := range meta.Arguments {
+		if a.Name == "" {
+			return nil, errors.New("argument without name")
+		}
+		def.Arguments = append(def.Arguments, &mcp.PromptArgument{
+			Name:        a.Name,
+			Title:       a.Title,
+			Description: a.Description,
+			Required:    a.Required,
+		})
+	}
+
+	// the real tool function is bound per call, see CustomImpl.Handler
+	tmpl, err := template.New(name).
+		Option("missingkey=zero").
+		Funcs(template.FuncMap{"tool": func(string, ...any) (string, error) { return "", nil }}).
+		Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	return &Template{def: def, tmpl: tmpl}, nil
+}
+
+// Name returns the name of the prompt.
+func (t *Template) Name() string {
+	return t.def.Name
+}
+
+// CustomImpl implements a prompt defined by a Template.
+type CustomImpl struct {
+	Template *Template
+	Client   *tools.Client
+}
+
+// Definition returns the prompt definition declared in the template.
+func (impl CustomImpl) Definition() *mcp.Prompt {
+	return impl.Template.def
+}
+
+// Handler validates required arguments and renders the template as a single
+// user message.
+func (impl CustomImpl) Handler() mcp.PromptHandler {
+	return func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args := map[string]string{}
+		for _, a := range impl.Template.def.Arguments {
+			v := req.Params.Arguments[a.Name]
+			if a.Required && strings.TrimSpace(v) == "" {
+				return nil, fmt.Errorf("missing required argument %q", a.Name)
+			}
+			args[a.Name] = v
+		}
+
+		registry := readOnlyTools(impl.Client)
+		tmpl, err := impl.Template.tmpl.Clone()
+		if err != nil {
+			return nil, err
+		}
+		tmpl.Funcs(template.FuncMap{
+			"tool": func(name string, kv ...any) (string, error) {
+				fn, ok := registry[name]
+				if !ok {
+					return "", fmt.Errorf("unknown or non read-only tool %q", name)
+				}
+				if len(kv)%2 != 0 {
+					return "", fmt.Errorf("tool %s: odd number of key-value arguments", name)
+				}
+				in := map[string]any{}
+				for i := 0; i < len(kv); i += 2 {
+					k, ok := kv[i].(string)
+					if !ok {
+						return "", fmt.Errorf("tool %s: argument name must be a string", name)
+					}
+					in[k] = kv[i+1]
+				}
+				return fn(ctx, in)
+			},
+		})
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, args); err != nil {
+			return nil, fmt.Errorf("failed to render prompt %s: %w", impl.Template.def.Name, err)
+		}
+
+		return userPrompt(impl.Template.def.Description, buf.String()), nil
+	}
+}
+
+// toolFunc calls a tool with loosely typed arguments.
+type toolFunc func(ctx context.Context, args map[string]any) (string, error)
+
+// bind adapts a tool implementation to a toolFunc, converting arguments by a
+// JSON round trip into the tool's parameter type.
+func bind[In any](impl tools.ToolImpl[In, any]) toolFunc {
+	return func(ctx context.Context, args map[string]any) (string, error) {
+		data, err := json.Marshal(args)
+		if err != nil {
+			return "", err
+		}
+		var in In
+		if err := json.Unmarshal(data, &in); err != nil {
+			return "", fmt.Errorf("invalid arguments for %s: %w", impl.Definition().Name, err)
+		}
+		return run(ctx, impl, in)
+	}
+}
+
+// readOnlyTools returns the tools templates are allowed to call, keyed by
+// tool name.
+func readOnlyTools(cl *tools.Client) map[string]toolFunc {
+	return map[string]toolFunc{
+		"list_repo_issues":          bind[issue.ListRepoIssuesParams](issue.ListRepoIssuesImpl{Client: cl}),
+		"get_issue":                 bind[issue.GetIssueParams](issue.GetIssueImpl{Client: cl}),
+		"search_issues":             bind[issue.SearchIssuesParams](issue.SearchIssuesImpl{Client: cl}),
+		"get_issue_timeline":        bind[issue.GetIssueTimelineParams](issue.GetIssueTimelineImpl{Client: cl}),
+		"list_issue_templates":      bind[issue.ListIssueTemplatesParams](issue.ListIssueTemplatesImpl{Client: cl}),
+		"list_issue_comments":       bind[issue.ListIssueCommentsParams](issue.ListIssueCommentsImpl{Client: cl}),
+		"list_issue_attachments":    bind[issue.ListIssueAttachmentsParams](issue.ListIssueAttachmentsImpl{Client: cl}),
+		"list_issue_dependencies":   bind[issue.ListIssueDependenciesParams](issue.ListIssueDependenciesImpl{Client: cl}),
+		"list_issue_blocking":       bind[issue.ListIssueBlockingParams](issue.ListIssueBlockingImpl{Client: cl}),
+		"get_dependency_graph":      bind[issue.GetDependencyGraphParams](issue.GetDependencyGraphImpl{Client: cl}),
+		"list_pinned_issues":        bind[issue.ListPinnedIssuesParams](issue.ListPinnedIssuesImpl{Client: cl}),
+		"list_issue_subscribers":    bind[issue.ListIssueSubscribersParams](issue.ListIssueSubscribersImpl{Client: cl}),
+		"check_issue_subscription":  bind[issue.CheckIssueSubscriptionParams](issue.CheckIssueSubscriptionImpl{Client: cl}),
+		"list_issue_reactions":      bind[issue.ListIssueReactionsParams](issue.ListIssueReactionsImpl{Client: cl}),
+		"list_most_upvoted_issues":  bind[issue.ListMostUpvotedIssuesParams](issue.ListMostUpvotedIssuesImpl{Client: cl}),
+		"list_tracked_times":        bind[issue.ListTrackedTimesParams](issue.ListTrackedTimesImpl{Client: cl}),
+		"list_my_stopwatches":       bind[issue.ListMyStopwatchesParams](issue.ListMyStopwatchesImpl{Client: cl}),
+		"get_milestone_time_report": bind[issue.GetMilestoneTimeReportParams](issue.GetMilestoneTimeReportImpl{Client: cl}),
+		"list_repo_labels":          bind[label.ListRepoLabelsParams](label.ListRepoLabelsImpl{Client: cl}),
+		"list_repo_milestones":      bind[milestone.ListRepoMilestonesParams](milestone.ListRepoMilestonesImpl{Client: cl}),
+		"list_releases":             bind[release.ListReleasesParams](release.ListReleasesImpl{Client: cl}),
+		"list_release_attachments":  bind[release.ListReleaseAttachmentsParams](release.ListReleaseAttachmentsImpl{Client: cl}),
+		"list_pull_requests":        bind[pullreq.ListPullRequestsParams](pullreq.ListPullRequestsImpl{Client: cl}),
+		"get_pull_request":          bind[pullreq.GetPullRequestParams](pullreq.GetPullRequestImpl{Client: cl}),
+		"search_repositories":       bind[repo.SearchRepositoriesParams](repo.SearchRepositoriesImpl{Client: cl}),
+		"list_my_repositories":      bind[repo.ListMyRepositoriesParams](repo.ListMyRepositoriesImpl{Client: cl}),
+		"list_org_repositories":     bind[repo.ListOrgRepositoriesParams](repo.ListOrgRepositoriesImpl{Client: cl}),
+		"get_repository":            bind[repo.GetRepositoryParams](repo.GetRepositoryImpl{Client: cl}),
+		"check_repo_watch":          bind[repo.CheckRepoWatchParams](repo.CheckRepoWatchImpl{Client: cl}),
+		"list_notifications":        bind[notification.ListNotificationsParams](notification.ListNotificationsImpl{Client: cl}),
+		"get_notification_thread":   bind[notification.GetNotificationThreadParams](notification.GetNotificationThreadImpl{Client: cl}),
+		"get_wiki_page":             bind[wiki.GetWikiPageParams](wiki.GetWikiPageImpl{Client: cl}),
+		"list_wiki_pages":           bind[wiki.ListWikiPagesParams](wiki.ListWikiPagesImpl{Client: cl}),
+		"list_action_tasks":         bind[action.ListActionTasksParams](action.ListActionTasksImpl{Client: cl}),
+		"list_action_runs":          bind[action.ListActionRunsParams](action.ListActionRunsImpl{Client: cl}),
+		"list_action_run_jobs":      bind[action.ListActionRunJobsParams](action.ListActionRunJobsImpl{Client: cl}),
+		"get_action_job_log":        bind[action.GetActionJobLogParams](action.GetActionJobLogImpl{Client: cl}),
+		"list_action_secrets":       bind[action.ListActionSecretsParams](action.ListActionSecretsImpl{Client: cl}),
+		"list_action_variables":     bind[action.ListActionVariablesParams](action.ListActionVariablesImpl{Client: cl}),
+		"get_action_variable":       bind[action.GetActionVariableParams](action.GetActionVariableImpl{Client: cl}),
+		"list_action_artifacts":     bind[action.ListActionArtifactsParams](action.ListActionArtifactsImpl{Client: cl}),
+		"get_action_artifact":       bind[action.GetActionArtifactParams](action.GetActionArtifactImpl{Client: cl}),
+		"list_action_runners":       bind[action.ListActionRunnersParams](action.ListActionRunnersImpl{Client: cl}),
+		"summarize_ci_failures":     bind[action.SummarizeCIFailuresParams](action.SummarizeCIFailuresImpl{Client: cl}),
+		"detect_flaky_workflows":    bind[action.DetectFlakyWorkflowsParams](action.DetectFlakyWorkflowsImpl{Client: cl}),
+	}
+}
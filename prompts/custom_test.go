@@ -0,0 +1,130 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package prompts
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const testTemplate = `---
+title: Weekly Report
+description: Summarize the week
+arguments:
+  - name: owner
+    required: true
+  - name: repo
+    required: true
+  - name: focus
+---
+Report for {{.owner}}/{{.repo}}{{if .focus}} focusing on {{.focus}}{{end}}.
+`
+
+func TestParseTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{name: "valid template", data: testTemplate},
+		{name: "missing front matter", data: "hello {{.owner}}", wantErr: true},
+		{name: "unterminated front matter", data: "---\nname: x\n", wantErr: true},
+		{name: "invalid yaml", data: "---\nname: [\n---\nbody", wantErr: true},
+		{name: "invalid template", data: "---\nname: x\n---\n{{.owner", wantErr: true},
+		{name: "argument without name", data: "---\narguments:\n  - required: true\n---\nbody", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTemplate("weekly", []byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error: %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCustomImpl_Handler(t *testing.T) {
+	tmpl, err := ParseTemplate("weekly", []byte(testTemplate))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	impl := CustomImpl{Template: tmpl}
+	def := impl.Definition()
+	if def.Name != "weekly" || len(def.Arguments) != 3 || !def.Arguments[0].Required || def.Arguments[2].Required {
+		t.Fatalf("Unexpected definition: %+v", def)
+	}
+
+	tests := []struct {
+		name    string
+		args    map[string]string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "all arguments",
+			args: map[string]string{"owner": "o", "repo": "r", "focus": "bugs"},
+			want: "Report for o/r focusing on bugs.",
+		},
+		{
+			name: "optional argument omitted",
+			args: map[string]string{"owner": "o", "repo": "r"},
+			want: "Report for o/r.",
+		},
+		{
+			name:    "required argument missing",
+			args:    map[string]string{"owner": "o"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := impl.Handler()(context.Background(), &mcp.GetPromptRequest{
+				Params: &mcp.GetPromptParams{Name: "weekly", Arguments: tt.args},
+			})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			text := res.Messages[0].Content.(*mcp.TextContent).Text
+			if strings.TrimSpace(text) != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, text)
+			}
+		})
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "weekly.md"), []byte(testTemplate), 0o644)
+	os.WriteFile(filepath.Join(dir, "named.md"), []byte("---\nname: custom_name\n---\nbody"), 0o644)
+	os.WriteFile(filepath.Join(dir, "ignored.txt"), []byte("not a template"), 0o644)
+
+	list, err := LoadDir(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	names := map[string]bool{}
+	for _, tmpl := range list {
+		names[tmpl.Name()] = true
+	}
+	if len(names) != 2 || !names["weekly"] || !names["custom_name"] {
+		t.Errorf("Unexpected templates: %v", names)
+	}
+}
@@ -0,0 +1,7 @@
+// Package prompts provides MCP prompts for common Forgejo workflows.
+//
+// Each prompt pre-loads the data it needs by calling the read-only tools of
+// this server, so the model starts with the relevant issues, pull requests or
+// milestones in context. Custom prompts can be loaded from markdown templates
+// in a directory, see LoadDir.
+package prompts
@@ -0,0 +1,106 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+)
+
+// PromptImpl defines the interface that every prompt implementation must satisfy.
+// It is the prompt counterpart of tools.ToolImpl.
+type PromptImpl interface {
+	// Definition returns the formal MCP prompt definition, including its name,
+	// description, and arguments.
+	Definition() *mcp.Prompt
+
+	// Handler returns the function that renders the prompt messages.
+	Handler() mcp.PromptHandler
+}
+
+// Register is a helper function that registers a prompt implementation with the
+// MCP server.
+func Register(s *mcp.Server, p PromptImpl) {
+	s.AddPrompt(p.Definition(), p.Handler())
+}
+
+// run calls a tool handler directly and returns its text content. It is used to
+// pre-load data into prompts with exactly the same rendering as the tools.
+func run[In any](ctx context.Context, impl tools.ToolImpl[In, any], args In) (string, error) {
+	res, _, err := impl.Handler()(ctx, &mcp.CallToolRequest{}, args)
+	if err != nil {
+		return "", err
+	}
+
+	var texts []string
+	for _, c := range res.Content {
+		if t, ok := c.(*mcp.TextContent); ok {
+			texts = append(texts, t.Text)
+		}
+	}
+	return strings.Join(texts, "\n\n"), nil
+}
+
+// requireArgs returns the named arguments, or an error naming the first
+// missing one.
+func requireArgs(req *mcp.GetPromptRequest, names ...string) ([]string, error) {
+	ret := make([]string, len(names))
+	for i, name := range names {
+		v := strings.TrimSpace(req.Params.Arguments[name])
+		if v == "" {
+			return nil, fmt.Errorf("missing required argument %q", name)
+		}
+		ret[i] = v
+	}
+	return ret, nil
+}
+
+// userPrompt wraps text as the single user message of a prompt result.
+func userPrompt(desc, text string) *mcp.GetPromptResult {
+	return &mcp.GetPromptResult{
+		Description: desc,
+		Messages: []*mcp.PromptMessage{
+			{
+				Role:    "user",
+				Content: &mcp.TextContent{Text: text},
+			},
+		},
+	}
+}
+
+// section renders a titled markdown section of pre-loaded data.
+func section(title, body string) string {
+	return "## " + title + "\n\n" + strings.TrimSpace(body) + "\n\n"
+}
+
+// repoArgs are the arguments shared by every repository-scoped prompt.
+var repoArgs = []*mcp.PromptArgument{
+	{
+		Name:        "owner",
+		Title:       "Owner",
+		Description: "Repository owner (username or organization name)",
+		Required:    true,
+	},
+	{
+		Name:        "repo",
+		Title:       "Repository",
+		Description: "Repository name",
+		Required:    true,
+	},
+}
+
+// withRepoArgs returns the repository arguments followed by extra.
+func withRepoArgs(extra ...*mcp.PromptArgument) []*mcp.PromptArgument {
+	ret := make([]*mcp.PromptArgument, 0, len(repoArgs)+len(extra))
+	ret = append(ret, repoArgs...)
+	return append(ret, extra...)
+}
@@ -0,0 +1,8 @@
+// Package resources exposes Forgejo repositories, issues and pull requests as
+// MCP resources, and delivers `resources/updated` notifications to subscribed
+// sessions.
+//
+// Change detection is driven either by Forgejo webhooks (see WebhookHandler),
+// which is suitable for the long-running HTTP server, or by periodic polling
+// (see Hub.Poll), which is used in stdio mode where no inbound endpoint exists.
+package resources
@@ -0,0 +1,136 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package resources
+
+import (
+	"context"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Hub keeps track of resource subscriptions across MCP servers and fans out
+// `resources/updated` notifications to them.
+//
+// The HTTP transport creates a new mcp.Server for every client session, so the
+// per-server subscription bookkeeping of the SDK is not enough to find every
+// interested session when a change is detected. Hub records which servers hold
+// subscriptions for an URI, and lets the SDK notify the sessions of each server.
+type Hub struct {
+	mu   sync.Mutex
+	subs map[string]map[*mcp.Server]int // uri -> server -> subscription count
+}
+
+// NewHub creates an empty subscription hub.
+func NewHub() *Hub {
+	return &Hub{subs: map[string]map[*mcp.Server]int{}}
+}
+
+// Bind wires the subscribe and unsubscribe handlers of opts to the hub. The
+// server pointer is dereferenced lazily, so it may be assigned after Bind
+// returns, typically with the result of mcp.NewServer(impl, opts).
+func (h *Hub) Bind(opts *mcp.ServerOptions, server **mcp.Server) {
+	opts.SubscribeHandler = func(ctx context.Context, req *mcp.SubscribeRequest) error {
+		return h.Subscribe(*server, req.Params.URI)
+	}
+	opts.UnsubscribeHandler = func(ctx context.Context, req *mcp.UnsubscribeRequest) error {
+		h.Unsubscribe(*server, req.Params.URI)
+		return nil
+	}
+}
+
+// Subscribe records that a session of s is interested in uri. It returns an
+// error if uri is not a resource URI of this server.
+func (h *Hub) Subscribe(s *mcp.Server, uri string) error {
+	if _, err := ParseURI(uri); err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subs[uri] == nil {
+		h.subs[uri] = map[*mcp.Server]int{}
+	}
+	h.subs[uri][s]++
+	return nil
+}
+
+// Unsubscribe removes one subscription of s to uri. It is not an error to
+// unsubscribe from an URI which has no subscription.
+func (h *Hub) Unsubscribe(s *mcp.Server, uri string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	servers, ok := h.subs[uri]
+	if !ok {
+		return
+	}
+	servers[s]--
+	if servers[s] <= 0 {
+		delete(servers, s)
+	}
+	if len(servers) == 0 {
+		delete(h.subs, uri)
+	}
+}
+
+// Subscriptions returns the URIs which have at least one subscriber.
+func (h *Hub) Subscriptions() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ret := make([]string, 0, len(h.subs))
+	for uri := range h.subs {
+		ret = append(ret, uri)
+	}
+	return ret
+}
+
+// Notify sends a `resources/updated` notification for each uri to all
+// subscribed sessions. URIs without subscribers are ignored.
+//
+// Servers which no longer have any connected session are dropped from the hub,
+// as sessions can go away without unsubscribing.
+func (h *Hub) Notify(ctx context.Context, uris ...string) {
+	for _, uri := range uris {
+		for _, s := range h.servers(uri) {
+			if !hasSession(s) {
+				h.forget(s)
+				continue
+			}
+			s.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: uri})
+		}
+	}
+}
+
+// servers returns a snapshot of servers subscribed to uri.
+func (h *Hub) servers(uri string) []*mcp.Server {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ret := make([]*mcp.Server, 0, len(h.subs[uri]))
+	for s := range h.subs[uri] {
+		ret = append(ret, s)
+	}
+	return ret
+}
+
+// forget removes all subscriptions of s.
+func (h *Hub) forget(s *mcp.Server) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for uri, servers := range h.subs {
+		delete(servers, s)
+		if len(servers) == 0 {
+			delete(h.subs, uri)
+		}
+	}
+}
+
+func hasSession(s *mcp.Server) bool {
+	for range s.Sessions() {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package resources
+
+import (
+	"context"
+	"time"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+)
+
+// pollPageSize is the page size used when listing updated issues.
+const pollPageSize = 50
+
+// Poll detects changes of subscribed resources by querying Forgejo every
+// interval, and notifies subscribers through the hub. It blocks until ctx is
+// done.
+//
+// For each repository with subscribed resources, issues and pull requests
+// updated since the previous round are listed with the `since` filter, and the
+// repository itself is checked by its update time. Poll is meant for the stdio
+// transport, where Forgejo cannot deliver webhooks to the server.
+func (h *Hub) Poll(ctx context.Context, cl *tools.Client, interval time.Duration) {
+	p := &poller{
+		hub:   h,
+		cl:    cl,
+		since: map[string]time.Time{},
+		repos: map[string]time.Time{},
+	}
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			p.round(ctx)
+		}
+	}
+}
+
+type poller struct {
+	hub *Hub
+	cl  *tools.Client
+	// since records when each repository was last polled, keyed by repo URI.
+	since map[string]time.Time
+	// repos records the last seen update time of subscribed repositories.
+	repos map[string]time.Time
+}
+
+// round runs one polling round over all subscribed repositories.
+func (p *poller) round(ctx context.Context) {
+	watched := map[string]Ref{} // repo uri -> repo ref
+	repoSubscribed := map[string]bool{}
+	for _, uri := range p.hub.Subscriptions() {
+		ref, err := ParseURI(uri)
+		if err != nil {
+			continue
+		}
+		key := RepoURI(ref.Owner, ref.Repo)
+		watched[key] = Ref{Kind: KindRepo, Owner: ref.Owner, Repo: ref.Repo}
+		if ref.Kind == KindRepo {
+			repoSubscribed[key] = true
+		}
+	}
+
+	for key := range p.since {
+		if _, ok := watched[key]; !ok {
+			delete(p.since, key)
+			delete(p.repos, key)
+		}
+	}
+
+	for key, ref := range watched {
+		now := time.Now()
+		since, ok := p.since[key]
+		p.since[key] = now
+		if !ok {
+			// first round for this repository, only record the baseline
+			if repoSubscribed[key] {
+				p.repoChanged(key, ref)
+			}
+			continue
+		}
+
+		uris := p.updatedIssues(ref, since)
+		if repoSubscribed[key] {
+			changed := p.repoChanged(key, ref)
+			if changed || len(uris) > 0 {
+				uris = append(uris, key)
+			}
+		}
+		p.hub.Notify(ctx, uris...)
+	}
+}
+
+// updatedIssues lists URIs of issues and pull requests updated after since.
+func (p *poller) updatedIssues(ref Ref, since time.Time) []string {
+	var uris []string
+	for page := 1; ; page++ {
+		issues, _, err := p.cl.ListRepoIssues(ref.Owner, ref.Repo, forgejo.ListIssueOption{
+			ListOptions: forgejo.ListOptions{Page: page, PageSize: pollPageSize},
+			State:       forgejo.StateAll,
+			Type:        forgejo.IssueTypeAll,
+			Since:       since,
+		})
+		if err != nil {
+			return uris
+		}
+		for _, issue := range issues {
+			if issue.PullRequest != nil {
+				uris = append(uris, PullURI(ref.Owner, ref.Repo, issue.Index))
+			} else {
+				uris = append(uris, IssueURI(ref.Owner, ref.Repo, issue.Index))
+			}
+		}
+		if len(issues) < pollPageSize {
+			return uris
+		}
+	}
+}
+
+// repoChanged reports whether the update time of the repository differs from
+// the one seen in previous round.
+func (p *poller) repoChanged(key string, ref Ref) bool {
+	repo, _, err := p.cl.GetRepo(ref.Owner, ref.Repo)
+	if err != nil {
+		return false
+	}
+	prev, ok := p.repos[key]
+	p.repos[key] = repo.Updated
+	return ok && !prev.Equal(repo.Updated)
+}
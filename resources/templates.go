@@ -0,0 +1,89 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// Register adds the repository, issue and pull request resource templates to
+// the MCP server. Reading a resource fetches the object with the given client
+// and renders it as markdown, the same way the corresponding get_* tool does.
+func Register(s *mcp.Server, cl *tools.Client) {
+	read := func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := req.Params.URI
+		ref, err := ParseURI(uri)
+		if err != nil {
+			return nil, mcp.ResourceNotFoundError(uri)
+		}
+
+		text, err := render(cl, ref)
+		if err != nil {
+			return nil, err
+		}
+
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{
+				{URI: uri, MIMEType: "text/markdown", Text: text},
+			},
+		}, nil
+	}
+
+	s.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "repository",
+		Title:       "Repository",
+		Description: "A Forgejo repository. Subscribe to be notified when anything in the repository changes.",
+		MIMEType:    "text/markdown",
+		URITemplate: Scheme + "{owner}/{repo}",
+	}, read)
+	s.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "issue",
+		Title:       "Issue",
+		Description: "A Forgejo issue. Subscribe to be notified when the issue or its comments change.",
+		MIMEType:    "text/markdown",
+		URITemplate: Scheme + "{owner}/{repo}/issues/{index}",
+	}, read)
+	s.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "pull_request",
+		Title:       "Pull Request",
+		Description: "A Forgejo pull request. Subscribe to be notified when the pull request or its comments change.",
+		MIMEType:    "text/markdown",
+		URITemplate: Scheme + "{owner}/{repo}/pulls/{index}",
+	}, read)
+}
+
+// render fetches the object referred by ref and formats it as markdown.
+func render(cl *tools.Client, ref Ref) (string, error) {
+	switch ref.Kind {
+	case KindRepo:
+		repo, _, err := cl.GetRepo(ref.Owner, ref.Repo)
+		if err != nil {
+			return "", fmt.Errorf("failed to get repository: %w", err)
+		}
+		return (&types.Repository{Repository: repo}).ToMarkdown(), nil
+	case KindIssue:
+		issue, _, err := cl.GetIssue(ref.Owner, ref.Repo, ref.Index)
+		if err != nil {
+			return "", fmt.Errorf("failed to get issue: %w", err)
+		}
+		return (&types.Issue{Issue: issue}).ToMarkdown(), nil
+	case KindPull:
+		pr, _, err := cl.GetPullRequest(ref.Owner, ref.Repo, ref.Index)
+		if err != nil {
+			return "", fmt.Errorf("failed to get pull request: %w", err)
+		}
+		return (&types.PullRequest{PullRequest: pr}).ToMarkdown(), nil
+	}
+
+	return "", fmt.Errorf("unsupported resource type %q", ref.Kind)
+}
@@ -0,0 +1,92 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package resources
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Scheme is the URI scheme used by all resources of this server.
+const Scheme = "forgejo://"
+
+// Kind identifies the type of Forgejo object a resource URI refers to.
+type Kind string
+
+const (
+	// KindRepo is a repository: forgejo://{owner}/{repo}
+	KindRepo Kind = "repo"
+	// KindIssue is an issue: forgejo://{owner}/{repo}/issues/{index}
+	KindIssue Kind = "issues"
+	// KindPull is a pull request: forgejo://{owner}/{repo}/pulls/{index}
+	KindPull Kind = "pulls"
+)
+
+// Ref is the parsed form of a resource URI.
+type Ref struct {
+	Kind  Kind
+	Owner string
+	Repo  string
+	// Index is the issue or pull request number, zero for repositories.
+	Index int64
+}
+
+// URI renders the ref back to its canonical resource URI.
+func (r Ref) URI() string {
+	if r.Kind == KindRepo {
+		return RepoURI(r.Owner, r.Repo)
+	}
+	return fmt.Sprintf("%s%s/%s/%s/%d", Scheme, r.Owner, r.Repo, r.Kind, r.Index)
+}
+
+// RepoURI returns the resource URI of a repository.
+func RepoURI(owner, repo string) string {
+	return Scheme + owner + "/" + repo
+}
+
+// IssueURI returns the resource URI of an issue.
+func IssueURI(owner, repo string, index int64) string {
+	return Ref{Kind: KindIssue, Owner: owner, Repo: repo, Index: index}.URI()
+}
+
+// PullURI returns the resource URI of a pull request.
+func PullURI(owner, repo string, index int64) string {
+	return Ref{Kind: KindPull, Owner: owner, Repo: repo, Index: index}.URI()
+}
+
+// ParseURI parses a resource URI produced by RepoURI, IssueURI or PullURI.
+func ParseURI(uri string) (Ref, error) {
+	rest, ok := strings.CutPrefix(uri, Scheme)
+	if !ok {
+		return Ref{}, fmt.Errorf("unsupported resource URI %q", uri)
+	}
+
+	parts := strings.Split(rest, "/")
+	for _, p := range parts {
+		if p == "" {
+			return Ref{}, fmt.Errorf("malformed resource URI %q", uri)
+		}
+	}
+
+	switch len(parts) {
+	case 2:
+		return Ref{Kind: KindRepo, Owner: parts[0], Repo: parts[1]}, nil
+	case 4:
+		kind := Kind(parts[2])
+		if kind != KindIssue && kind != KindPull {
+			return Ref{}, fmt.Errorf("unsupported resource type %q in %q", parts[2], uri)
+		}
+		index, err := strconv.ParseInt(parts[3], 10, 64)
+		if err != nil || index <= 0 {
+			return Ref{}, fmt.Errorf("invalid index in resource URI %q", uri)
+		}
+		return Ref{Kind: kind, Owner: parts[0], Repo: parts[1], Index: index}, nil
+	}
+
+	return Ref{}, fmt.Errorf("malformed resource URI %q", uri)
+}
@@ -0,0 +1,60 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package resources
+
+import "testing"
+
+func TestParseURI(t *testing.T) {
+	tests := []struct {
+		name    string
+		uri     string
+		want    Ref
+		wantErr bool
+	}{
+		{
+			name: "repository",
+			uri:  "forgejo://owner/repo",
+			want: Ref{Kind: KindRepo, Owner: "owner", Repo: "repo"},
+		},
+		{
+			name: "issue",
+			uri:  "forgejo://owner/repo/issues/12",
+			want: Ref{Kind: KindIssue, Owner: "owner", Repo: "repo", Index: 12},
+		},
+		{
+			name: "pull request",
+			uri:  "forgejo://owner/repo/pulls/3",
+			want: Ref{Kind: KindPull, Owner: "owner", Repo: "repo", Index: 3},
+		},
+		{name: "other scheme", uri: "file:///etc/passwd", wantErr: true},
+		{name: "unknown type", uri: "forgejo://owner/repo/wiki/3", wantErr: true},
+		{name: "invalid index", uri: "forgejo://owner/repo/issues/abc", wantErr: true},
+		{name: "empty segment", uri: "forgejo://owner//issues/1", wantErr: true},
+		{name: "owner only", uri: "forgejo://owner", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseURI(tt.uri)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for %q, got %+v", tt.uri, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+			if got.URI() != tt.uri {
+				t.Errorf("Expected URI %q, got %q", tt.uri, got.URI())
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package resources
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxWebhookBody limits the size of accepted webhook deliveries.
+const maxWebhookBody = 8 << 20
+
+// webhookPayload holds the parts of a Forgejo webhook payload needed to find
+// the affected resources. Every event carries the repository; issue and pull
+// request related events carry the issue or pull request as well.
+type webhookPayload struct {
+	Repository *struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Issue *struct {
+		Number      int64           `json:"number"`
+		PullRequest json.RawMessage `json:"pull_request"`
+	} `json:"issue"`
+	PullRequest *struct {
+		Number int64 `json:"number"`
+	} `json:"pull_request"`
+	IsPull bool `json:"is_pull"`
+}
+
+// EventURIs maps a webhook payload to the URIs of the resources it affects.
+// The repository is always included; the issue or pull request is included
+// when the payload refers to one.
+func EventURIs(payload []byte) ([]string, error) {
+	var p webhookPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, fmt.Errorf("failed to decode payload: %w", err)
+	}
+	if p.Repository == nil {
+		return nil, nil
+	}
+	owner, repo, ok := strings.Cut(p.Repository.FullName, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid repository name %q", p.Repository.FullName)
+	}
+
+	uris := []string{RepoURI(owner, repo)}
+	switch {
+	case p.PullRequest != nil && p.PullRequest.Number > 0:
+		uris = append(uris, PullURI(owner, repo, p.PullRequest.Number))
+	case p.Issue != nil && p.Issue.Number > 0:
+		isPull := p.IsPull || (len(p.Issue.PullRequest) > 0 && string(p.Issue.PullRequest) != "null")
+		if isPull {
+			uris = append(uris, PullURI(owner, repo, p.Issue.Number))
+		} else {
+			uris = append(uris, IssueURI(owner, repo, p.Issue.Number))
+		}
+	}
+	return uris, nil
+}
+
+// VerifySignature reports whether sig is the hex encoded HMAC-SHA256 of body
+// keyed with secret, as sent by Forgejo in the X-Forgejo-Signature header.
+func VerifySignature(secret string, body []byte, sig string) bool {
+	got, err := hex.DecodeString(strings.TrimSpace(sig))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// WebhookHandler returns an http.Handler accepting Forgejo webhook deliveries.
+// Each delivery is verified against secret, mapped to resource URIs with
+// EventURIs and forwarded to the hub.
+//
+// Configure the webhook in Forgejo with content type "application/json" and
+// the same secret.
+func WebhookHandler(hub *Hub, secret string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+
+		sig := r.Header.Get("X-Forgejo-Signature")
+		if sig == "" {
+			sig = r.Header.Get("X-Gitea-Signature")
+		}
+		if !VerifySignature(secret, body, sig) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		uris, err := EventURIs(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		hub.Notify(r.Context(), uris...)
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
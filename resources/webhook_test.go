@@ -0,0 +1,104 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package resources
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestEventURIs(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    []string
+	}{
+		{
+			name:    "push event",
+			payload: `{"ref":"refs/heads/main","repository":{"full_name":"owner/repo"}}`,
+			want:    []string{"forgejo://owner/repo"},
+		},
+		{
+			name:    "issues event",
+			payload: `{"action":"edited","number":5,"issue":{"number":5,"pull_request":null},"repository":{"full_name":"owner/repo"}}`,
+			want:    []string{"forgejo://owner/repo", "forgejo://owner/repo/issues/5"},
+		},
+		{
+			name:    "comment on pull request",
+			payload: `{"action":"created","issue":{"number":7,"pull_request":{"merged":false}},"is_pull":true,"repository":{"full_name":"owner/repo"}}`,
+			want:    []string{"forgejo://owner/repo", "forgejo://owner/repo/pulls/7"},
+		},
+		{
+			name:    "pull request event",
+			payload: `{"action":"opened","number":8,"pull_request":{"number":8},"repository":{"full_name":"owner/repo"}}`,
+			want:    []string{"forgejo://owner/repo", "forgejo://owner/repo/pulls/8"},
+		},
+		{
+			name:    "event without repository",
+			payload: `{"action":"created"}`,
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EventURIs([]byte(tt.payload))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler(t *testing.T) {
+	const secret = "s3cret"
+	body := []byte(`{"repository":{"full_name":"owner/repo"}}`)
+
+	tests := []struct {
+		name   string
+		method string
+		header string
+		sig    string
+		status int
+	}{
+		{name: "forgejo signature", method: "POST", header: "X-Forgejo-Signature", sig: sign(secret, body), status: http.StatusNoContent},
+		{name: "gitea signature", method: "POST", header: "X-Gitea-Signature", sig: sign(secret, body), status: http.StatusNoContent},
+		{name: "wrong secret", method: "POST", header: "X-Forgejo-Signature", sig: sign("other", body), status: http.StatusUnauthorized},
+		{name: "missing signature", method: "POST", status: http.StatusUnauthorized},
+		{name: "wrong method", method: "GET", status: http.StatusMethodNotAllowed},
+	}
+
+	h := WebhookHandler(NewHub(), secret)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/webhook", bytes.NewReader(body))
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.sig)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+			if w.Code != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, w.Code)
+			}
+		})
+	}
+}
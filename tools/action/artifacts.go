@@ -0,0 +1,340 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package action
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// maxInlineSize is the maximum size of a file returned inline by
+// get_action_artifact.
+const maxInlineSize = 512 << 10
+
+// ListActionArtifactsParams defines the parameters for the list_action_artifacts
+// tool. It filters artifacts by run and name.
+type ListActionArtifactsParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// RunID limits the list to the artifacts of a workflow run.
+	RunID int `json:"run_id,omitempty"`
+	// Name filters artifacts by name.
+	Name string `json:"name,omitempty"`
+}
+
+// ListActionArtifactsImpl implements the read-only MCP tool for listing artifacts
+// uploaded by workflow runs. This is a safe, idempotent operation. Note: This
+// feature is not supported by the official Forgejo SDK and requires a custom
+// HTTP implementation.
+type ListActionArtifactsImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `list_action_artifacts` tool. It requires `owner` and
+// `repo`. It is marked as a safe, read-only operation.
+func (ListActionArtifactsImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "list_action_artifacts",
+		Title:       "List Workflow Artifacts",
+		Description: "List artifacts uploaded by workflow runs, such as test reports and coverage output, of a run or of the whole repository. Use get_action_artifact to read them. Not available on Forgejo 11 or earlier, whose API has no artifacts endpoints.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"run_id": {
+					Type:        "integer",
+					Description: "Workflow run ID (optional, defaults to all runs)",
+				},
+				"name": {
+					Type:        "string",
+					Description: "Filter by artifact name (optional)",
+				},
+			},
+			Required: []string{"owner", "repo"},
+		},
+	}
+}
+
+// Handler implements the logic for listing artifacts. It performs a custom HTTP
+// GET request to the `/repos/{owner}/{repo}/actions/runs/{run}/artifacts` or
+// `/repos/{owner}/{repo}/actions/artifacts` endpoint.
+func (impl ListActionArtifactsImpl) Handler() mcp.ToolHandlerFor[ListActionArtifactsParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args ListActionArtifactsParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		// Call custom client method
+		response, err := impl.Client.MyListActionArtifacts(p.Owner, p.Repo, int64(p.RunID), p.Name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list artifacts: %w", err)
+		}
+
+		artifactList := types.ActionArtifactList{MyActionArtifactResponse: response}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: artifactList.ToMarkdown(),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// GetActionArtifactParams defines the parameters for the get_action_artifact
+// tool. It specifies the artifact and the file to extract.
+type GetActionArtifactParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// ArtifactID is the ID of the artifact.
+	ArtifactID int `json:"artifact_id"`
+	// File is the path, or a glob pattern, of the file in the artifact.
+	File string `json:"file,omitempty"`
+}
+
+// GetActionArtifactImpl implements the read-only MCP tool for downloading an
+// artifact and reading a file in it. This is a safe, idempotent operation.
+// Note: This feature is not supported by the official Forgejo SDK and requires
+// a custom HTTP implementation.
+type GetActionArtifactImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `get_action_artifact` tool. It requires `owner`,
+// `repo` and `artifact_id`. It is marked as a safe, read-only operation.
+func (GetActionArtifactImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:  "get_action_artifact",
+		Title: "Get Workflow Artifact",
+		Description: fmt.Sprintf(
+			"Download an artifact and read a file in it, e.g. a JUnit XML report or a coverage summary. Without `file`, the files in the artifact are listed (and shown if there is only one). Artifacts up to %d MiB can be downloaded, files up to %d KiB are returned inline. Not available on Forgejo 11 or earlier, whose API has no artifacts endpoints.",
+			tools.MaxArtifactSize>>20, maxInlineSize>>10),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"artifact_id": {
+					Type:        "integer",
+					Description: "Artifact ID, see list_action_artifacts",
+				},
+				"file": {
+					Type:        "string",
+					Description: "Path of the file in the artifact, or a glob pattern like '*.xml' (optional)",
+				},
+			},
+			Required: []string{"owner", "repo", "artifact_id"},
+		},
+	}
+}
+
+// Handler implements the logic for reading an artifact. It performs custom HTTP
+// GET requests to the `/repos/{owner}/{repo}/actions/artifacts/{artifact_id}` and
+// `/repos/{owner}/{repo}/actions/artifacts/{artifact_id}/zip` endpoints, and
+// extracts the selected file from the zip archive.
+func (impl GetActionArtifactImpl) Handler() mcp.ToolHandlerFor[GetActionArtifactParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args GetActionArtifactParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		// Call custom client methods
+		artifact, err := impl.Client.MyGetActionArtifact(p.Owner, p.Repo, int64(p.ArtifactID))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get artifact: %w", err)
+		}
+		if artifact.Expired {
+			return nil, nil, fmt.Errorf("artifact %s has expired", artifact.Name)
+		}
+		if artifact.SizeInBytes > tools.MaxArtifactSize {
+			return nil, nil, fmt.Errorf("artifact %s is too large to download (%d bytes, limit %d)", artifact.Name, artifact.SizeInBytes, tools.MaxArtifactSize)
+		}
+		data, err := impl.Client.MyDownloadActionArtifact(p.Owner, p.Repo, int64(p.ArtifactID))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to download artifact: %w", err)
+		}
+
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open artifact archive: %w", err)
+		}
+		files, err := matchZipFiles(zr, p.File)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		header := artifact.ToMarkdown()
+		if len(files) != 1 {
+			content := fmt.Sprintf("%s\n\n%d files", header, len(files))
+			if p.File != "" {
+				content += fmt.Sprintf(" matching `%s`", p.File)
+			}
+			content += ", use `file` to read one:\n\n" + zipFilesMarkdown(files)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{
+						Text: content,
+					},
+				},
+			}, nil, nil
+		}
+
+		f := files[0]
+		body, err := readZipFile(f, maxInlineSize)
+		if err != nil {
+			return nil, nil, err
+		}
+		header += fmt.Sprintf("\n\n### %s (%d bytes)", f.Name, f.UncompressedSize64)
+
+		// Text files are returned inline, others as embedded resources
+		if isText(body) {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{
+						Text: fmt.Sprintf("%s\n\n```%s\n%s\n```", header, codeLang(f.Name), strings.TrimRight(string(body), "\n")),
+					},
+				},
+			}, nil, nil
+		}
+
+		mimeType := mime.TypeByExtension(path.Ext(f.Name))
+		if mimeType == "" {
+			mimeType = "application/octet-stream"
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: header + "\n\nBinary file, returned as embedded resource.",
+				},
+				&mcp.EmbeddedResource{
+					Resource: &mcp.ResourceContents{
+						URI:      artifact.ArchiveDownloadURL + "#" + f.Name,
+						MIMEType: mimeType,
+						Blob:     body,
+					},
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// matchZipFiles returns the regular files in zr matching pattern: an exact
+// path, or a glob pattern matched against the path and the base name. An
+// empty pattern matches every file.
+func matchZipFiles(zr *zip.Reader, pattern string) ([]*zip.File, error) {
+	var ret []*zip.File
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		if pattern == "" {
+			ret = append(ret, f)
+			continue
+		}
+		if f.Name == pattern {
+			return []*zip.File{f}, nil
+		}
+		full, err := path.Match(pattern, f.Name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid file pattern: %w", err)
+		}
+		base, _ := path.Match(pattern, path.Base(f.Name))
+		if full || base {
+			ret = append(ret, f)
+		}
+	}
+	if pattern != "" && len(ret) == 0 {
+		return nil, fmt.Errorf("no file matching %q in artifact", pattern)
+	}
+	return ret, nil
+}
+
+// zipFilesMarkdown renders files as a bullet list with their sizes.
+func zipFilesMarkdown(files []*zip.File) string {
+	if len(files) == 0 {
+		return "*No files*"
+	}
+	var b strings.Builder
+	for _, f := range files {
+		fmt.Fprintf(&b, "- `%s` (%d bytes)\n", f.Name, f.UncompressedSize64)
+	}
+	return b.String()
+}
+
+// readZipFile reads f, failing if it is larger than maxSize.
+func readZipFile(f *zip.File, maxSize int64) ([]byte, error) {
+	if f.UncompressedSize64 > uint64(maxSize) {
+		return nil, fmt.Errorf("file %s is too large to return inline (%d bytes, limit %d)", f.Name, f.UncompressedSize64, maxSize)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("file %s is too large to return inline (limit %d bytes)", f.Name, maxSize)
+	}
+	return data, nil
+}
+
+// isText reports whether data looks like UTF-8 text.
+func isText(data []byte) bool {
+	return utf8.Valid(data) && !bytes.ContainsRune(data, 0)
+}
+
+// codeLang returns the language of a code block for a file name.
+func codeLang(name string) string {
+	switch ext := strings.TrimPrefix(path.Ext(name), "."); ext {
+	case "xml", "json", "html", "yaml", "csv":
+		return ext
+	case "yml":
+		return "yaml"
+	case "md":
+		return "markdown"
+	}
+	return ""
+}
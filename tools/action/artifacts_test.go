@@ -0,0 +1,96 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package action
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+)
+
+func testZip(t *testing.T, files map[string]string) *zip.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return zr
+}
+
+func TestMatchZipFiles(t *testing.T) {
+	zr := testZip(t, map[string]string{
+		"report/junit.xml":    "<testsuites/>",
+		"report/summary.txt":  "ok",
+		"coverage/cover.xml":  "<coverage/>",
+		"coverage/cover.html": "<html/>",
+	})
+
+	tests := []struct {
+		pattern string
+		want    int
+		wantErr bool
+	}{
+		{pattern: "", want: 4},
+		{pattern: "report/junit.xml", want: 1},
+		{pattern: "*.xml", want: 2},
+		{pattern: "coverage/*", want: 2},
+		{pattern: "*.json", wantErr: true},
+		{pattern: "[", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			files, err := matchZipFiles(zr, tt.pattern)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("matchZipFiles() error = %v", err)
+			}
+			if len(files) != tt.want {
+				t.Errorf("got %d files, want %d", len(files), tt.want)
+			}
+		})
+	}
+}
+
+func TestReadZipFile(t *testing.T) {
+	zr := testZip(t, map[string]string{"a.txt": "hello"})
+	data, err := readZipFile(zr.File[0], 5)
+	if err != nil || string(data) != "hello" {
+		t.Errorf("readZipFile() = %q, %v", data, err)
+	}
+	if _, err := readZipFile(zr.File[0], 4); err == nil {
+		t.Error("expected error for file over the limit")
+	}
+}
+
+func TestIsText(t *testing.T) {
+	if !isText([]byte("<xml>ünïcode</xml>")) {
+		t.Error("expected text")
+	}
+	if isText([]byte{0x50, 0x4b, 0x03, 0x04, 0x00}) || isText([]byte{0xff, 0xfe}) {
+		t.Error("expected binary")
+	}
+}
@@ -0,0 +1,411 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package action
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// DispatchWorkflowParams defines the parameters for the dispatch_workflow tool.
+// It specifies the workflow, the ref to run on and the inputs.
+type DispatchWorkflowParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Workflow is the file name of the workflow, e.g. 'deploy.yml'.
+	Workflow string `json:"workflow"`
+	// Ref is the branch or tag to run the workflow on.
+	Ref string `json:"ref,omitempty"`
+	// Inputs are the values of the workflow_dispatch inputs.
+	Inputs map[string]any `json:"inputs,omitempty"`
+	// DryRun previews the dispatch without sending it.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// DispatchWorkflowImpl implements the MCP tool for triggering a workflow with a
+// `workflow_dispatch` event. Inputs are validated against the inputs declared in
+// the workflow file. Note: This feature is not supported by the official Forgejo
+// SDK and requires a custom HTTP implementation.
+type DispatchWorkflowImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `dispatch_workflow` tool. It requires `owner`, `repo`
+// and `workflow`. It is not idempotent, as every call starts a new run.
+func (DispatchWorkflowImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "dispatch_workflow",
+		Title:       "Dispatch Workflow",
+		Description: "Trigger a workflow that has a workflow_dispatch trigger on a branch or tag. Inputs are validated against the inputs declared in the workflow file (types boolean, number and choice are checked, required inputs must be given). Use dry_run to see the declared inputs.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  false,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"workflow": {
+					Type:        "string",
+					Description: "Workflow file name, e.g. 'deploy.yml', or its path in the repository",
+				},
+				"ref": {
+					Type:        "string",
+					Description: "Branch or tag to run the workflow on (optional, defaults to the default branch)",
+				},
+				"inputs": {
+					Type:        "object",
+					Description: "Values of the workflow inputs, keyed by input name; strings, numbers and booleans are accepted (optional)",
+				},
+				"dry_run": tools.DryRunSchema(),
+			},
+			Required: []string{"owner", "repo", "workflow"},
+		},
+	}
+}
+
+// Handler implements the logic for dispatching a workflow. It reads the workflow
+// file to validate the inputs, then performs a custom HTTP POST request to the
+// `/repos/{owner}/{repo}/actions/workflows/{workflow}/dispatches` endpoint.
+func (impl DispatchWorkflowImpl) Handler() mcp.ToolHandlerFor[DispatchWorkflowParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args DispatchWorkflowParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		ref := p.Ref
+		if ref == "" {
+			repo, _, err := impl.Client.GetRepo(p.Owner, p.Repo)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get repository: %w", err)
+			}
+			ref = repo.DefaultBranch
+		}
+
+		wf, err := fetchWorkflow(impl.Client, p.Owner, p.Repo, ref, p.Workflow)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read workflow: %w", err)
+		}
+		inputs, err := wf.validateInputs(p.Inputs)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid inputs for %s:\n%w\n\nDeclared inputs:\n%s", wf.Path, err, wf.inputsMarkdown())
+		}
+
+		file := path.Base(wf.Path)
+		opt := tools.MyDispatchWorkflowOption{
+			Ref:           ref,
+			Inputs:        inputs,
+			ReturnRunInfo: true,
+		}
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, wf, file, opt)
+			return res, nil, err
+		}
+
+		// Call custom client method
+		run, err := impl.Client.MyDispatchWorkflow(p.Owner, p.Repo, file, opt)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to dispatch workflow: %w", err)
+		}
+
+		content := fmt.Sprintf("Dispatched workflow %s on %s", wf.Path, ref)
+		if run != nil && run.ID > 0 {
+			content += ": " + run.ToMarkdown()
+		} else {
+			content += ". Use list_action_runs with event 'workflow_dispatch' to find the run."
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: content,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// dryRun previews the workflow to be dispatched with its resolved inputs.
+func (impl DispatchWorkflowImpl) dryRun(p DispatchWorkflowParams, wf *dispatchWorkflow, file string, opt tools.MyDispatchWorkflowOption) (*mcp.CallToolResult, error) {
+	name := wf.Name
+	if name == "" {
+		name = file
+	}
+	preview := fmt.Sprintf("Would dispatch workflow **%s** (%s) in %s/%s on `%s`.\n\n### Declared Inputs\n%s",
+		name, wf.Path, p.Owner, p.Repo, opt.Ref, wf.inputsMarkdown())
+	if len(opt.Inputs) > 0 {
+		preview += "\n### Values\n"
+		for _, k := range wf.inputNames() {
+			if v, ok := opt.Inputs[k]; ok {
+				preview += fmt.Sprintf("- `%s`: %s\n", k, v)
+			}
+		}
+	}
+	preview = strings.TrimRight(preview, "\n")
+
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "POST",
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/actions/workflows/%s/dispatches", p.Owner, p.Repo, file),
+		Body:     opt,
+	})
+}
+
+// RerunActionRunParams defines the parameters for the rerun_action_run tool.
+// It specifies the run, and optionally a single job, to rerun.
+type RerunActionRunParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// RunID is the ID of the workflow run.
+	RunID int `json:"run_id"`
+	// JobID is the ID of a single job to rerun.
+	JobID int `json:"job_id,omitempty"`
+	// DryRun previews the rerun without sending it.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// RerunActionRunImpl implements the MCP tool for rerunning a completed workflow
+// run or one of its jobs. Note: This feature is not supported by the official
+// Forgejo SDK and requires a custom HTTP implementation.
+type RerunActionRunImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `rerun_action_run` tool. It requires `owner`, `repo`
+// and `run_id`. It is not idempotent, as every call starts a new attempt.
+func (RerunActionRunImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "rerun_action_run",
+		Title:       "Rerun Workflow Run",
+		Description: "Rerun all jobs of a completed workflow run, or a single job with job_id. Useful to re-trigger flaky pipelines. Not available on Forgejo 11 or earlier, whose API cannot rerun workflow runs.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  false,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"run_id": {
+					Type:        "integer",
+					Description: "Workflow run ID (not the run number)",
+				},
+				"job_id": {
+					Type:        "integer",
+					Description: "Rerun only this job (optional, defaults to all jobs)",
+				},
+				"dry_run": tools.DryRunSchema(),
+			},
+			Required: []string{"owner", "repo", "run_id"},
+		},
+	}
+}
+
+// Handler implements the logic for rerunning a workflow run. It performs a custom
+// HTTP POST request to the `/repos/{owner}/{repo}/actions/runs/{run}/rerun`
+// endpoint, or its per-job variant.
+func (impl RerunActionRunImpl) Handler() mcp.ToolHandlerFor[RerunActionRunParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args RerunActionRunParams) (*mcp.CallToolResult, any, error) {
+		p := args
+		if err := impl.Client.CheckAPI(tools.APIActionRuns); err != nil {
+			return nil, nil, err
+		}
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p)
+			return res, nil, err
+		}
+
+		// Call custom client method
+		err := impl.Client.MyRerunActionRun(p.Owner, p.Repo, int64(p.RunID), int64(p.JobID))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to rerun workflow run: %w", err)
+		}
+
+		content := fmt.Sprintf("Workflow run %d has been scheduled to rerun.", p.RunID)
+		if p.JobID > 0 {
+			content = fmt.Sprintf("Job %d of workflow run %d has been scheduled to rerun.", p.JobID, p.RunID)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: content,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// dryRun checks the run (and job) exists and is completed, and previews the
+// rerun.
+func (impl RerunActionRunImpl) dryRun(p RerunActionRunParams) (*mcp.CallToolResult, error) {
+	run, err := impl.Client.MyGetActionRun(p.Owner, p.Repo, int64(p.RunID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow run: %w", err)
+	}
+	if run.Status != "completed" {
+		return nil, fmt.Errorf("workflow run %d is %s, only completed runs can be rerun", p.RunID, run.Status)
+	}
+
+	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/actions/runs/%d", p.Owner, p.Repo, p.RunID)
+	preview := "Would rerun all jobs of workflow run:\n\n" + run.ToMarkdown()
+	if p.JobID > 0 {
+		jobs, err := impl.Client.MyListActionRunJobs(p.Owner, p.Repo, int64(p.RunID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list jobs: %w", err)
+		}
+		var job *types.MyActionJob
+		for _, j := range jobs.Jobs {
+			if j.ID == int64(p.JobID) {
+				job = j
+			}
+		}
+		if job == nil {
+			return nil, fmt.Errorf("job %d not found in workflow run %d", p.JobID, p.RunID)
+		}
+		endpoint += fmt.Sprintf("/jobs/%d", p.JobID)
+		preview = fmt.Sprintf("Would rerun job **%s** `%s` (Job ID: %d) of workflow run:\n\n%s",
+			job.Name, job.State(), job.ID, run.ToMarkdown())
+	}
+
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "POST",
+		Endpoint: endpoint + "/rerun",
+	})
+}
+
+// CancelActionRunParams defines the parameters for the cancel_action_run tool.
+// It specifies the run to cancel.
+type CancelActionRunParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// RunID is the ID of the workflow run.
+	RunID int `json:"run_id"`
+	// DryRun previews the cancellation without sending it.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// CancelActionRunImpl implements the MCP tool for cancelling a queued or running
+// workflow run. Note: This feature is not supported by the official Forgejo SDK
+// and requires a custom HTTP implementation.
+type CancelActionRunImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `cancel_action_run` tool. It requires `owner`, `repo`
+// and `run_id`. It is marked as destructive, as unfinished jobs are aborted.
+func (CancelActionRunImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "cancel_action_run",
+		Title:       "Cancel Workflow Run",
+		Description: "Cancel a queued or running workflow run. Unfinished jobs are aborted. Not available on Forgejo 11 or earlier, whose API cannot cancel workflow runs.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(true),
+			IdempotentHint:  true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"run_id": {
+					Type:        "integer",
+					Description: "Workflow run ID (not the run number)",
+				},
+				"dry_run": tools.DryRunSchema(),
+			},
+			Required: []string{"owner", "repo", "run_id"},
+		},
+	}
+}
+
+// Handler implements the logic for cancelling a workflow run. It performs a
+// custom HTTP POST request to the `/repos/{owner}/{repo}/actions/runs/{run}/cancel`
+// endpoint.
+func (impl CancelActionRunImpl) Handler() mcp.ToolHandlerFor[CancelActionRunParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args CancelActionRunParams) (*mcp.CallToolResult, any, error) {
+		p := args
+		if err := impl.Client.CheckAPI(tools.APIActionRuns); err != nil {
+			return nil, nil, err
+		}
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p)
+			return res, nil, err
+		}
+
+		// Call custom client method
+		err := impl.Client.MyCancelActionRun(p.Owner, p.Repo, int64(p.RunID))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to cancel workflow run: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("Workflow run %d has been cancelled.", p.RunID),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// dryRun checks the run is not completed yet and previews the cancellation.
+func (impl CancelActionRunImpl) dryRun(p CancelActionRunParams) (*mcp.CallToolResult, error) {
+	run, err := impl.Client.MyGetActionRun(p.Owner, p.Repo, int64(p.RunID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow run: %w", err)
+	}
+	if run.Status == "completed" {
+		return nil, fmt.Errorf("workflow run %d is already completed (%s)", p.RunID, run.State())
+	}
+
+	preview := "Would cancel workflow run:\n\n" + run.ToMarkdown()
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "POST",
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/actions/runs/%d/cancel", p.Owner, p.Repo, p.RunID),
+	})
+}
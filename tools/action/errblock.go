@@ -0,0 +1,161 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package action
+
+import (
+	"regexp"
+	"strings"
+)
+
+// maxErrorBlock is the maximum number of lines in an extracted error block.
+const maxErrorBlock = 15
+
+// Kinds of failures recognized by extractFailure.
+const (
+	failurePanic   = "panic"
+	failureTest    = "test"
+	failureCompile = "compile"
+	failureError   = "error"
+	failureUnknown = "unknown"
+)
+
+// jobFailure is the first error block found in a job log.
+type jobFailure struct {
+	// Kind is one of the failure* constants.
+	Kind string
+	// Lines is the error block.
+	Lines []string
+	// ExitCode is the exit code reported by the runner, if any.
+	ExitCode string
+}
+
+var (
+	// logTimestampRe matches the timestamp runners prefix to each line.
+	logTimestampRe = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z\s?`)
+	panicRe        = regexp.MustCompile(`^(panic: |fatal error: |thread '.*' panicked at|Traceback \(most recent call last\):)`)
+	goTestFailRe   = regexp.MustCompile(`^\s*--- FAIL: `)
+	compileErrorRe = regexp.MustCompile(`^\S+\.(go|c|cc|cpp|h|rs|ts|tsx|js|java|kt|py|cs|swift):\d+(:\d+)?:?\s|^error(\[E\d+\])?: |error TS\d+: `)
+	genericErrorRe = regexp.MustCompile(`(?i)(^|\W)(error|err!|fatal|failed|failure)(\W|$)`)
+	exitCodeRe     = regexp.MustCompile(`(?i)exit(ed with)? (code|status) '?(\d+)'?|exitcode '(\d+)'`)
+	// ignoredErrorRe matches lines mentioning errors without being one.
+	ignoredErrorRe = regexp.MustCompile(`(?i)^\s*(::(group|endgroup|debug)|##\[|shell: |\S+=)|continue-on-error|\b0 (errors?|failed)\b`)
+)
+
+// cleanLogLines strips ANSI sequences and runner timestamps from a log.
+func cleanLogLines(log string) []string {
+	lines := splitLog(StripANSI(log))
+	for i, l := range lines {
+		lines[i] = logTimestampRe.ReplaceAllString(l, "")
+	}
+	return lines
+}
+
+// extractFailure finds the first error block in a job log. Specific patterns
+// (panics, Go test failures, compiler errors) win over generic error lines.
+func extractFailure(log string) *jobFailure {
+	lines := cleanLogLines(log)
+	ret := &jobFailure{Kind: failureUnknown}
+
+	for i := len(lines) - 1; i >= 0; i-- {
+		if m := exitCodeRe.FindStringSubmatch(lines[i]); m != nil {
+			ret.ExitCode = m[3] + m[4]
+			break
+		}
+	}
+
+	if i := indexOf(lines, panicRe); i >= 0 {
+		ret.Kind = failurePanic
+		ret.Lines = takeUntil(lines[i:], func(l string) bool {
+			t := strings.TrimSpace(l)
+			return strings.HasPrefix(t, "FAIL") || strings.HasPrefix(t, "exit status")
+		})
+		return ret
+	}
+	if i := indexOf(lines, goTestFailRe); i >= 0 {
+		ret.Kind = failureTest
+		ret.Lines = append([]string{lines[i]}, takeUntil(lines[i+1:], func(l string) bool {
+			t := strings.TrimSpace(l)
+			return strings.HasPrefix(t, "--- ") || strings.HasPrefix(t, "=== ") ||
+				t == "FAIL" || strings.HasPrefix(t, "FAIL\t") || strings.HasPrefix(t, "ok  ")
+		})...)
+		return ret
+	}
+	if i := indexOf(lines, compileErrorRe); i >= 0 {
+		ret.Kind = failureCompile
+		ret.Lines = takeUntil(lines[i:], func(l string) bool { return strings.TrimSpace(l) == "" })
+		return ret
+	}
+	for i, l := range lines {
+		if !genericErrorRe.MatchString(l) || ignoredErrorRe.MatchString(l) || exitCodeRe.MatchString(l) {
+			continue
+		}
+		ret.Kind = failureError
+		start := max(i-2, 0)
+		ret.Lines = lines[start:min(i+6, len(lines))]
+		return ret
+	}
+
+	// Nothing recognized, fall back to the end of the log
+	ret.Lines = lines[max(len(lines)-5, 0):]
+	return ret
+}
+
+// indexOf returns the index of the first line matching re, or -1.
+func indexOf(lines []string, re *regexp.Regexp) int {
+	for i, l := range lines {
+		if re.MatchString(l) {
+			return i
+		}
+	}
+	return -1
+}
+
+// takeUntil returns the leading lines until stop reports true, at most
+// maxErrorBlock lines. The first line is always taken.
+func takeUntil(lines []string, stop func(string) bool) []string {
+	n := 0
+	for n < len(lines) && n < maxErrorBlock {
+		if n > 0 && stop(lines[n]) {
+			break
+		}
+		n++
+	}
+	return lines[:n]
+}
+
+var (
+	normDurationRe = regexp.MustCompile(`\(\d+(\.\d+)?m?s\)`)
+	normHexRe      = regexp.MustCompile(`\b(0x)?[0-9a-fA-F]{7,}\b`)
+	normNumberRe   = regexp.MustCompile(`\d+`)
+	normSpaceRe    = regexp.MustCompile(`\s+`)
+)
+
+// Signature identifies recurring failures: the kind and the first line of the
+// block with durations, hashes and numbers normalized.
+func (f *jobFailure) Signature() string {
+	first := ""
+	if len(f.Lines) > 0 {
+		first = f.Lines[0]
+		if f.Kind == failureError {
+			// Generic blocks include leading context, use the error line
+			for _, l := range f.Lines {
+				if genericErrorRe.MatchString(l) && !ignoredErrorRe.MatchString(l) {
+					first = l
+					break
+				}
+			}
+		}
+	}
+	first = normDurationRe.ReplaceAllString(first, "")
+	first = normHexRe.ReplaceAllString(first, "H")
+	first = normNumberRe.ReplaceAllString(first, "N")
+	first = strings.TrimSpace(normSpaceRe.ReplaceAllString(first, " "))
+	if f.Kind == failureUnknown {
+		return f.Kind + ": exit " + f.ExitCode
+	}
+	return f.Kind + ": " + first
+}
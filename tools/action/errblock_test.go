@@ -0,0 +1,92 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package action
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractFailure(t *testing.T) {
+	tests := []struct {
+		name      string
+		log       string
+		kind      string
+		first     string
+		exitCode  string
+		signature string
+	}{
+		{
+			name: "go test failure",
+			log: `2024-01-15T14:30:00.1234567Z === RUN   TestLogin
+2024-01-15T14:30:00.2234567Z --- FAIL: TestLogin (0.02s)
+2024-01-15T14:30:00.3234567Z     login_test.go:42: expected 200, got 500
+2024-01-15T14:30:00.4234567Z === RUN   TestLogout
+2024-01-15T14:30:01.0000000Z FAIL
+2024-01-15T14:30:01.1000000Z ::error::Process completed with exit code 1.`,
+			kind:      failureTest,
+			first:     "--- FAIL: TestLogin (0.02s)",
+			exitCode:  "1",
+			signature: "test: --- FAIL: TestLogin",
+		},
+		{
+			name:      "panic wins over test failure",
+			log:       "--- FAIL: TestX (0.00s)\npanic: runtime error: index out of range [3] with length 3\n\ngoroutine 7 [running]:\nFAIL\tpkg\t0.01s\nexit status 2",
+			kind:      failurePanic,
+			first:     "panic: runtime error: index out of range [3] with length 3",
+			exitCode:  "2",
+			signature: "panic: panic: runtime error: index out of range [N] with length N",
+		},
+		{
+			name:      "compiler error",
+			log:       "go build ./...\n# pkg\n./main.go:10:2: undefined: foo\n./main.go:11:2: undefined: bar\n\nexit code 1",
+			kind:      failureCompile,
+			first:     "./main.go:10:2: undefined: foo",
+			signature: "compile: ./main.go:N:N: undefined: foo",
+		},
+		{
+			name:      "generic error",
+			log:       "npm ci\nadded 100 packages\nnpm ERR! code E404\nnpm ERR! 404 Not Found",
+			kind:      failureError,
+			first:     "npm ci",
+			signature: "error: npm ERR! code EN",
+		},
+		{
+			name:      "unknown",
+			log:       "step one\nstep two\nexited with code 137",
+			kind:      failureUnknown,
+			exitCode:  "137",
+			signature: "unknown: exit 137",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := extractFailure(tt.log)
+			if f.Kind != tt.kind {
+				t.Errorf("Kind = %q, want %q (lines %q)", f.Kind, tt.kind, f.Lines)
+			}
+			if tt.first != "" && (len(f.Lines) == 0 || f.Lines[0] != tt.first) {
+				t.Errorf("first line = %q, want %q", f.Lines, tt.first)
+			}
+			if tt.exitCode != "" && f.ExitCode != tt.exitCode {
+				t.Errorf("ExitCode = %q, want %q", f.ExitCode, tt.exitCode)
+			}
+			if got := f.Signature(); got != tt.signature {
+				t.Errorf("Signature() = %q, want %q", got, tt.signature)
+			}
+		})
+	}
+}
+
+func TestExtractFailure_TestBlockStops(t *testing.T) {
+	log := "--- FAIL: TestA (0.01s)\n    a_test.go:1: boom\n--- FAIL: TestB (0.01s)\n    b_test.go:1: bang"
+	f := extractFailure(log)
+	if got := strings.Join(f.Lines, "\n"); got != "--- FAIL: TestA (0.01s)\n    a_test.go:1: boom" {
+		t.Errorf("Lines = %q", got)
+	}
+}
@@ -0,0 +1,313 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package action
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+const (
+	// defaultWindowDays is the default time window of CI analysis tools.
+	defaultWindowDays = 7
+	// maxFailureLogs is the maximum number of job logs summarize_ci_failures
+	// downloads in one call.
+	maxFailureLogs = 50
+)
+
+// SummarizeCIFailuresParams defines the parameters for the summarize_ci_failures
+// tool. It selects the failed runs to analyze.
+type SummarizeCIFailuresParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Branch limits the analysis to runs on a branch.
+	Branch string `json:"branch,omitempty"`
+	// Days is the time window in days.
+	Days int `json:"days,omitempty"`
+	// MaxRuns is the maximum number of failed runs to analyze.
+	MaxRuns int `json:"max_runs,omitempty"`
+}
+
+// SummarizeCIFailuresImpl implements the read-only MCP tool for summarizing
+// recent CI failures. It reads the logs of failed jobs, extracts the first
+// error block of each and groups recurring failures across runs. This is a
+// safe, idempotent operation.
+type SummarizeCIFailuresImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `summarize_ci_failures` tool. It requires `owner` and
+// `repo`. It is marked as a safe, read-only operation.
+func (SummarizeCIFailuresImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:  "summarize_ci_failures",
+		Title: "Summarize CI Failures",
+		Description: fmt.Sprintf(
+			"Summarize failed workflow runs of a repository in a time window: reads the logs of failed jobs (at most %d), extracts the first error block of each (panics, Go test failures, compiler errors, other errors, exit codes) and groups recurring failures across runs, most frequent first. Use get_action_job_log to dig into a job.",
+			maxFailureLogs),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"branch": {
+					Type:        "string",
+					Description: "Only analyze runs on this branch (optional)",
+				},
+				"days": {
+					Type:        "integer",
+					Description: fmt.Sprintf("Time window in days (optional, defaults to %d)", defaultWindowDays),
+					Minimum:     tools.Float64Ptr(1),
+					Maximum:     tools.Float64Ptr(90),
+				},
+				"max_runs": {
+					Type:        "integer",
+					Description: "Maximum number of failed runs to analyze, newest first (optional, defaults to 20)",
+					Minimum:     tools.Float64Ptr(1),
+					Maximum:     tools.Float64Ptr(100),
+				},
+			},
+			Required: []string{"owner", "repo"},
+		},
+	}
+}
+
+// failureGroup is a failure recurring across jobs.
+type failureGroup struct {
+	Signature string
+	Failure   *jobFailure
+	Jobs      map[string]int
+	Runs      []*types.MyActionRun
+	Steps     map[string]bool
+}
+
+// Handler implements the logic for summarizing failures. It lists failed runs
+// via `/repos/{owner}/{repo}/actions/runs`, their jobs and the logs of failed
+// jobs, and renders a markdown digest.
+func (impl SummarizeCIFailuresImpl) Handler() mcp.ToolHandlerFor[SummarizeCIFailuresParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args SummarizeCIFailuresParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		days := p.Days
+		if days <= 0 {
+			days = defaultWindowDays
+		}
+		maxRuns := p.MaxRuns
+		if maxRuns <= 0 {
+			maxRuns = 20
+		}
+		since := time.Now().AddDate(0, 0, -days)
+
+		runs, err := collectRuns(impl.Client, p.Owner, p.Repo, tools.MyListActionRunsOptions{
+			Status: "failure",
+			Branch: p.Branch,
+		}, since, maxRuns)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list workflow runs: %w", err)
+		}
+
+		groups := map[string]*failureGroup{}
+		jobCount, logCount := 0, 0
+		var skipped []string
+		for _, run := range runs {
+			jobs, err := impl.Client.MyListActionRunJobs(p.Owner, p.Repo, run.ID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to list jobs of run %d: %w", run.ID, err)
+			}
+			for _, job := range jobs.Jobs {
+				if job.Conclusion != "failure" {
+					continue
+				}
+				jobCount++
+				if logCount >= maxFailureLogs {
+					skipped = append(skipped, fmt.Sprintf("%s (Job ID: %d)", job.Name, job.ID))
+					continue
+				}
+				logCount++
+
+				log, err := impl.Client.MyGetActionJobLogs(p.Owner, p.Repo, job.ID)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to get log of job %d: %w", job.ID, err)
+				}
+				f := extractFailure(log)
+				sig := f.Signature()
+				g, ok := groups[sig]
+				if !ok {
+					g = &failureGroup{Signature: sig, Failure: f, Jobs: map[string]int{}, Steps: map[string]bool{}}
+					groups[sig] = g
+				}
+				g.Jobs[job.Name]++
+				if len(g.Runs) == 0 || g.Runs[len(g.Runs)-1].ID != run.ID {
+					g.Runs = append(g.Runs, run)
+				}
+				for _, s := range job.Steps {
+					if s.Conclusion == "failure" {
+						g.Steps[s.Name] = true
+						break
+					}
+				}
+			}
+		}
+
+		content := renderFailureSummary(p, days, len(runs), jobCount, sortFailureGroups(groups), skipped)
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: content,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// collectRuns lists workflow runs matching opt, newest first, until a run
+// started before since or limit runs are collected.
+func collectRuns(cl *tools.Client, owner, repo string, opt tools.MyListActionRunsOptions, since time.Time, limit int) ([]*types.MyActionRun, error) {
+	const pageSize = 50
+	opt.Limit = pageSize
+
+	var ret []*types.MyActionRun
+	for page := 1; ; page++ {
+		opt.Page = page
+		resp, err := cl.MyListActionRuns(owner, repo, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, run := range resp.WorkflowRuns {
+			if !run.StartedAt.IsZero() && run.StartedAt.Before(since) {
+				return ret, nil
+			}
+			ret = append(ret, run)
+			if len(ret) >= limit {
+				return ret, nil
+			}
+		}
+		if len(resp.WorkflowRuns) < pageSize {
+			return ret, nil
+		}
+	}
+}
+
+// sortFailureGroups orders groups by number of runs, then by the most recent
+// occurrence.
+func sortFailureGroups(groups map[string]*failureGroup) []*failureGroup {
+	ret := make([]*failureGroup, 0, len(groups))
+	for _, g := range groups {
+		ret = append(ret, g)
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		if len(ret[i].Runs) != len(ret[j].Runs) {
+			return len(ret[i].Runs) > len(ret[j].Runs)
+		}
+		return ret[i].Runs[0].StartedAt.After(ret[j].Runs[0].StartedAt)
+	})
+	return ret
+}
+
+// renderFailureSummary renders failure groups as markdown.
+func renderFailureSummary(p SummarizeCIFailuresParams, days, runCount, jobCount int, groups []*failureGroup, skipped []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# CI failures in %s/%s", p.Owner, p.Repo)
+	if p.Branch != "" {
+		fmt.Fprintf(&b, " on %s", p.Branch)
+	}
+	fmt.Fprintf(&b, " (last %d days)\n\n", days)
+	if runCount == 0 {
+		b.WriteString("*No failed workflow runs found*\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "Analyzed %d failed runs with %d failed jobs: %d distinct failures.\n", runCount, jobCount, len(groups))
+
+	for i, g := range groups {
+		f := g.Failure
+		title := f.Kind
+		if len(f.Lines) > 0 && f.Kind != failureUnknown {
+			title += ": " + strings.TrimPrefix(g.Signature, f.Kind+": ")
+		}
+		fmt.Fprintf(&b, "\n## %d. %s\n\n", i+1, title)
+		fmt.Fprintf(&b, "- Occurrences: %d runs\n", len(g.Runs))
+		fmt.Fprintf(&b, "- Jobs: %s\n", countList(g.Jobs))
+		if len(g.Steps) > 0 {
+			fmt.Fprintf(&b, "- Failed steps: %s\n", strings.Join(sortedKeys(g.Steps), ", "))
+		}
+		if f.ExitCode != "" {
+			fmt.Fprintf(&b, "- Exit code: %s\n", f.ExitCode)
+		}
+		runs := make([]string, len(g.Runs))
+		for j, r := range g.Runs {
+			runs[j] = fmt.Sprintf("#%d (ID: %d, %s @ %s)", r.RunNumber, r.ID, r.StartedAt.Format("2006-01-02 15:04"), shortSHA(r.HeadSHA))
+		}
+		fmt.Fprintf(&b, "- Runs: %s\n", strings.Join(runs, ", "))
+		if len(f.Lines) > 0 {
+			fmt.Fprintf(&b, "\n```\n%s\n```\n", strings.Join(f.Lines, "\n"))
+		}
+	}
+
+	if len(skipped) > 0 {
+		fmt.Fprintf(&b, "\n%d failed jobs were not analyzed (log limit reached): %s\n", len(skipped), strings.Join(skipped, ", "))
+	}
+	return b.String()
+}
+
+// countList renders counts like "test (3), lint (1)", most frequent first.
+func countList(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s (%d)", k, counts[k])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]bool) []string {
+	ret := make([]string, 0, len(m))
+	for k := range m {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// shortSHA returns the first 8 characters of a commit SHA.
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
@@ -0,0 +1,275 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package action
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// defaultFlakyWindowDays is the default time window of detect_flaky_workflows.
+const defaultFlakyWindowDays = 30
+
+// DetectFlakyWorkflowsParams defines the parameters for the
+// detect_flaky_workflows tool. It selects the task history to analyze.
+type DetectFlakyWorkflowsParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Days is the time window in days.
+	Days int `json:"days,omitempty"`
+	// MaxTasks is the maximum number of tasks to analyze.
+	MaxTasks int `json:"max_tasks,omitempty"`
+}
+
+// DetectFlakyWorkflowsImpl implements the read-only MCP tool for finding flaky
+// workflows in the Actions task history. A job is flaky when it both failed and
+// succeeded on the same commit. This is a safe, idempotent operation.
+type DetectFlakyWorkflowsImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `detect_flaky_workflows` tool. It requires `owner` and
+// `repo`. It is marked as a safe, read-only operation.
+func (DetectFlakyWorkflowsImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "detect_flaky_workflows",
+		Title:       "Detect Flaky Workflows",
+		Description: "Analyze the Actions task history of a repository: find workflows whose jobs both failed and succeeded on the same head commit, and compute failure rates and median durations per workflow. Returns a table ranked by flakiness, then failure rate.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"days": {
+					Type:        "integer",
+					Description: fmt.Sprintf("Time window in days (optional, defaults to %d)", defaultFlakyWindowDays),
+					Minimum:     tools.Float64Ptr(1),
+					Maximum:     tools.Float64Ptr(365),
+				},
+				"max_tasks": {
+					Type:        "integer",
+					Description: "Maximum number of tasks to analyze, newest first (optional, defaults to 500)",
+					Minimum:     tools.Float64Ptr(1),
+					Maximum:     tools.Float64Ptr(5000),
+				},
+			},
+			Required: []string{"owner", "repo"},
+		},
+	}
+}
+
+// Handler implements the logic for detecting flaky workflows. It pages through
+// `/repos/{owner}/{repo}/actions/tasks` and aggregates the tasks per workflow.
+func (impl DetectFlakyWorkflowsImpl) Handler() mcp.ToolHandlerFor[DetectFlakyWorkflowsParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args DetectFlakyWorkflowsParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		days := p.Days
+		if days <= 0 {
+			days = defaultFlakyWindowDays
+		}
+		maxTasks := p.MaxTasks
+		if maxTasks <= 0 {
+			maxTasks = 500
+		}
+		since := time.Now().AddDate(0, 0, -days)
+
+		tasks, err := collectTasks(impl.Client, p.Owner, p.Repo, since, maxTasks)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list action tasks: %w", err)
+		}
+
+		stats := analyzeFlaky(tasks)
+		content := fmt.Sprintf("# Flaky workflows in %s/%s (last %d days)\n\nAnalyzed %d tasks.\n\n%s",
+			p.Owner, p.Repo, days, len(tasks), renderFlakyTable(stats))
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: content,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// collectTasks lists action tasks, newest first, until a task created before
+// since or limit tasks are collected.
+func collectTasks(cl *tools.Client, owner, repo string, since time.Time, limit int) ([]*types.MyActionTask, error) {
+	const pageSize = 50
+
+	var ret []*types.MyActionTask
+	for page := 1; ; page++ {
+		resp, err := cl.MyListActionTasks(owner, repo, page, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range resp.WorkflowRuns {
+			if !task.CreatedAt.IsZero() && task.CreatedAt.Before(since) {
+				return ret, nil
+			}
+			ret = append(ret, task)
+			if len(ret) >= limit {
+				return ret, nil
+			}
+		}
+		if len(resp.WorkflowRuns) < pageSize {
+			return ret, nil
+		}
+	}
+}
+
+// workflowStats aggregates the tasks of a workflow.
+type workflowStats struct {
+	Workflow string
+	// Runs is the number of distinct run numbers.
+	Runs int
+	// Finished is the number of tasks that succeeded or failed.
+	Finished int
+	Failed   int
+	// FlakySHAs is the number of commits where a job both failed and succeeded.
+	FlakySHAs int
+	// FlakyJobs counts flaky commits per job name.
+	FlakyJobs      map[string]int
+	MedianDuration time.Duration
+}
+
+// FailureRate returns the share of finished tasks that failed.
+func (s *workflowStats) FailureRate() float64 {
+	if s.Finished == 0 {
+		return 0
+	}
+	return float64(s.Failed) / float64(s.Finished)
+}
+
+// analyzeFlaky aggregates tasks per workflow, ranked by number of flaky
+// commits, then failure rate. Tasks are jobs; a job is flaky on a commit if it
+// both failed and succeeded there, e.g. after a rerun.
+func analyzeFlaky(tasks []*types.MyActionTask) []*workflowStats {
+	type jobKey struct{ workflow, job, sha string }
+	outcomes := map[jobKey]map[string]bool{}
+	byWorkflow := map[string]*workflowStats{}
+	runs := map[string]map[int64]bool{}
+	durations := map[string][]time.Duration{}
+
+	for _, t := range tasks {
+		s, ok := byWorkflow[t.WorkflowID]
+		if !ok {
+			s = &workflowStats{Workflow: t.WorkflowID, FlakyJobs: map[string]int{}}
+			byWorkflow[t.WorkflowID] = s
+			runs[t.WorkflowID] = map[int64]bool{}
+		}
+		runs[t.WorkflowID][t.RunNumber] = true
+
+		if t.Status != "success" && t.Status != "failure" {
+			continue
+		}
+		s.Finished++
+		if t.Status == "failure" {
+			s.Failed++
+		}
+		if !t.RunStartedAt.IsZero() && t.UpdatedAt.After(t.RunStartedAt) {
+			durations[t.WorkflowID] = append(durations[t.WorkflowID], t.UpdatedAt.Sub(t.RunStartedAt))
+		}
+		if t.HeadSHA != "" {
+			k := jobKey{t.WorkflowID, t.Name, t.HeadSHA}
+			if outcomes[k] == nil {
+				outcomes[k] = map[string]bool{}
+			}
+			outcomes[k][t.Status] = true
+		}
+	}
+
+	flakySHAs := map[string]map[string]bool{}
+	for k, o := range outcomes {
+		if !o["success"] || !o["failure"] {
+			continue
+		}
+		byWorkflow[k.workflow].FlakyJobs[k.job]++
+		if flakySHAs[k.workflow] == nil {
+			flakySHAs[k.workflow] = map[string]bool{}
+		}
+		flakySHAs[k.workflow][k.sha] = true
+	}
+
+	ret := make([]*workflowStats, 0, len(byWorkflow))
+	for name, s := range byWorkflow {
+		s.Runs = len(runs[name])
+		s.FlakySHAs = len(flakySHAs[name])
+		s.MedianDuration = median(durations[name])
+		ret = append(ret, s)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		a, b := ret[i], ret[j]
+		if a.FlakySHAs != b.FlakySHAs {
+			return a.FlakySHAs > b.FlakySHAs
+		}
+		if a.FailureRate() != b.FailureRate() {
+			return a.FailureRate() > b.FailureRate()
+		}
+		return a.Workflow < b.Workflow
+	})
+	return ret
+}
+
+// median returns the median of ds, or 0 if empty.
+func median(ds []time.Duration) time.Duration {
+	if len(ds) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), ds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// renderFlakyTable renders workflow stats as a ranked markdown table.
+func renderFlakyTable(stats []*workflowStats) string {
+	if len(stats) == 0 {
+		return "*No action tasks found*"
+	}
+	var b strings.Builder
+	b.WriteString("| # | Workflow | Runs | Failed / Finished | Failure Rate | Flaky Commits | Flaky Jobs | Median Duration |\n")
+	b.WriteString("|---|----------|------|-------------------|--------------|---------------|------------|-----------------|\n")
+	for i, s := range stats {
+		flakyJobs := "-"
+		if len(s.FlakyJobs) > 0 {
+			flakyJobs = countList(s.FlakyJobs)
+		}
+		duration := "-"
+		if s.MedianDuration > 0 {
+			duration = s.MedianDuration.Round(time.Second).String()
+		}
+		fmt.Fprintf(&b, "| %d | %s | %d | %d / %d | %.1f%% | %d | %s | %s |\n",
+			i+1, s.Workflow, s.Runs, s.Failed, s.Finished, s.FailureRate()*100, s.FlakySHAs, flakyJobs, duration)
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package action
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+func TestAnalyzeFlaky(t *testing.T) {
+	start := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+	task := func(workflow, job, sha, status string, run int64, minutes int) *types.MyActionTask {
+		return &types.MyActionTask{
+			WorkflowID:   workflow,
+			Name:         job,
+			HeadSHA:      sha,
+			Status:       status,
+			RunNumber:    run,
+			RunStartedAt: start,
+			UpdatedAt:    start.Add(time.Duration(minutes) * time.Minute),
+		}
+	}
+	tasks := []*types.MyActionTask{
+		// test job flaky on sha1 (rerun), build always fine
+		task("ci.yml", "test", "sha1", "failure", 1, 4),
+		task("ci.yml", "test", "sha1", "success", 1, 5),
+		task("ci.yml", "build", "sha1", "success", 1, 2),
+		task("ci.yml", "test", "sha2", "success", 2, 6),
+		// deploy always fails, but not flaky
+		task("deploy.yml", "deploy", "sha1", "failure", 3, 1),
+		task("deploy.yml", "deploy", "sha2", "failure", 4, 1),
+		// lint fails on one commit and passes on another: not flaky
+		task("lint.yml", "lint", "sha1", "failure", 5, 1),
+		task("lint.yml", "lint", "sha2", "success", 6, 3),
+		task("lint.yml", "lint", "sha3", "running", 7, 0),
+	}
+
+	stats := analyzeFlaky(tasks)
+	if len(stats) != 3 {
+		t.Fatalf("got %d workflows, want 3", len(stats))
+	}
+
+	ci, deploy, lint := stats[0], stats[1], stats[2]
+	if ci.Workflow != "ci.yml" || ci.FlakySHAs != 1 || ci.FlakyJobs["test"] != 1 || ci.FlakyJobs["build"] != 0 {
+		t.Errorf("ci stats = %+v", ci)
+	}
+	if ci.Runs != 2 || ci.Failed != 1 || ci.Finished != 4 {
+		t.Errorf("ci counts = %+v", ci)
+	}
+	if ci.MedianDuration != 4*time.Minute+30*time.Second {
+		t.Errorf("ci median = %s", ci.MedianDuration)
+	}
+	if deploy.Workflow != "deploy.yml" || deploy.FlakySHAs != 0 || deploy.FailureRate() != 1 {
+		t.Errorf("deploy stats = %+v", deploy)
+	}
+	if lint.Workflow != "lint.yml" || lint.FlakySHAs != 0 || lint.Finished != 2 || lint.Runs != 3 {
+		t.Errorf("lint stats = %+v", lint)
+	}
+
+	table := renderFlakyTable(stats)
+	for _, want := range []string{"| 1 | ci.yml | 2 | 1 / 4 | 25.0% | 1 | test (1) | 4m30s |", "| 2 | deploy.yml |"} {
+		if !strings.Contains(table, want) {
+			t.Errorf("table does not contain %q:\n%s", want, table)
+		}
+	}
+}
@@ -85,7 +85,7 @@ func (impl ListActionTasksImpl) Handler() mcp.ToolHandlerFor[ListActionTasksPara
 		p := args
 
 		// Call custom client method
-		response, err := impl.Client.MyListActionTasks(p.Owner, p.Repo)
+		response, err := impl.Client.MyListActionTasks(p.Owner, p.Repo, p.Page, p.Limit)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to list action tasks: %w", err)
 		}
@@ -100,8 +100,8 @@ func (impl ListActionTasksImpl) Handler() mcp.ToolHandlerFor[ListActionTasksPara
 				MyActionTaskResponse: response,
 			}
 
-			content = fmt.Sprintf("Found %d action tasks\n\n%s",
-				response.TotalCount, taskList.ToMarkdown())
+			content = fmt.Sprintf("Found %d action tasks, showing %d\n\n%s",
+				response.TotalCount, len(response.WorkflowRuns), taskList.ToMarkdown())
 		}
 
 		return &mcp.CallToolResult{
@@ -0,0 +1,313 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package action
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+)
+
+const (
+	// defaultLogTail is the number of lines returned when no filter is given.
+	defaultLogTail = 200
+	// maxLogLines is the maximum number of lines returned in one call.
+	maxLogLines = 1000
+)
+
+// GetActionJobLogParams defines the parameters for the get_action_job_log tool.
+// It specifies the job and how to filter its log.
+type GetActionJobLogParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// JobID is the ID of the job.
+	JobID int `json:"job_id"`
+	// Tail returns only the last N lines (after other filters).
+	Tail int `json:"tail,omitempty"`
+	// Grep is a regular expression; only matching lines are returned.
+	Grep string `json:"grep,omitempty"`
+	// Context is the number of lines shown around each grep match.
+	Context int `json:"context,omitempty"`
+	// StartLine is the first line to return (1-based).
+	StartLine int `json:"start_line,omitempty"`
+	// EndLine is the last line to return (1-based, inclusive).
+	EndLine int `json:"end_line,omitempty"`
+	// KeepANSI keeps ANSI escape sequences (colors) in the log.
+	KeepANSI bool `json:"keep_ansi,omitempty"`
+}
+
+// GetActionJobLogImpl implements the read-only MCP tool for reading the log of a
+// workflow job. This is a safe, idempotent operation. Note: This feature is not
+// supported by the official Forgejo SDK and requires a custom HTTP implementation.
+type GetActionJobLogImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `get_action_job_log` tool. It requires `owner`, `repo`
+// and `job_id`, and supports tail, grep and line-range filters. It is marked as a
+// safe, read-only operation.
+func (GetActionJobLogImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "get_action_job_log",
+		Title:       "Get Job Log",
+		Description: fmt.Sprintf("Read the log of a workflow job with line numbers. Filter with a line range, a regular expression (grep) with context lines, and tail. Without filters the last %d lines are returned; at most %d lines are returned per call. Not available on Forgejo 11 or earlier, whose API has no job logs endpoint.", defaultLogTail, maxLogLines),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"job_id": {
+					Type:        "integer",
+					Description: "Job ID, see list_action_run_jobs",
+				},
+				"tail": {
+					Type:        "integer",
+					Description: "Return only the last N lines, applied after other filters (optional)",
+					Minimum:     tools.Float64Ptr(1),
+				},
+				"grep": {
+					Type:        "string",
+					Description: "Regular expression, return only matching lines, e.g. '(?i)error|fail' (optional)",
+				},
+				"context": {
+					Type:        "integer",
+					Description: "Number of lines to show before and after each grep match (optional, defaults to 0)",
+					Minimum:     tools.Float64Ptr(0),
+				},
+				"start_line": {
+					Type:        "integer",
+					Description: "First line to return, 1-based (optional)",
+					Minimum:     tools.Float64Ptr(1),
+				},
+				"end_line": {
+					Type:        "integer",
+					Description: "Last line to return, inclusive (optional)",
+					Minimum:     tools.Float64Ptr(1),
+				},
+				"keep_ansi": {
+					Type:        "boolean",
+					Description: "Keep ANSI escape sequences such as colors (optional, defaults to false)",
+				},
+			},
+			Required: []string{"owner", "repo", "job_id"},
+		},
+	}
+}
+
+// Handler implements the logic for reading a job log. It performs a custom HTTP
+// GET request to the `/repos/{owner}/{repo}/actions/jobs/{job_id}/logs` endpoint
+// and filters the lines.
+func (impl GetActionJobLogImpl) Handler() mcp.ToolHandlerFor[GetActionJobLogParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args GetActionJobLogParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		f := logFilter{
+			StripANSI: !p.KeepANSI,
+			StartLine: p.StartLine,
+			EndLine:   p.EndLine,
+			Context:   p.Context,
+			Tail:      p.Tail,
+		}
+		if p.Grep != "" {
+			re, err := regexp.Compile(p.Grep)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid grep pattern: %w", err)
+			}
+			f.Grep = re
+		}
+		if f.Tail == 0 && f.Grep == nil && f.StartLine == 0 && f.EndLine == 0 {
+			f.Tail = defaultLogTail
+		}
+
+		// Call custom client method
+		log, err := impl.Client.MyGetActionJobLogs(p.Owner, p.Repo, int64(p.JobID))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get job log: %w", err)
+		}
+
+		lines, total := f.Apply(log)
+		truncated := false
+		if len(lines) > maxLogLines {
+			lines = lines[len(lines)-maxLogLines:]
+			truncated = true
+		}
+
+		content := fmt.Sprintf("Job %d log: showing %d of %d lines", p.JobID, countLines(lines), total)
+		if truncated {
+			content += fmt.Sprintf(" (only the last %d selected lines, narrow the filters to see more)", maxLogLines)
+		}
+		if len(lines) == 0 {
+			content += "\n\n*No matching lines*"
+		} else {
+			content += "\n\n```\n" + renderLogLines(lines) + "```"
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: content,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// ansiRe matches ANSI escape sequences: CSI sequences (colors, cursor
+// movement), OSC sequences (titles, hyperlinks) and two-byte escapes.
+var ansiRe = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// StripANSI removes ANSI escape sequences from s.
+func StripANSI(s string) string {
+	return ansiRe.ReplaceAllString(s, "")
+}
+
+// logLine is a line of a log. No is the 1-based line number, 0 marks a gap
+// between non-adjacent lines.
+type logLine struct {
+	No   int
+	Text string
+}
+
+// logFilter selects lines of a log. Filters are applied in order: line range,
+// grep (with context), tail.
+type logFilter struct {
+	StripANSI bool
+	StartLine int // 1-based, 0 for the first line
+	EndLine   int // 1-based and inclusive, 0 for the last line
+	Grep      *regexp.Regexp
+	Context   int
+	Tail      int
+}
+
+// splitLog splits a log into lines. Carriage returns used to redraw progress
+// bars are resolved by keeping the text after the last one.
+func splitLog(log string) []string {
+	log = strings.TrimRight(log, "\n")
+	if log == "" {
+		return nil
+	}
+	lines := strings.Split(log, "\n")
+	for i, l := range lines {
+		l = strings.TrimRight(l, "\r")
+		if idx := strings.LastIndexByte(l, '\r'); idx >= 0 {
+			l = l[idx+1:]
+		}
+		lines[i] = l
+	}
+	return lines
+}
+
+// Apply returns the selected lines of log and the total number of lines.
+func (f logFilter) Apply(log string) ([]logLine, int) {
+	if f.StripANSI {
+		log = StripANSI(log)
+	}
+	all := splitLog(log)
+	total := len(all)
+
+	// line range
+	start, end := 1, total
+	if f.StartLine > 0 {
+		start = f.StartLine
+	}
+	if f.EndLine > 0 && f.EndLine < end {
+		end = f.EndLine
+	}
+	if start > end {
+		return nil, total
+	}
+
+	// grep with context
+	selected := make([]bool, end-start+1)
+	if f.Grep == nil {
+		for i := range selected {
+			selected[i] = true
+		}
+	} else {
+		for i := range selected {
+			if !f.Grep.MatchString(all[start-1+i]) {
+				continue
+			}
+			for j := max(i-f.Context, 0); j <= min(i+f.Context, len(selected)-1); j++ {
+				selected[j] = true
+			}
+		}
+	}
+
+	var ret []logLine
+	last := 0
+	for i, ok := range selected {
+		if !ok {
+			continue
+		}
+		no := start + i
+		if last > 0 && no != last+1 {
+			ret = append(ret, logLine{Text: "--"})
+		}
+		ret = append(ret, logLine{No: no, Text: all[no-1]})
+		last = no
+	}
+
+	// tail, counting only real lines
+	if f.Tail > 0 {
+		n := 0
+		for i := len(ret) - 1; i >= 0; i-- {
+			if ret[i].No == 0 {
+				continue
+			}
+			n++
+			if n == f.Tail {
+				ret = ret[i:]
+				break
+			}
+		}
+	}
+
+	return ret, total
+}
+
+// countLines counts real lines, skipping gap markers.
+func countLines(lines []logLine) int {
+	n := 0
+	for _, l := range lines {
+		if l.No > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// renderLogLines renders lines with line numbers.
+func renderLogLines(lines []logLine) string {
+	var b strings.Builder
+	for _, l := range lines {
+		if l.No == 0 {
+			b.WriteString(l.Text + "\n")
+			continue
+		}
+		fmt.Fprintf(&b, "%d: %s\n", l.No, l.Text)
+	}
+	return b.String()
+}
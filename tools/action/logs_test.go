@@ -0,0 +1,84 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package action
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestStripANSI(t *testing.T) {
+	in := "\x1b[31mred\x1b[0m \x1b]0;title\x07plain \x1b[1;32mgreen\x1b[m"
+	if got := StripANSI(in); got != "red plain green" {
+		t.Errorf("StripANSI() = %q", got)
+	}
+}
+
+func TestLogFilter_Apply(t *testing.T) {
+	log := "one\ntwo\nthree error\nfour\nfive\nsix error\nseven\n"
+
+	tests := []struct {
+		name   string
+		filter logFilter
+		want   string
+	}{
+		{
+			name:   "no filter",
+			filter: logFilter{},
+			want:   "1: one\n2: two\n3: three error\n4: four\n5: five\n6: six error\n7: seven\n",
+		},
+		{
+			name:   "line range",
+			filter: logFilter{StartLine: 2, EndLine: 3},
+			want:   "2: two\n3: three error\n",
+		},
+		{
+			name:   "grep",
+			filter: logFilter{Grep: regexp.MustCompile("error")},
+			want:   "3: three error\n--\n6: six error\n",
+		},
+		{
+			name:   "grep with context merges adjacent blocks",
+			filter: logFilter{Grep: regexp.MustCompile("error"), Context: 1},
+			want:   "2: two\n3: three error\n4: four\n5: five\n6: six error\n7: seven\n",
+		},
+		{
+			name:   "tail",
+			filter: logFilter{Tail: 2},
+			want:   "6: six error\n7: seven\n",
+		},
+		{
+			name:   "grep then tail skips gap markers",
+			filter: logFilter{Grep: regexp.MustCompile("error"), Tail: 1},
+			want:   "6: six error\n",
+		},
+		{
+			name:   "empty range",
+			filter: logFilter{StartLine: 10},
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, total := tt.filter.Apply(log)
+			if total != 7 {
+				t.Errorf("total = %d, want 7", total)
+			}
+			if got := renderLogLines(lines); got != tt.want {
+				t.Errorf("Apply() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitLog_CarriageReturn(t *testing.T) {
+	lines := splitLog("progress 10%\rprogress 100%\r\ndone\r\n")
+	if len(lines) != 2 || lines[0] != "progress 100%" || lines[1] != "done" {
+		t.Errorf("splitLog() = %q", lines)
+	}
+}
@@ -0,0 +1,283 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// ListActionRunnersParams defines the parameters for the list_action_runners
+// tool. It specifies the scope to list runners from.
+type ListActionRunnersParams struct {
+	// Scope is where the runners are registered: repo, org, user or admin.
+	Scope string `json:"scope,omitempty"`
+	// Owner is the repository owner or the organization name.
+	Owner string `json:"owner,omitempty"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo,omitempty"`
+}
+
+// ListActionRunnersImpl implements the read-only MCP tool for listing Actions
+// runners with their labels and status. This is a safe, idempotent operation.
+// Note: This feature is not supported by the official Forgejo SDK and requires
+// a custom HTTP implementation.
+type ListActionRunnersImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `list_action_runners` tool. It is marked as a safe,
+// read-only operation.
+func (ListActionRunnersImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "list_action_runners",
+		Title:       "List Actions Runners",
+		Description: "List the Actions runners registered for a repository, an organization, the authenticated user or the whole instance, with their labels and online status. Not available on Forgejo 11 or earlier, whose API cannot list runners.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type:       "object",
+			Properties: runnerScopeProperties(nil),
+		},
+	}
+}
+
+// Handler implements the logic for listing runners. It performs a custom HTTP GET
+// request to the `.../actions/runners` endpoint of the scope.
+func (impl ListActionRunnersImpl) Handler() mcp.ToolHandlerFor[ListActionRunnersParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args ListActionRunnersParams) (*mcp.CallToolResult, any, error) {
+		p := args
+		scope := defaultScope(p.Scope)
+
+		// Call custom client method
+		response, err := impl.Client.MyListActionRunners(scope, p.Owner, p.Repo)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list runners: %w", err)
+		}
+
+		runnerList := types.ActionRunnerList{MyActionRunnerResponse: response}
+		content := fmt.Sprintf("Runners of %s:\n\n%s", scopeName(scope, p.Owner, p.Repo), runnerList.ToMarkdown())
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: content,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// GetRunnerRegistrationTokenParams defines the parameters for the
+// get_runner_registration_token tool. It specifies the scope to register
+// runners at.
+type GetRunnerRegistrationTokenParams struct {
+	// Scope is where the runners are registered: repo, org, user or admin.
+	Scope string `json:"scope,omitempty"`
+	// Owner is the repository owner or the organization name.
+	Owner string `json:"owner,omitempty"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo,omitempty"`
+}
+
+// GetRunnerRegistrationTokenImpl implements the MCP tool for getting a token to
+// register new runners. The server creates the token if there is none, so this
+// is not strictly read-only, but repeated calls return the same token. Note:
+// This feature is not supported by the official Forgejo SDK and requires a
+// custom HTTP implementation.
+type GetRunnerRegistrationTokenImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `get_runner_registration_token` tool. It is marked as
+// idempotent and non-destructive.
+func (GetRunnerRegistrationTokenImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "get_runner_registration_token",
+		Title:       "Get Runner Registration Token",
+		Description: "Get a token to register a new Actions runner for a repository, an organization, the authenticated user or the whole instance, e.g. with `forgejo-runner register --token`. Treat the token as a secret.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type:       "object",
+			Properties: runnerScopeProperties(nil),
+		},
+	}
+}
+
+// Handler implements the logic for getting a registration token. It performs a
+// custom HTTP GET request to the `.../runners/registration-token` endpoint of
+// the scope.
+func (impl GetRunnerRegistrationTokenImpl) Handler() mcp.ToolHandlerFor[GetRunnerRegistrationTokenParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args GetRunnerRegistrationTokenParams) (*mcp.CallToolResult, any, error) {
+		p := args
+		scope := defaultScope(p.Scope)
+
+		// Call custom client method
+		token, err := impl.Client.MyGetRunnerRegistrationToken(scope, p.Owner, p.Repo)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get registration token: %w", err)
+		}
+
+		content := fmt.Sprintf("Runner registration token for %s:\n\n```\n%s\n```", scopeName(scope, p.Owner, p.Repo), token.Token)
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: content,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// DeleteActionRunnerParams defines the parameters for the delete_action_runner
+// tool. It specifies the runner to delete by ID.
+type DeleteActionRunnerParams struct {
+	// Scope is where the runner is registered: repo, org, user or admin.
+	Scope string `json:"scope,omitempty"`
+	// Owner is the repository owner or the organization name.
+	Owner string `json:"owner,omitempty"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo,omitempty"`
+	// RunnerID is the ID of the runner.
+	RunnerID int `json:"runner_id"`
+	// ConfirmToken confirms the operation, see tools.Confirmer.
+	ConfirmToken string `json:"confirm_token,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// DeleteActionRunnerImpl implements the destructive MCP tool for deleting an
+// Actions runner. This is an idempotent but irreversible operation: the runner
+// has to be registered again to be used. Note: This feature is not supported by
+// the official Forgejo SDK and requires a custom HTTP implementation.
+type DeleteActionRunnerImpl struct {
+	Client  *tools.Client
+	Confirm *tools.Confirmer
+}
+
+// Definition describes the `delete_action_runner` tool. It requires `runner_id`.
+// It is marked as a destructive operation to ensure clients can warn the user
+// before execution.
+func (impl DeleteActionRunnerImpl) Definition() *mcp.Tool {
+	def := &mcp.Tool{
+		Name:        "delete_action_runner",
+		Title:       "Delete Actions Runner",
+		Description: "Delete an Actions runner registered for a repository, an organization, the authenticated user or the whole instance. The runner must be registered again to be used. Not available on Forgejo 11 or earlier, whose API cannot delete runners.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(true),
+			IdempotentHint:  true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: runnerScopeProperties(map[string]*jsonschema.Schema{
+				"runner_id": {
+					Type:        "integer",
+					Description: "Runner ID, see list_action_runners",
+				},
+				"dry_run": tools.DryRunSchema(),
+			}),
+			Required: []string{"runner_id"},
+		},
+	}
+	if impl.Confirm != nil {
+		def.InputSchema.Properties["confirm_token"] = tools.ConfirmTokenSchema()
+	}
+	return def
+}
+
+// Handler implements the logic for deleting a runner. It performs a custom HTTP
+// DELETE request to the `.../actions/runners/{runner_id}` endpoint of the scope.
+func (impl DeleteActionRunnerImpl) Handler() mcp.ToolHandlerFor[DeleteActionRunnerParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args DeleteActionRunnerParams) (*mcp.CallToolResult, any, error) {
+		p := args
+		scope := defaultScope(p.Scope)
+		if err := impl.Client.CheckAPI(tools.APIActionRunners); err != nil {
+			return nil, nil, err
+		}
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, scope)
+			return res, nil, err
+		}
+
+		// Ask for confirmation if required
+		action := fmt.Sprintf("delete_action_runner:%s:%s/%s:%d", scope, p.Owner, p.Repo, p.RunnerID)
+		res, err := impl.Confirm.Check(ctx, req, action, p.ConfirmToken, func() (string, error) {
+			return impl.preview(p, scope)
+		})
+		if err != nil || res != nil {
+			return res, nil, err
+		}
+
+		// Call custom client method
+		err = impl.Client.MyDeleteActionRunner(scope, p.Owner, p.Repo, int64(p.RunnerID))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to delete runner: %w", err)
+		}
+
+		// Return success message
+		emptyResponse := types.EmptyResponse{}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: emptyResponse.ToMarkdown(),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// dryRun previews the runner to be deleted.
+func (impl DeleteActionRunnerImpl) dryRun(p DeleteActionRunnerParams, scope string) (*mcp.CallToolResult, error) {
+	preview, err := impl.preview(p, scope)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := tools.RunnersEndpoint(scope, p.Owner, p.Repo)
+	if err != nil {
+		return nil, err
+	}
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "DELETE",
+		Endpoint: fmt.Sprintf("%s/%d", endpoint, p.RunnerID),
+	})
+}
+
+// preview describes the runner to be deleted and warns if it is busy.
+func (impl DeleteActionRunnerImpl) preview(p DeleteActionRunnerParams, scope string) (string, error) {
+	response, err := impl.Client.MyListActionRunners(scope, p.Owner, p.Repo)
+	if err != nil {
+		return "", fmt.Errorf("failed to list runners: %w", err)
+	}
+	for _, r := range response.Runners {
+		if r.ID != int64(p.RunnerID) {
+			continue
+		}
+		preview := fmt.Sprintf("About to delete runner of %s: %s", scopeName(scope, p.Owner, p.Repo), r.ToMarkdown())
+		if r.Busy {
+			preview += "\n\nThe runner is running a job right now."
+		}
+		return preview, nil
+	}
+	return "", fmt.Errorf("runner %d not found in %s", p.RunnerID, scopeName(scope, p.Owner, p.Repo))
+}
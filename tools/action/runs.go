@@ -0,0 +1,225 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// ListActionRunsParams defines the parameters for the list_action_runs tool.
+// It includes filters and pagination options for workflow runs.
+type ListActionRunsParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Status filters runs by status or conclusion (e.g., 'failure', 'in_progress').
+	Status string `json:"status,omitempty"`
+	// Branch filters runs by the branch they ran on.
+	Branch string `json:"branch,omitempty"`
+	// Event filters runs by the triggering event (e.g., 'push', 'pull_request').
+	Event string `json:"event,omitempty"`
+	// Page is the page number for pagination.
+	Page int `json:"page,omitempty"`
+	// Limit is the number of runs to return per page.
+	Limit int `json:"limit,omitempty"`
+}
+
+// ListActionRunsImpl implements the read-only MCP tool for listing workflow runs.
+// This is a safe, idempotent operation. Note: This feature is not supported by the
+// official Forgejo SDK and requires a custom HTTP implementation.
+type ListActionRunsImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `list_action_runs` tool. It requires `owner` and `repo`
+// and supports filtering by status, branch and event. It is marked as a safe,
+// read-only operation.
+func (ListActionRunsImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "list_action_runs",
+		Title:       "List Workflow Runs",
+		Description: "List Forgejo Actions workflow runs in a repository, newest first. Filter by status, branch or event. Use list_action_run_jobs to see the jobs of a run. Not available on Forgejo 11 or earlier, whose API has no workflow runs endpoints.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"status": {
+					Type:        "string",
+					Description: "Filter by status: 'queued', 'in_progress', 'completed', 'success', 'failure', 'cancelled', 'skipped' or 'waiting' (optional)",
+				},
+				"branch": {
+					Type:        "string",
+					Description: "Filter by branch name (optional)",
+				},
+				"event": {
+					Type:        "string",
+					Description: "Filter by triggering event, e.g. 'push', 'pull_request', 'schedule', 'workflow_dispatch' (optional)",
+				},
+				"page": {
+					Type:        "integer",
+					Description: "Page number for pagination (optional, defaults to 1)",
+					Minimum:     tools.Float64Ptr(1),
+				},
+				"limit": {
+					Type:        "integer",
+					Description: "Number of runs per page (optional, defaults to 20, max 50)",
+					Minimum:     tools.Float64Ptr(1),
+					Maximum:     tools.Float64Ptr(50),
+				},
+			},
+			Required: []string{"owner", "repo"},
+		},
+	}
+}
+
+// Handler implements the logic for listing workflow runs. It performs a custom HTTP
+// GET request to the `/repos/{owner}/{repo}/actions/runs` endpoint and formats
+// the results into a markdown list.
+func (impl ListActionRunsImpl) Handler() mcp.ToolHandlerFor[ListActionRunsParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args ListActionRunsParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		page := max(p.Page, 1)
+		limit := p.Limit
+		if limit <= 0 {
+			limit = 20
+		}
+
+		// Call custom client method
+		response, err := impl.Client.MyListActionRuns(p.Owner, p.Repo, tools.MyListActionRunsOptions{
+			Page:   page,
+			Limit:  limit,
+			Status: p.Status,
+			Branch: p.Branch,
+			Event:  p.Event,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list workflow runs: %w", err)
+		}
+
+		// Convert to our types and format
+		var content string
+		if len(response.WorkflowRuns) == 0 {
+			content = "No workflow runs found matching the criteria."
+		} else {
+			runList := types.ActionRunList{MyActionRunResponse: response}
+			content = fmt.Sprintf("Found %d workflow runs, showing page %d (%d runs)\n\n%s",
+				response.TotalCount, page, len(response.WorkflowRuns), runList.ToMarkdown())
+			if int64(page*limit) < response.TotalCount {
+				content += fmt.Sprintf("\nMore runs available, use page %d to see them.", page+1)
+			}
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: content,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// ListActionRunJobsParams defines the parameters for the list_action_run_jobs tool.
+// It specifies the workflow run by its ID.
+type ListActionRunJobsParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// RunID is the ID of the workflow run.
+	RunID int `json:"run_id"`
+}
+
+// ListActionRunJobsImpl implements the read-only MCP tool for listing the jobs
+// and steps of a workflow run. This is a safe, idempotent operation. Note: This
+// feature is not supported by the official Forgejo SDK and requires a custom
+// HTTP implementation.
+type ListActionRunJobsImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `list_action_run_jobs` tool. It requires `owner`, `repo`
+// and `run_id`. It is marked as a safe, read-only operation.
+func (ListActionRunJobsImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "list_action_run_jobs",
+		Title:       "List Workflow Run Jobs",
+		Description: "Get a workflow run with its jobs and their steps, including the status of each. Use get_action_job_log to read the log of a job. Not available on Forgejo 11 or earlier, whose API has no workflow runs endpoints.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"run_id": {
+					Type:        "integer",
+					Description: "Workflow run ID (not the run number)",
+				},
+			},
+			Required: []string{"owner", "repo", "run_id"},
+		},
+	}
+}
+
+// Handler implements the logic for listing jobs of a run. It performs custom HTTP
+// GET requests to the `/repos/{owner}/{repo}/actions/runs/{run}` and
+// `/repos/{owner}/{repo}/actions/runs/{run}/jobs` endpoints.
+func (impl ListActionRunJobsImpl) Handler() mcp.ToolHandlerFor[ListActionRunJobsParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args ListActionRunJobsParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		// Call custom client methods
+		run, err := impl.Client.MyGetActionRun(p.Owner, p.Repo, int64(p.RunID))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get workflow run: %w", err)
+		}
+		jobs, err := impl.Client.MyListActionRunJobs(p.Owner, p.Repo, int64(p.RunID))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list jobs: %w", err)
+		}
+
+		jobList := types.ActionJobList{MyActionJobResponse: jobs}
+		content := fmt.Sprintf("%s\n\n%s", run.ToMarkdown(), jobList.ToMarkdown())
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: content,
+				},
+			},
+		}, nil, nil
+	}
+}
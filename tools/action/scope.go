@@ -0,0 +1,92 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package action
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+)
+
+// scopeProperties returns the schema properties selecting where Actions
+// secrets and variables are stored. extra properties are merged in.
+func scopeProperties(extra map[string]*jsonschema.Schema) map[string]*jsonschema.Schema {
+	ret := map[string]*jsonschema.Schema{
+		"scope": {
+			Type:        "string",
+			Description: "Where the setting is stored: 'repo' (a repository), 'org' (an organization) or 'user' (the authenticated user) (optional, defaults to 'repo')",
+			Enum:        []any{tools.ActionsScopeRepo, tools.ActionsScopeOrg, tools.ActionsScopeUser},
+		},
+		"owner": {
+			Type:        "string",
+			Description: "Repository owner at repo scope, or organization name at org scope (ignored at user scope)",
+		},
+		"repo": {
+			Type:        "string",
+			Description: "Repository name, required at repo scope",
+		},
+	}
+	for k, v := range extra {
+		ret[k] = v
+	}
+	return ret
+}
+
+// runnerScopeProperties is like scopeProperties, but also accepts the
+// instance-wide admin scope.
+func runnerScopeProperties(extra map[string]*jsonschema.Schema) map[string]*jsonschema.Schema {
+	ret := scopeProperties(extra)
+	ret["scope"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Where the runners are registered: 'repo' (a repository), 'org' (an organization), 'user' (the authenticated user) or 'admin' (instance-wide, site administrators only) (optional, defaults to 'repo')",
+		Enum:        []any{tools.ActionsScopeRepo, tools.ActionsScopeOrg, tools.ActionsScopeUser, tools.ActionsScopeAdmin},
+	}
+	return ret
+}
+
+// defaultScope returns scope, or the repository scope if it is empty.
+func defaultScope(scope string) string {
+	if scope == "" {
+		return tools.ActionsScopeRepo
+	}
+	return scope
+}
+
+// scopeName describes the scope for humans.
+func scopeName(scope, owner, repo string) string {
+	switch defaultScope(scope) {
+	case tools.ActionsScopeOrg:
+		return "organization " + owner
+	case tools.ActionsScopeUser:
+		return "your user account"
+	case tools.ActionsScopeAdmin:
+		return "the instance"
+	}
+	return owner + "/" + repo
+}
+
+// settingNameRe matches valid names of secrets and variables.
+var settingNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validateSettingName checks the name of a secret or variable, following the
+// rules of the server.
+func validateSettingName(name string) error {
+	if !settingNameRe.MatchString(name) {
+		return fmt.Errorf("invalid name %q, only letters, digits and underscores are allowed and it must not start with a digit", name)
+	}
+	upper := strings.ToUpper(name)
+	for _, prefix := range []string{"GITHUB_", "GITEA_", "FORGEJO_"} {
+		if strings.HasPrefix(upper, prefix) {
+			return fmt.Errorf("invalid name %q, names must not start with %s", name, prefix)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,420 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package action
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// maxSecretSize is the maximum size of a secret value accepted by the server.
+const maxSecretSize = 64 << 10
+
+// ListActionSecretsParams defines the parameters for the list_action_secrets tool.
+// It specifies the scope to list secrets from.
+type ListActionSecretsParams struct {
+	// Scope is where the secrets are stored: repo, org or user.
+	Scope string `json:"scope,omitempty"`
+	// Owner is the repository owner or the organization name.
+	Owner string `json:"owner,omitempty"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo,omitempty"`
+}
+
+// ListActionSecretsImpl implements the read-only MCP tool for listing the names
+// of Actions secrets. Values are never returned. Note: This feature is not
+// fully supported by the official Forgejo SDK and requires a custom HTTP
+// implementation.
+type ListActionSecretsImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `list_action_secrets` tool. It is marked as a safe,
+// read-only operation.
+func (ListActionSecretsImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "list_action_secrets",
+		Title:       "List Actions Secrets",
+		Description: "List the names of Actions secrets of a repository or an organization. Secret values are never returned. The secrets of the authenticated user cannot be listed, the Forgejo API can only set and delete them.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: scopeProperties(map[string]*jsonschema.Schema{
+				"scope": {
+					Type:        "string",
+					Description: "Where the secrets are stored: 'repo' (a repository) or 'org' (an organization) (optional, defaults to 'repo')",
+					Enum:        []any{tools.ActionsScopeRepo, tools.ActionsScopeOrg},
+				},
+			}),
+		},
+	}
+}
+
+// Handler implements the logic for listing secrets. It performs custom HTTP GET
+// requests to the `/repos/{owner}/{repo}/actions/secrets` or
+// `/orgs/{org}/actions/secrets` endpoint.
+func (impl ListActionSecretsImpl) Handler() mcp.ToolHandlerFor[ListActionSecretsParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args ListActionSecretsParams) (*mcp.CallToolResult, any, error) {
+		p := args
+		scope := defaultScope(p.Scope)
+
+		// Call custom client method
+		secrets, err := impl.Client.MyListActionSecrets(scope, p.Owner, p.Repo)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list secrets: %w", err)
+		}
+
+		content := fmt.Sprintf("Secrets of %s:\n\n%s", scopeName(scope, p.Owner, p.Repo), types.SecretList(secrets).ToMarkdown())
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: content,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// SetActionSecretParams defines the parameters for the set_action_secret tool.
+// The value is read from the host running the server, never passed directly.
+type SetActionSecretParams struct {
+	// Scope is where the secret is stored: repo, org or user.
+	Scope string `json:"scope,omitempty"`
+	// Owner is the repository owner or the organization name.
+	Owner string `json:"owner,omitempty"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo,omitempty"`
+	// Name is the name of the secret.
+	Name string `json:"name"`
+	// ValueEnv is the environment variable holding the value.
+	ValueEnv string `json:"value_env,omitempty"`
+	// ValueFile is the path of the file holding the value.
+	ValueFile string `json:"value_file,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// SetActionSecretImpl implements the MCP tool for creating or updating an
+// Actions secret. To keep secrets out of the conversation, the value is read
+// from an environment variable or a file on the host running the server, and
+// is never included in the output. Only the environment variables and the
+// directory the server was started with can be read, see EnvPrefix and Dir.
+// Note: This feature is not fully supported by the official Forgejo SDK and
+// requires a custom HTTP implementation.
+type SetActionSecretImpl struct {
+	Client *tools.Client
+	// EnvPrefix is the prefix of the environment variables values may be read
+	// from. Empty disables `value_env`.
+	EnvPrefix string
+	// Dir is the directory values files may be read from. Empty disables
+	// `value_file`.
+	Dir string
+}
+
+// Definition describes the `set_action_secret` tool. It requires `name` and
+// exactly one of `value_env` or `value_file`, as far as they are enabled. It is
+// idempotent, as setting the same value twice has the same effect.
+func (impl SetActionSecretImpl) Definition() *mcp.Tool {
+	def := &mcp.Tool{
+		Name:        "set_action_secret",
+		Title:       "Set Actions Secret",
+		Description: "Create or update an Actions secret of a repository, an organization or the authenticated user. For safety the value cannot be passed directly: it is read from an environment variable (value_env) or a file (value_file) on the host running this server, and is never shown.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(true),
+			IdempotentHint:  true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: scopeProperties(map[string]*jsonschema.Schema{
+				"name": {
+					Type:        "string",
+					Description: "Secret name, letters, digits and underscores only",
+				},
+				"dry_run": tools.DryRunSchema(),
+			}),
+			Required: []string{"name"},
+		},
+	}
+	if impl.EnvPrefix != "" {
+		def.InputSchema.Properties["value_env"] = &jsonschema.Schema{
+			Type:        "string",
+			Description: fmt.Sprintf("Name of the environment variable on the server host holding the value, must start with %s", impl.EnvPrefix),
+		}
+	}
+	if impl.Dir != "" {
+		def.InputSchema.Properties["value_file"] = &jsonschema.Schema{
+			Type:        "string",
+			Description: fmt.Sprintf("Path of the file on the server host holding the value, inside %s; one trailing newline is removed", impl.Dir),
+		}
+	}
+	return def
+}
+
+// Handler implements the logic for setting a secret. It performs a custom HTTP
+// PUT request to the `.../actions/secrets/{secretname}` endpoint of the scope.
+func (impl SetActionSecretImpl) Handler() mcp.ToolHandlerFor[SetActionSecretParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args SetActionSecretParams) (*mcp.CallToolResult, any, error) {
+		p := args
+		scope := defaultScope(p.Scope)
+
+		if err := validateSettingName(p.Name); err != nil {
+			return nil, nil, err
+		}
+		value, source, err := readSecretValue(p.ValueEnv, p.ValueFile, impl.EnvPrefix, impl.Dir)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, scope, value, source)
+			return res, nil, err
+		}
+
+		// Call custom client method
+		err = impl.Client.MySetActionSecret(scope, p.Owner, p.Repo, p.Name, value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to set secret: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("Secret `%s` of %s has been set from %s.", p.Name, scopeName(scope, p.Owner, p.Repo), source),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// dryRun checks whether the secret exists and previews the change, masking
+// the value.
+func (impl SetActionSecretImpl) dryRun(p SetActionSecretParams, scope, value, source string) (*mcp.CallToolResult, error) {
+	prefix, err := tools.ActionsScopeEndpoint(scope, p.Owner, p.Repo)
+	if err != nil {
+		return nil, err
+	}
+	// The secrets of the user cannot be listed
+	verb := "create or update"
+	if scope != tools.ActionsScopeUser {
+		secrets, err := impl.Client.MyListActionSecrets(scope, p.Owner, p.Repo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list secrets: %w", err)
+		}
+		verb = "create"
+		for _, s := range secrets {
+			if strings.EqualFold(s.Name, p.Name) {
+				verb = "update"
+			}
+		}
+	}
+
+	preview := fmt.Sprintf("Would %s secret `%s` of %s with a %d-byte value from %s.",
+		verb, p.Name, scopeName(scope, p.Owner, p.Repo), len(value), source)
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "PUT",
+		Endpoint: prefix + "/secrets/" + p.Name,
+		Body:     map[string]string{"data": "***"},
+	})
+}
+
+// readSecretValue reads a secret value from the environment variable env,
+// which must start with envPrefix, or the file at path inside dir; exactly one
+// must be given. It returns the value and a description of its source.
+func readSecretValue(env, path, envPrefix, dir string) (string, string, error) {
+	switch {
+	case env != "" && path != "":
+		return "", "", errors.New("only one of value_env and value_file can be given")
+	case env != "":
+		if envPrefix == "" {
+			return "", "", errors.New("value_env is disabled, start the server with --secret-env-prefix to allow environment variables")
+		}
+		if !strings.HasPrefix(env, envPrefix) {
+			return "", "", fmt.Errorf("environment variable %s is not allowed, only names starting with %s can be read", env, envPrefix)
+		}
+		value, ok := os.LookupEnv(env)
+		if !ok || value == "" {
+			return "", "", fmt.Errorf("environment variable %s is not set or empty", env)
+		}
+		if len(value) > maxSecretSize {
+			return "", "", fmt.Errorf("environment variable %s exceeds %d bytes", env, maxSecretSize)
+		}
+		return value, "environment variable " + env, nil
+	case path != "":
+		path, err := tools.ContainedPath(dir, path, "--secret-dir")
+		if err != nil {
+			return "", "", err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to open value file: %w", err)
+		}
+		defer f.Close()
+		data, err := io.ReadAll(io.LimitReader(f, maxSecretSize+1))
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read value file: %w", err)
+		}
+		value := strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r")
+		if len(value) > maxSecretSize {
+			return "", "", fmt.Errorf("value file %s exceeds %d bytes", path, maxSecretSize)
+		}
+		if value == "" {
+			return "", "", fmt.Errorf("value file %s is empty", path)
+		}
+		return value, "file " + path, nil
+	}
+	return "", "", errors.New("value_env or value_file is required, secret values cannot be passed directly")
+}
+
+// DeleteActionSecretParams defines the parameters for the delete_action_secret
+// tool. It specifies the secret to delete by name.
+type DeleteActionSecretParams struct {
+	// Scope is where the secret is stored: repo, org or user.
+	Scope string `json:"scope,omitempty"`
+	// Owner is the repository owner or the organization name.
+	Owner string `json:"owner,omitempty"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo,omitempty"`
+	// Name is the name of the secret.
+	Name string `json:"name"`
+	// ConfirmToken confirms the operation, see tools.Confirmer.
+	ConfirmToken string `json:"confirm_token,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// DeleteActionSecretImpl implements the destructive MCP tool for deleting an
+// Actions secret. This is an idempotent but irreversible operation. Note: This
+// feature is not supported by the official Forgejo SDK and requires a custom
+// HTTP implementation.
+type DeleteActionSecretImpl struct {
+	Client  *tools.Client
+	Confirm *tools.Confirmer
+}
+
+// Definition describes the `delete_action_secret` tool. It requires `name`. It is
+// marked as a destructive operation to ensure clients can warn the user before
+// execution.
+func (impl DeleteActionSecretImpl) Definition() *mcp.Tool {
+	def := &mcp.Tool{
+		Name:        "delete_action_secret",
+		Title:       "Delete Actions Secret",
+		Description: "Delete an Actions secret of a repository, an organization or the authenticated user.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(true),
+			IdempotentHint:  true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: scopeProperties(map[string]*jsonschema.Schema{
+				"name": {
+					Type:        "string",
+					Description: "Secret name to delete",
+				},
+				"dry_run": tools.DryRunSchema(),
+			}),
+			Required: []string{"name"},
+		},
+	}
+	if impl.Confirm != nil {
+		def.InputSchema.Properties["confirm_token"] = tools.ConfirmTokenSchema()
+	}
+	return def
+}
+
+// Handler implements the logic for deleting a secret. It performs a custom HTTP
+// DELETE request to the `.../actions/secrets/{secretname}` endpoint of the scope.
+func (impl DeleteActionSecretImpl) Handler() mcp.ToolHandlerFor[DeleteActionSecretParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args DeleteActionSecretParams) (*mcp.CallToolResult, any, error) {
+		p := args
+		scope := defaultScope(p.Scope)
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, scope)
+			return res, nil, err
+		}
+
+		// Ask for confirmation if required
+		action := fmt.Sprintf("delete_action_secret:%s:%s/%s:%s", scope, p.Owner, p.Repo, p.Name)
+		res, err := impl.Confirm.Check(ctx, req, action, p.ConfirmToken, func() (string, error) {
+			return impl.preview(p, scope)
+		})
+		if err != nil || res != nil {
+			return res, nil, err
+		}
+
+		// Call custom client method
+		err = impl.Client.MyDeleteActionSecret(scope, p.Owner, p.Repo, p.Name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to delete secret: %w", err)
+		}
+
+		// Return success message
+		emptyResponse := types.EmptyResponse{}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: emptyResponse.ToMarkdown(),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// dryRun previews the secret to be deleted.
+func (impl DeleteActionSecretImpl) dryRun(p DeleteActionSecretParams, scope string) (*mcp.CallToolResult, error) {
+	prefix, err := tools.ActionsScopeEndpoint(scope, p.Owner, p.Repo)
+	if err != nil {
+		return nil, err
+	}
+	preview, err := impl.preview(p, scope)
+	if err != nil {
+		return nil, err
+	}
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "DELETE",
+		Endpoint: prefix + "/secrets/" + p.Name,
+	})
+}
+
+// preview checks the secret exists and describes the deletion. The secrets of
+// the user cannot be listed, so they are not checked.
+func (impl DeleteActionSecretImpl) preview(p DeleteActionSecretParams, scope string) (string, error) {
+	if scope == tools.ActionsScopeUser {
+		return fmt.Sprintf("About to delete secret `%s` of %s, if it exists. Workflows using it will no longer receive its value.",
+			p.Name, scopeName(scope, p.Owner, p.Repo)), nil
+	}
+	secrets, err := impl.Client.MyListActionSecrets(scope, p.Owner, p.Repo)
+	if err != nil {
+		return "", fmt.Errorf("failed to list secrets: %w", err)
+	}
+	for _, s := range secrets {
+		if strings.EqualFold(s.Name, p.Name) {
+			return fmt.Sprintf("About to delete secret `%s` of %s. Workflows using it will no longer receive its value.",
+				s.Name, scopeName(scope, p.Owner, p.Repo)), nil
+		}
+	}
+	return "", fmt.Errorf("secret %s not found in %s", p.Name, scopeName(scope, p.Owner, p.Repo))
+}
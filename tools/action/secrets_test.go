@@ -0,0 +1,68 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package action
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateSettingName(t *testing.T) {
+	for _, name := range []string{"DEPLOY_KEY", "_x", "a1"} {
+		if err := validateSettingName(name); err != nil {
+			t.Errorf("validateSettingName(%q) error = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "1A", "A-B", "github_token", "FORGEJO_X", "GITEA_X"} {
+		if err := validateSettingName(name); err == nil {
+			t.Errorf("validateSettingName(%q) expected error", name)
+		}
+	}
+}
+
+func TestReadSecretValue(t *testing.T) {
+	t.Setenv("FORGEJOMCP_TEST_SECRET", "s3cret")
+	t.Setenv("OTHER_SECRET", "other")
+	dir := t.TempDir()
+	file := filepath.Join(dir, "secret")
+	if err := os.WriteFile(file, []byte("from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	outside := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(outside, []byte("outside\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	const prefix = "FORGEJOMCP_TEST_"
+
+	value, source, err := readSecretValue("FORGEJOMCP_TEST_SECRET", "", prefix, dir)
+	if err != nil || value != "s3cret" || source != "environment variable FORGEJOMCP_TEST_SECRET" {
+		t.Errorf("readSecretValue(env) = %q, %q, %v", value, source, err)
+	}
+
+	value, _, err = readSecretValue("", "secret", prefix, dir)
+	if err != nil || value != "from-file" {
+		t.Errorf("readSecretValue(file) = %q, %v", value, err)
+	}
+
+	errCases := []struct{ env, file, prefix, dir string }{
+		{"", "", prefix, dir},
+		{"FORGEJOMCP_TEST_SECRET", file, prefix, dir},
+		{"FORGEJOMCP_TEST_UNSET", "", prefix, dir},
+		{"", filepath.Join(dir, "missing"), prefix, dir},
+		{"OTHER_SECRET", "", prefix, dir},
+		{"FORGEJOMCP_TEST_SECRET", "", "", dir},
+		{"", outside, prefix, dir},
+		{"", "../secret", prefix, dir},
+		{"", file, prefix, ""},
+	}
+	for _, c := range errCases {
+		if _, _, err := readSecretValue(c.env, c.file, c.prefix, c.dir); err == nil {
+			t.Errorf("readSecretValue(%q, %q, %q, %q) expected error", c.env, c.file, c.prefix, c.dir)
+		}
+	}
+}
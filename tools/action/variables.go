@@ -0,0 +1,502 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// ListActionVariablesParams defines the parameters for the list_action_variables
+// tool. It specifies the scope to list variables from.
+type ListActionVariablesParams struct {
+	// Scope is where the variables are stored: repo, org or user.
+	Scope string `json:"scope,omitempty"`
+	// Owner is the repository owner or the organization name.
+	Owner string `json:"owner,omitempty"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo,omitempty"`
+}
+
+// ListActionVariablesImpl implements the read-only MCP tool for listing Actions
+// variables with their values. Note: This feature is not supported by the
+// official Forgejo SDK and requires a custom HTTP implementation.
+type ListActionVariablesImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `list_action_variables` tool. It is marked as a safe,
+// read-only operation.
+func (ListActionVariablesImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "list_action_variables",
+		Title:       "List Actions Variables",
+		Description: "List the Actions variables and their values of a repository, an organization or the authenticated user.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type:       "object",
+			Properties: scopeProperties(nil),
+		},
+	}
+}
+
+// Handler implements the logic for listing variables. It performs custom HTTP GET
+// requests to the `.../actions/variables` endpoint of the scope.
+func (impl ListActionVariablesImpl) Handler() mcp.ToolHandlerFor[ListActionVariablesParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args ListActionVariablesParams) (*mcp.CallToolResult, any, error) {
+		p := args
+		scope := defaultScope(p.Scope)
+
+		// Call custom client method
+		vars, err := impl.Client.MyListActionVariables(scope, p.Owner, p.Repo)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list variables: %w", err)
+		}
+
+		content := fmt.Sprintf("Variables of %s:\n\n%s", scopeName(scope, p.Owner, p.Repo), types.ActionVariableList(vars).ToMarkdown())
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: content,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// GetActionVariableParams defines the parameters for the get_action_variable
+// tool. It specifies the variable by name.
+type GetActionVariableParams struct {
+	// Scope is where the variable is stored: repo, org or user.
+	Scope string `json:"scope,omitempty"`
+	// Owner is the repository owner or the organization name.
+	Owner string `json:"owner,omitempty"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo,omitempty"`
+	// Name is the name of the variable.
+	Name string `json:"name"`
+}
+
+// GetActionVariableImpl implements the read-only MCP tool for getting an Actions
+// variable. Note: This feature is not supported by the official Forgejo SDK and
+// requires a custom HTTP implementation.
+type GetActionVariableImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `get_action_variable` tool. It requires `name`. It is
+// marked as a safe, read-only operation.
+func (GetActionVariableImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "get_action_variable",
+		Title:       "Get Actions Variable",
+		Description: "Get an Actions variable of a repository, an organization or the authenticated user.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: scopeProperties(map[string]*jsonschema.Schema{
+				"name": {
+					Type:        "string",
+					Description: "Variable name",
+				},
+			}),
+			Required: []string{"name"},
+		},
+	}
+}
+
+// Handler implements the logic for getting a variable. It performs a custom HTTP
+// GET request to the `.../actions/variables/{variablename}` endpoint of the scope.
+func (impl GetActionVariableImpl) Handler() mcp.ToolHandlerFor[GetActionVariableParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args GetActionVariableParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		// Call custom client method
+		v, err := impl.Client.MyGetActionVariable(defaultScope(p.Scope), p.Owner, p.Repo, p.Name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get variable: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: v.ToMarkdown(),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// CreateActionVariableParams defines the parameters for the create_action_variable
+// tool. It specifies the name and value of the new variable.
+type CreateActionVariableParams struct {
+	// Scope is where the variable is stored: repo, org or user.
+	Scope string `json:"scope,omitempty"`
+	// Owner is the repository owner or the organization name.
+	Owner string `json:"owner,omitempty"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo,omitempty"`
+	// Name is the name of the variable.
+	Name string `json:"name"`
+	// Value is the value of the variable.
+	Value string `json:"value"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// CreateActionVariableImpl implements the MCP tool for creating an Actions
+// variable. This is a non-idempotent operation. Note: This feature is not
+// supported by the official Forgejo SDK and requires a custom HTTP
+// implementation.
+type CreateActionVariableImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `create_action_variable` tool. It requires `name` and
+// `value`.
+func (CreateActionVariableImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "create_action_variable",
+		Title:       "Create Actions Variable",
+		Description: "Create an Actions variable in a repository, an organization or the authenticated user. Variables are not secret, use set_action_secret for sensitive values.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  false,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: scopeProperties(map[string]*jsonschema.Schema{
+				"name": {
+					Type:        "string",
+					Description: "Variable name, letters, digits and underscores only",
+				},
+				"value": {
+					Type:        "string",
+					Description: "Variable value",
+				},
+				"dry_run": tools.DryRunSchema(),
+			}),
+			Required: []string{"name", "value"},
+		},
+	}
+}
+
+// Handler implements the logic for creating a variable. It performs a custom HTTP
+// POST request to the `.../actions/variables/{variablename}` endpoint of the scope.
+func (impl CreateActionVariableImpl) Handler() mcp.ToolHandlerFor[CreateActionVariableParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args CreateActionVariableParams) (*mcp.CallToolResult, any, error) {
+		p := args
+		scope := defaultScope(p.Scope)
+
+		if err := validateSettingName(p.Name); err != nil {
+			return nil, nil, err
+		}
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, scope)
+			return res, nil, err
+		}
+
+		// Call custom client method
+		err := impl.Client.MyCreateActionVariable(scope, p.Owner, p.Repo, p.Name, p.Value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create variable: %w", err)
+		}
+
+		v := &types.MyActionVariable{Name: p.Name, Data: p.Value}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("Created variable of %s: %s", scopeName(scope, p.Owner, p.Repo), v.ToMarkdown()),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// dryRun checks the name is not taken and previews the variable to be created.
+func (impl CreateActionVariableImpl) dryRun(p CreateActionVariableParams, scope string) (*mcp.CallToolResult, error) {
+	prefix, err := tools.ActionsScopeEndpoint(scope, p.Owner, p.Repo)
+	if err != nil {
+		return nil, err
+	}
+	if v, err := impl.Client.MyGetActionVariable(scope, p.Owner, p.Repo, p.Name); err == nil {
+		return nil, fmt.Errorf("variable %s already exists in %s, use update_action_variable", v.Name, scopeName(scope, p.Owner, p.Repo))
+	}
+
+	v := &types.MyActionVariable{Name: p.Name, Data: p.Value}
+	preview := fmt.Sprintf("Would create variable of %s: %s", scopeName(scope, p.Owner, p.Repo), v.ToMarkdown())
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "POST",
+		Endpoint: prefix + "/variables/" + p.Name,
+		Body:     map[string]string{"value": p.Value},
+	})
+}
+
+// UpdateActionVariableParams defines the parameters for the update_action_variable
+// tool. It specifies the variable by name, its new value and optionally a new name.
+type UpdateActionVariableParams struct {
+	// Scope is where the variable is stored: repo, org or user.
+	Scope string `json:"scope,omitempty"`
+	// Owner is the repository owner or the organization name.
+	Owner string `json:"owner,omitempty"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo,omitempty"`
+	// Name is the name of the variable.
+	Name string `json:"name"`
+	// NewName renames the variable.
+	NewName string `json:"new_name,omitempty"`
+	// Value is the new value of the variable.
+	Value string `json:"value"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// UpdateActionVariableImpl implements the MCP tool for updating an Actions
+// variable. This is an idempotent operation. Note: This feature is not supported
+// by the official Forgejo SDK and requires a custom HTTP implementation.
+type UpdateActionVariableImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `update_action_variable` tool. It requires `name` and
+// `value`. It is marked as destructive, as the old value is overwritten.
+func (UpdateActionVariableImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "update_action_variable",
+		Title:       "Update Actions Variable",
+		Description: "Update the value of an Actions variable of a repository, an organization or the authenticated user, optionally renaming it.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(true),
+			IdempotentHint:  true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: scopeProperties(map[string]*jsonschema.Schema{
+				"name": {
+					Type:        "string",
+					Description: "Current variable name",
+				},
+				"new_name": {
+					Type:        "string",
+					Description: "New variable name (optional)",
+				},
+				"value": {
+					Type:        "string",
+					Description: "New variable value",
+				},
+				"dry_run": tools.DryRunSchema(),
+			}),
+			Required: []string{"name", "value"},
+		},
+	}
+}
+
+// Handler implements the logic for updating a variable. It performs a custom HTTP
+// PUT request to the `.../actions/variables/{variablename}` endpoint of the scope.
+func (impl UpdateActionVariableImpl) Handler() mcp.ToolHandlerFor[UpdateActionVariableParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args UpdateActionVariableParams) (*mcp.CallToolResult, any, error) {
+		p := args
+		scope := defaultScope(p.Scope)
+
+		if p.NewName != "" {
+			if err := validateSettingName(p.NewName); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, scope)
+			return res, nil, err
+		}
+
+		// Call custom client method
+		err := impl.Client.MyUpdateActionVariable(scope, p.Owner, p.Repo, p.Name, p.NewName, p.Value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to update variable: %w", err)
+		}
+
+		v := &types.MyActionVariable{Name: p.Name, Data: p.Value}
+		if p.NewName != "" {
+			v.Name = p.NewName
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("Updated variable of %s: %s", scopeName(scope, p.Owner, p.Repo), v.ToMarkdown()),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// dryRun previews the variable before and after the update.
+func (impl UpdateActionVariableImpl) dryRun(p UpdateActionVariableParams, scope string) (*mcp.CallToolResult, error) {
+	prefix, err := tools.ActionsScopeEndpoint(scope, p.Owner, p.Repo)
+	if err != nil {
+		return nil, err
+	}
+	old, err := impl.Client.MyGetActionVariable(scope, p.Owner, p.Repo, p.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get variable: %w", err)
+	}
+
+	body := map[string]string{"value": p.Value}
+	updated := &types.MyActionVariable{Name: old.Name, Data: p.Value}
+	if p.NewName != "" {
+		body["name"] = p.NewName
+		updated.Name = p.NewName
+	}
+	preview := fmt.Sprintf("Would update variable of %s.\n\nBefore: %s\n\nAfter: %s",
+		scopeName(scope, p.Owner, p.Repo), old.ToMarkdown(), updated.ToMarkdown())
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "PUT",
+		Endpoint: prefix + "/variables/" + p.Name,
+		Body:     body,
+	})
+}
+
+// DeleteActionVariableParams defines the parameters for the delete_action_variable
+// tool. It specifies the variable to delete by name.
+type DeleteActionVariableParams struct {
+	// Scope is where the variable is stored: repo, org or user.
+	Scope string `json:"scope,omitempty"`
+	// Owner is the repository owner or the organization name.
+	Owner string `json:"owner,omitempty"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo,omitempty"`
+	// Name is the name of the variable.
+	Name string `json:"name"`
+	// ConfirmToken confirms the operation, see tools.Confirmer.
+	ConfirmToken string `json:"confirm_token,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// DeleteActionVariableImpl implements the destructive MCP tool for deleting an
+// Actions variable. This is an idempotent but irreversible operation. Note: This
+// feature is not supported by the official Forgejo SDK and requires a custom
+// HTTP implementation.
+type DeleteActionVariableImpl struct {
+	Client  *tools.Client
+	Confirm *tools.Confirmer
+}
+
+// Definition describes the `delete_action_variable` tool. It requires `name`. It
+// is marked as a destructive operation to ensure clients can warn the user
+// before execution.
+func (impl DeleteActionVariableImpl) Definition() *mcp.Tool {
+	def := &mcp.Tool{
+		Name:        "delete_action_variable",
+		Title:       "Delete Actions Variable",
+		Description: "Delete an Actions variable of a repository, an organization or the authenticated user.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(true),
+			IdempotentHint:  true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: scopeProperties(map[string]*jsonschema.Schema{
+				"name": {
+					Type:        "string",
+					Description: "Variable name to delete",
+				},
+				"dry_run": tools.DryRunSchema(),
+			}),
+			Required: []string{"name"},
+		},
+	}
+	if impl.Confirm != nil {
+		def.InputSchema.Properties["confirm_token"] = tools.ConfirmTokenSchema()
+	}
+	return def
+}
+
+// Handler implements the logic for deleting a variable. It performs a custom HTTP
+// DELETE request to the `.../actions/variables/{variablename}` endpoint of the
+// scope.
+func (impl DeleteActionVariableImpl) Handler() mcp.ToolHandlerFor[DeleteActionVariableParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args DeleteActionVariableParams) (*mcp.CallToolResult, any, error) {
+		p := args
+		scope := defaultScope(p.Scope)
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, scope)
+			return res, nil, err
+		}
+
+		// Ask for confirmation if required
+		action := fmt.Sprintf("delete_action_variable:%s:%s/%s:%s", scope, p.Owner, p.Repo, p.Name)
+		res, err := impl.Confirm.Check(ctx, req, action, p.ConfirmToken, func() (string, error) {
+			return impl.preview(p, scope)
+		})
+		if err != nil || res != nil {
+			return res, nil, err
+		}
+
+		// Call custom client method
+		err = impl.Client.MyDeleteActionVariable(scope, p.Owner, p.Repo, p.Name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to delete variable: %w", err)
+		}
+
+		// Return success message
+		emptyResponse := types.EmptyResponse{}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: emptyResponse.ToMarkdown(),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// dryRun previews the variable to be deleted.
+func (impl DeleteActionVariableImpl) dryRun(p DeleteActionVariableParams, scope string) (*mcp.CallToolResult, error) {
+	prefix, err := tools.ActionsScopeEndpoint(scope, p.Owner, p.Repo)
+	if err != nil {
+		return nil, err
+	}
+	preview, err := impl.preview(p, scope)
+	if err != nil {
+		return nil, err
+	}
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "DELETE",
+		Endpoint: prefix + "/variables/" + p.Name,
+	})
+}
+
+// preview describes the variable to be deleted.
+func (impl DeleteActionVariableImpl) preview(p DeleteActionVariableParams, scope string) (string, error) {
+	v, err := impl.Client.MyGetActionVariable(scope, p.Owner, p.Repo, p.Name)
+	if err != nil {
+		return "", fmt.Errorf("failed to get variable: %w", err)
+	}
+	return fmt.Sprintf("About to delete variable of %s: %s", scopeName(scope, p.Owner, p.Repo), v.ToMarkdown()), nil
+}
@@ -0,0 +1,220 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package action
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+)
+
+// workflowDirs are the directories searched for workflow files, in the order
+// Forgejo looks them up.
+var workflowDirs = []string{".forgejo/workflows", ".gitea/workflows", ".github/workflows"}
+
+// workflowInput is an input declared under `on.workflow_dispatch.inputs`.
+type workflowInput struct {
+	Description string   `yaml:"description"`
+	Required    bool     `yaml:"required"`
+	Default     string   `yaml:"default"`
+	Type        string   `yaml:"type"` // string, boolean, number, choice or environment
+	Options     []string `yaml:"options"`
+}
+
+// dispatchWorkflow is the part of a workflow file relevant to dispatching.
+type dispatchWorkflow struct {
+	Name string
+	// Path is the path of the workflow file in the repository.
+	Path string
+	// Dispatchable reports whether the workflow has a workflow_dispatch trigger.
+	Dispatchable bool
+	Inputs       map[string]*workflowInput
+}
+
+// parseWorkflow extracts the workflow_dispatch trigger from a workflow file.
+func parseWorkflow(data []byte) (*dispatchWorkflow, error) {
+	var doc struct {
+		Name string    `yaml:"name"`
+		On   yaml.Node `yaml:"on"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid workflow file: %w", err)
+	}
+
+	ret := &dispatchWorkflow{Name: doc.Name}
+	on := &doc.On
+	switch on.Kind {
+	case yaml.ScalarNode:
+		ret.Dispatchable = on.Value == "workflow_dispatch"
+	case yaml.SequenceNode:
+		for _, n := range on.Content {
+			if n.Value == "workflow_dispatch" {
+				ret.Dispatchable = true
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(on.Content); i += 2 {
+			if on.Content[i].Value != "workflow_dispatch" {
+				continue
+			}
+			ret.Dispatchable = true
+			var trigger struct {
+				Inputs map[string]*workflowInput `yaml:"inputs"`
+			}
+			if err := on.Content[i+1].Decode(&trigger); err != nil {
+				return nil, fmt.Errorf("invalid workflow_dispatch inputs: %w", err)
+			}
+			ret.Inputs = trigger.Inputs
+		}
+	}
+	return ret, nil
+}
+
+// fetchWorkflow reads and parses a workflow file at ref. workflow is either a
+// file name, looked up in the workflow directories, or a path in the
+// repository.
+func fetchWorkflow(cl *tools.Client, owner, repo, ref, workflow string) (*dispatchWorkflow, error) {
+	paths := []string{workflow}
+	if !strings.Contains(workflow, "/") {
+		paths = paths[:0]
+		for _, dir := range workflowDirs {
+			paths = append(paths, dir+"/"+workflow)
+		}
+	}
+
+	for _, path := range paths {
+		data, _, err := cl.GetFile(owner, repo, ref, path)
+		if err != nil {
+			continue
+		}
+		wf, err := parseWorkflow(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		wf.Path = path
+		return wf, nil
+	}
+	return nil, fmt.Errorf("workflow %q not found at %s, tried %s", workflow, ref, strings.Join(paths, ", "))
+}
+
+// validateInputs checks values against the declared inputs, fills in
+// defaults and converts the values to the strings sent to the server.
+func (wf *dispatchWorkflow) validateInputs(values map[string]any) (map[string]string, error) {
+	if !wf.Dispatchable {
+		return nil, fmt.Errorf("workflow %s has no workflow_dispatch trigger", wf.Path)
+	}
+
+	var errs []error
+	for name := range values {
+		if _, ok := wf.Inputs[name]; !ok {
+			errs = append(errs, fmt.Errorf("unknown input %q", name))
+		}
+	}
+
+	ret := make(map[string]string, len(wf.Inputs))
+	for _, name := range wf.inputNames() {
+		in := wf.Inputs[name]
+		v, ok := values[name]
+		if !ok || v == nil {
+			if in.Required && in.Default == "" {
+				errs = append(errs, fmt.Errorf("input %q is required", name))
+			}
+			continue
+		}
+
+		s, err := in.convert(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("input %q: %w", name, err))
+			continue
+		}
+		ret[name] = s
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// inputNames returns the names of declared inputs in sorted order.
+func (wf *dispatchWorkflow) inputNames() []string {
+	names := make([]string, 0, len(wf.Inputs))
+	for name := range wf.Inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// convert validates v against the input type and formats it as a string.
+func (in *workflowInput) convert(v any) (string, error) {
+	var s string
+	switch x := v.(type) {
+	case string:
+		s = x
+	case bool:
+		s = strconv.FormatBool(x)
+	case float64:
+		s = strconv.FormatFloat(x, 'f', -1, 64)
+	default:
+		return "", fmt.Errorf("unsupported value %v, use a string, number or boolean", v)
+	}
+
+	switch in.Type {
+	case "boolean":
+		if _, err := strconv.ParseBool(s); err != nil {
+			return "", fmt.Errorf("%q is not a boolean", s)
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return "", fmt.Errorf("%q is not a number", s)
+		}
+	case "choice":
+		if !slices.Contains(in.Options, s) {
+			return "", fmt.Errorf("%q is not one of %s", s, strings.Join(in.Options, ", "))
+		}
+	}
+	return s, nil
+}
+
+// inputsMarkdown renders the declared inputs as a list.
+func (wf *dispatchWorkflow) inputsMarkdown() string {
+	if len(wf.Inputs) == 0 {
+		return "*No inputs*"
+	}
+	var b strings.Builder
+	for _, name := range wf.inputNames() {
+		in := wf.Inputs[name]
+		typ := in.Type
+		if typ == "" {
+			typ = "string"
+		}
+		fmt.Fprintf(&b, "- `%s` (%s", name, typ)
+		if in.Required {
+			b.WriteString(", required")
+		}
+		b.WriteString(")")
+		if in.Description != "" {
+			b.WriteString(": " + in.Description)
+		}
+		if len(in.Options) > 0 {
+			b.WriteString(" | Options: " + strings.Join(in.Options, ", "))
+		}
+		if in.Default != "" {
+			b.WriteString(" | Default: " + in.Default)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,125 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package action
+
+import (
+	"maps"
+	"strings"
+	"testing"
+)
+
+const deployWorkflow = `
+name: Deploy
+on:
+  push:
+    branches: [main]
+  workflow_dispatch:
+    inputs:
+      environment:
+        type: choice
+        required: true
+        options: [staging, production]
+      dry:
+        type: boolean
+        default: "false"
+      replicas:
+        type: number
+      note:
+        description: Free text
+jobs:
+  deploy:
+    runs-on: docker
+`
+
+func TestParseWorkflow(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		dispatchable bool
+		inputs       int
+	}{
+		{name: "mapping with inputs", data: deployWorkflow, dispatchable: true, inputs: 4},
+		{name: "scalar", data: "on: workflow_dispatch\n", dispatchable: true},
+		{name: "sequence", data: "on: [push, workflow_dispatch]\n", dispatchable: true},
+		{name: "not dispatchable", data: "on: [push]\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := parseWorkflow([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("parseWorkflow() error = %v", err)
+			}
+			if wf.Dispatchable != tt.dispatchable {
+				t.Errorf("Dispatchable = %v, want %v", wf.Dispatchable, tt.dispatchable)
+			}
+			if len(wf.Inputs) != tt.inputs {
+				t.Errorf("got %d inputs, want %d", len(wf.Inputs), tt.inputs)
+			}
+		})
+	}
+}
+
+func TestValidateInputs(t *testing.T) {
+	wf, err := parseWorkflow([]byte(deployWorkflow))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		values  map[string]any
+		want    map[string]string
+		wantErr []string
+	}{
+		{
+			name:   "valid typed values",
+			values: map[string]any{"environment": "staging", "dry": true, "replicas": float64(3)},
+			want:   map[string]string{"environment": "staging", "dry": "true", "replicas": "3"},
+		},
+		{
+			name:    "missing required",
+			values:  map[string]any{},
+			wantErr: []string{`input "environment" is required`},
+		},
+		{
+			name:    "invalid values",
+			values:  map[string]any{"environment": "prod", "dry": "yes", "replicas": "many", "extra": "x"},
+			wantErr: []string{`unknown input "extra"`, "not one of staging, production", `"yes" is not a boolean`, `"many" is not a number`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wf.validateInputs(tt.values)
+			if len(tt.wantErr) > 0 {
+				if err == nil {
+					t.Fatalf("validateInputs() expected error")
+				}
+				for _, want := range tt.wantErr {
+					if !strings.Contains(err.Error(), want) {
+						t.Errorf("error %q does not contain %q", err, want)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validateInputs() error = %v", err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("validateInputs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateInputs_NotDispatchable(t *testing.T) {
+	wf := &dispatchWorkflow{Path: ".forgejo/workflows/ci.yml"}
+	if _, err := wf.validateInputs(nil); err == nil {
+		t.Error("expected error for workflow without workflow_dispatch")
+	}
+}
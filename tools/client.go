@@ -14,6 +14,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/url"
+	"sync"
 
 	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
 	"github.com/raohwork/forgejo-mcp/types"
@@ -29,6 +30,24 @@ type Client struct {
 	cl    *http.Client
 	base  string
 	token string
+
+	// DryRun forces every mutating tool to preview the change instead of
+	// sending it, see IsDryRun.
+	DryRun bool
+
+	versionOnce sync.Once
+	version     string
+}
+
+// HTTPError is returned by the custom requests if the server responds with an
+// error status.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Status)
 }
 
 // NewClient creates a new Client instance with extended functionality beyond
@@ -75,7 +94,7 @@ func NewClient(base, token, version string, cl *http.Client) (*Client, error) {
 // method: HTTP method (GET, POST, PATCH, DELETE)
 // endpoint: API endpoint path (relative to base URL)
 // paramObj: request parameter object (JSON serialized), can be nil for GET/DELETE
-// respObj: response data receiver object (JSON deserialized)
+// respObj: response data receiver object (JSON deserialized), can be nil to discard the response
 func (c *Client) sendSimpleRequest(method, endpoint string, paramObj, respObj any) error {
 	// Build complete URL
 	u, err := url.Parse(c.base + endpoint)
@@ -119,7 +138,12 @@ func (c *Client) sendSimpleRequest(method, endpoint string, paramObj, respObj an
 
 	// Check HTTP status
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+		return &HTTPError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	// Nothing to parse
+	if respObj == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
 	}
 
 	// Parse JSON response
@@ -130,6 +154,47 @@ func (c *Client) sendSimpleRequest(method, endpoint string, paramObj, respObj an
 	return nil
 }
 
+// sendRawRequest handles GET requests returning non-JSON content, like logs
+// endpoint: API endpoint path (relative to base URL)
+// maxSize: maximum number of bytes to read, the rest of the response is dropped
+func (c *Client) sendRawRequest(endpoint string, maxSize int64) ([]byte, error) {
+	// Build complete URL
+	u, err := url.Parse(c.base + endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+
+	// Create HTTP request
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set authentication header manually
+	if c.token != "" {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
+
+	// Send request
+	resp, err := c.cl.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Check HTTP status
+	if resp.StatusCode >= 400 {
+		return nil, &HTTPError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return data, nil
+}
+
 // sendUploadRequest handles file upload requests (multipart/form-data)
 // endpoint: API endpoint path (fixed to use POST)
 // filename: upload file name
@@ -192,7 +257,7 @@ func (c *Client) sendUploadRequest(endpoint, filename string, file io.Reader, ex
 
 	// Check HTTP status
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+		return &HTTPError{StatusCode: resp.StatusCode, Status: resp.Status}
 	}
 
 	// Parse JSON response
@@ -8,14 +8,35 @@ package tools
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
 
 	"github.com/raohwork/forgejo-mcp/types"
 )
 
-// MyListActionTasks lists all Forgejo Actions tasks in a repository.
+// MaxJobLogSize is the maximum size of job logs fetched by MyGetActionJobLogs.
+// Logs exceeding this size are truncated.
+const MaxJobLogSize = 16 << 20
+
+// MaxArtifactSize is the maximum size of artifacts downloaded by
+// MyDownloadActionArtifact.
+const MaxArtifactSize = 32 << 20
+
+// MyListActionTasks lists Forgejo Actions tasks in a repository.
+// page and limit are passed to the server only if positive.
 // GET /repos/{owner}/{repo}/actions/tasks
-func (c *Client) MyListActionTasks(owner, repo string) (*types.MyActionTaskResponse, error) {
+func (c *Client) MyListActionTasks(owner, repo string, page, limit int) (*types.MyActionTaskResponse, error) {
 	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/actions/tasks", owner, repo)
+	query := url.Values{}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
 
 	var result types.MyActionTaskResponse
 	err := c.sendSimpleRequest("GET", endpoint, nil, &result)
@@ -25,3 +46,240 @@ func (c *Client) MyListActionTasks(owner, repo string) (*types.MyActionTaskRespo
 
 	return &result, nil
 }
+
+// MyListActionRunsOptions represents the filters for listing workflow runs.
+// Empty fields are not sent.
+type MyListActionRunsOptions struct {
+	Page    int
+	Limit   int
+	Status  string
+	Branch  string
+	Event   string
+	Actor   string
+	HeadSHA string
+}
+
+func (o MyListActionRunsOptions) query() url.Values {
+	query := url.Values{}
+	if o.Page > 0 {
+		query.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+	for k, v := range map[string]string{
+		"status":   o.Status,
+		"branch":   o.Branch,
+		"event":    o.Event,
+		"actor":    o.Actor,
+		"head_sha": o.HeadSHA,
+	} {
+		if v != "" {
+			query.Set(k, v)
+		}
+	}
+	return query
+}
+
+// runsEndpoint returns the endpoint listing the workflow runs of a repository.
+func runsEndpoint(owner, repo string) string {
+	return fmt.Sprintf("/api/v1/repos/%s/%s/actions/runs", owner, repo)
+}
+
+// artifactsEndpoint returns the endpoint listing the artifacts of a
+// repository.
+func artifactsEndpoint(owner, repo string) string {
+	return fmt.Sprintf("/api/v1/repos/%s/%s/actions/artifacts", owner, repo)
+}
+
+// MyListActionRuns lists workflow runs in a repository.
+// GET /repos/{owner}/{repo}/actions/runs
+func (c *Client) MyListActionRuns(owner, repo string, opt MyListActionRunsOptions) (*types.MyActionRunResponse, error) {
+	if err := c.CheckAPI(APIActionRuns); err != nil {
+		return nil, err
+	}
+	endpoint := runsEndpoint(owner, repo)
+	if query := opt.query(); len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var result types.MyActionRunResponse
+	err := c.sendSimpleRequest("GET", endpoint, nil, &result)
+	if err != nil {
+		return nil, apiError(APIActionRuns, err)
+	}
+
+	return &result, nil
+}
+
+// MyGetActionRun gets a workflow run by its ID.
+// GET /repos/{owner}/{repo}/actions/runs/{run}
+func (c *Client) MyGetActionRun(owner, repo string, runID int64) (*types.MyActionRun, error) {
+	if err := c.CheckAPI(APIActionRuns); err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/actions/runs/%d", owner, repo, runID)
+
+	var result types.MyActionRun
+	err := c.sendSimpleRequest("GET", endpoint, nil, &result)
+	if err != nil {
+		return nil, c.itemError(APIActionRuns, runsEndpoint(owner, repo), fmt.Sprintf("run %d", runID), err)
+	}
+
+	return &result, nil
+}
+
+// MyListActionRunJobs lists the jobs of a workflow run, including their steps.
+// GET /repos/{owner}/{repo}/actions/runs/{run}/jobs
+func (c *Client) MyListActionRunJobs(owner, repo string, runID int64) (*types.MyActionJobResponse, error) {
+	if err := c.CheckAPI(APIActionRuns); err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/actions/runs/%d/jobs", owner, repo, runID)
+
+	var result types.MyActionJobResponse
+	err := c.sendSimpleRequest("GET", endpoint, nil, &result)
+	if err != nil {
+		return nil, c.itemError(APIActionRuns, runsEndpoint(owner, repo), fmt.Sprintf("run %d", runID), err)
+	}
+
+	return &result, nil
+}
+
+// MyGetActionJobLogs downloads the raw logs of a job. Logs larger than
+// MaxJobLogSize are truncated.
+// GET /repos/{owner}/{repo}/actions/jobs/{job_id}/logs
+func (c *Client) MyGetActionJobLogs(owner, repo string, jobID int64) (string, error) {
+	if err := c.CheckAPI(APIActionRuns); err != nil {
+		return "", err
+	}
+	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/actions/jobs/%d/logs", owner, repo, jobID)
+
+	data, err := c.sendRawRequest(endpoint, MaxJobLogSize)
+	if err != nil {
+		return "", c.itemError(APIActionRuns, runsEndpoint(owner, repo), fmt.Sprintf("job %d", jobID), err)
+	}
+
+	return string(data), nil
+}
+
+// MyDispatchWorkflowOption represents the options for dispatching a workflow.
+type MyDispatchWorkflowOption struct {
+	// Ref is the branch or tag to run the workflow on.
+	Ref string `json:"ref"`
+	// Inputs are the values of the workflow_dispatch inputs.
+	Inputs map[string]string `json:"inputs,omitempty"`
+	// ReturnRunInfo asks the server to return the created run (Forgejo only).
+	ReturnRunInfo bool `json:"return_run_info,omitempty"`
+}
+
+// MyDispatchWorkflow triggers a workflow_dispatch event for a workflow file.
+// Servers that do not return the created run yield a nil result.
+// POST /repos/{owner}/{repo}/actions/workflows/{workflow}/dispatches
+func (c *Client) MyDispatchWorkflow(owner, repo, workflow string, opt MyDispatchWorkflowOption) (*types.MyDispatchedRun, error) {
+	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/actions/workflows/%s/dispatches", owner, repo, url.PathEscape(workflow))
+
+	var result *types.MyDispatchedRun
+	err := c.sendSimpleRequest("POST", endpoint, opt, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// MyRerunActionRun reruns all jobs of a workflow run, or a single job if
+// jobID is positive.
+// POST /repos/{owner}/{repo}/actions/runs/{run}/rerun
+// POST /repos/{owner}/{repo}/actions/runs/{run}/jobs/{job_id}/rerun
+func (c *Client) MyRerunActionRun(owner, repo string, runID, jobID int64) error {
+	if err := c.CheckAPI(APIActionRuns); err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/actions/runs/%d", owner, repo, runID)
+	item := fmt.Sprintf("run %d", runID)
+	if jobID > 0 {
+		endpoint += fmt.Sprintf("/jobs/%d", jobID)
+		item = fmt.Sprintf("job %d of run %d", jobID, runID)
+	}
+	endpoint += "/rerun"
+
+	err := c.sendSimpleRequest("POST", endpoint, nil, nil)
+	return c.itemError(APIActionRuns, runsEndpoint(owner, repo), item, err)
+}
+
+// MyCancelActionRun cancels a running workflow run.
+// POST /repos/{owner}/{repo}/actions/runs/{run}/cancel
+func (c *Client) MyCancelActionRun(owner, repo string, runID int64) error {
+	if err := c.CheckAPI(APIActionRuns); err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/actions/runs/%d/cancel", owner, repo, runID)
+	err := c.sendSimpleRequest("POST", endpoint, nil, nil)
+	return c.itemError(APIActionRuns, runsEndpoint(owner, repo), fmt.Sprintf("run %d", runID), err)
+}
+
+// MyListActionArtifacts lists the artifacts of a workflow run, or of the whole
+// repository if runID is 0. name filters artifacts by exact name if not empty.
+// GET /repos/{owner}/{repo}/actions/runs/{run}/artifacts
+// GET /repos/{owner}/{repo}/actions/artifacts
+func (c *Client) MyListActionArtifacts(owner, repo string, runID int64, name string) (*types.MyActionArtifactResponse, error) {
+	if err := c.CheckAPI(APIActionArtifacts); err != nil {
+		return nil, err
+	}
+	endpoint := artifactsEndpoint(owner, repo)
+	if runID > 0 {
+		endpoint = fmt.Sprintf("/api/v1/repos/%s/%s/actions/runs/%d/artifacts", owner, repo, runID)
+	}
+	if name != "" {
+		endpoint += "?" + url.Values{"name": {name}}.Encode()
+	}
+
+	var result types.MyActionArtifactResponse
+	err := c.sendSimpleRequest("GET", endpoint, nil, &result)
+	if err != nil && runID > 0 {
+		return nil, c.itemError(APIActionArtifacts, artifactsEndpoint(owner, repo), fmt.Sprintf("run %d", runID), err)
+	}
+	if err != nil {
+		return nil, apiError(APIActionArtifacts, err)
+	}
+
+	return &result, nil
+}
+
+// MyGetActionArtifact gets an artifact by its ID.
+// GET /repos/{owner}/{repo}/actions/artifacts/{artifact_id}
+func (c *Client) MyGetActionArtifact(owner, repo string, artifactID int64) (*types.MyActionArtifact, error) {
+	if err := c.CheckAPI(APIActionArtifacts); err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/actions/artifacts/%d", owner, repo, artifactID)
+
+	var result types.MyActionArtifact
+	err := c.sendSimpleRequest("GET", endpoint, nil, &result)
+	if err != nil {
+		return nil, c.itemError(APIActionArtifacts, artifactsEndpoint(owner, repo), fmt.Sprintf("artifact %d", artifactID), err)
+	}
+
+	return &result, nil
+}
+
+// MyDownloadActionArtifact downloads an artifact as a zip archive. Artifacts
+// larger than MaxArtifactSize are rejected.
+// GET /repos/{owner}/{repo}/actions/artifacts/{artifact_id}/zip
+func (c *Client) MyDownloadActionArtifact(owner, repo string, artifactID int64) ([]byte, error) {
+	if err := c.CheckAPI(APIActionArtifacts); err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/actions/artifacts/%d/zip", owner, repo, artifactID)
+
+	data, err := c.sendRawRequest(endpoint, MaxArtifactSize+1)
+	if err != nil {
+		return nil, c.itemError(APIActionArtifacts, artifactsEndpoint(owner, repo), fmt.Sprintf("artifact %d", artifactID), err)
+	}
+	if len(data) > MaxArtifactSize {
+		return nil, fmt.Errorf("artifact exceeds %d bytes", MaxArtifactSize)
+	}
+
+	return data, nil
+}
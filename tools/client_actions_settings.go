@@ -0,0 +1,251 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package tools
+
+import (
+	"fmt"
+
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// Scopes of Actions secrets and variables.
+const (
+	ActionsScopeRepo = "repo"
+	ActionsScopeOrg  = "org"
+	ActionsScopeUser = "user"
+	// ActionsScopeAdmin is the instance-wide scope, only used for runners.
+	ActionsScopeAdmin = "admin"
+)
+
+// ActionsScopeEndpoint returns the endpoint prefix of Actions settings at
+// scope: repository (owner and repo), organization (owner) or the
+// authenticated user.
+func ActionsScopeEndpoint(scope, owner, repo string) (string, error) {
+	switch scope {
+	case ActionsScopeRepo:
+		if owner == "" || repo == "" {
+			return "", fmt.Errorf("owner and repo are required at repo scope")
+		}
+		return fmt.Sprintf("/api/v1/repos/%s/%s/actions", owner, repo), nil
+	case ActionsScopeOrg:
+		if owner == "" {
+			return "", fmt.Errorf("owner is required at org scope")
+		}
+		return fmt.Sprintf("/api/v1/orgs/%s/actions", owner), nil
+	case ActionsScopeUser:
+		return "/api/v1/user/actions", nil
+	}
+	return "", fmt.Errorf("unknown scope %q, use 'repo', 'org' or 'user'", scope)
+}
+
+// listAll fetches every page of a list endpoint.
+func listAll[T any](c *Client, endpoint string) ([]*T, error) {
+	const limit = 50
+	var ret []*T
+	for page := 1; ; page++ {
+		var result []*T
+		err := c.sendSimpleRequest("GET", fmt.Sprintf("%s?page=%d&limit=%d", endpoint, page, limit), nil, &result)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, result...)
+		if len(result) < limit {
+			return ret, nil
+		}
+	}
+}
+
+// MyListActionSecrets lists the names of Actions secrets, values are never
+// returned by the server. The API has no endpoint listing the secrets of the
+// user, so the user scope returns ErrUnsupported.
+// GET /repos/{owner}/{repo}/actions/secrets
+// GET /orgs/{org}/actions/secrets
+func (c *Client) MyListActionSecrets(scope, owner, repo string) ([]*types.MySecret, error) {
+	if scope == ActionsScopeUser {
+		return nil, fmt.Errorf("%w: the API can only set and delete the secrets of the user, not list them", ErrUnsupported)
+	}
+	prefix, err := ActionsScopeEndpoint(scope, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	return listAll[types.MySecret](c, prefix+"/secrets")
+}
+
+// MySetActionSecret creates or updates an Actions secret.
+// PUT /repos/{owner}/{repo}/actions/secrets/{secretname}
+// PUT /orgs/{org}/actions/secrets/{secretname}
+// PUT /user/actions/secrets/{secretname}
+func (c *Client) MySetActionSecret(scope, owner, repo, name, data string) error {
+	prefix, err := ActionsScopeEndpoint(scope, owner, repo)
+	if err != nil {
+		return err
+	}
+	body := map[string]string{"data": data}
+	return c.sendSimpleRequest("PUT", prefix+"/secrets/"+name, body, nil)
+}
+
+// MyDeleteActionSecret deletes an Actions secret.
+// DELETE /repos/{owner}/{repo}/actions/secrets/{secretname}
+// DELETE /orgs/{org}/actions/secrets/{secretname}
+// DELETE /user/actions/secrets/{secretname}
+func (c *Client) MyDeleteActionSecret(scope, owner, repo, name string) error {
+	prefix, err := ActionsScopeEndpoint(scope, owner, repo)
+	if err != nil {
+		return err
+	}
+	return c.sendSimpleRequest("DELETE", prefix+"/secrets/"+name, nil, nil)
+}
+
+// MyListActionVariables lists Actions variables with their values.
+// GET /repos/{owner}/{repo}/actions/variables
+// GET /orgs/{org}/actions/variables
+// GET /user/actions/variables
+func (c *Client) MyListActionVariables(scope, owner, repo string) ([]*types.MyActionVariable, error) {
+	prefix, err := ActionsScopeEndpoint(scope, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	return listAll[types.MyActionVariable](c, prefix+"/variables")
+}
+
+// MyGetActionVariable gets an Actions variable by name.
+// GET /repos/{owner}/{repo}/actions/variables/{variablename}
+// GET /orgs/{org}/actions/variables/{variablename}
+// GET /user/actions/variables/{variablename}
+func (c *Client) MyGetActionVariable(scope, owner, repo, name string) (*types.MyActionVariable, error) {
+	prefix, err := ActionsScopeEndpoint(scope, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var result types.MyActionVariable
+	err = c.sendSimpleRequest("GET", prefix+"/variables/"+name, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// MyCreateActionVariable creates an Actions variable.
+// POST /repos/{owner}/{repo}/actions/variables/{variablename}
+// POST /orgs/{org}/actions/variables/{variablename}
+// POST /user/actions/variables/{variablename}
+func (c *Client) MyCreateActionVariable(scope, owner, repo, name, value string) error {
+	prefix, err := ActionsScopeEndpoint(scope, owner, repo)
+	if err != nil {
+		return err
+	}
+	body := map[string]string{"value": value}
+	return c.sendSimpleRequest("POST", prefix+"/variables/"+name, body, nil)
+}
+
+// MyUpdateActionVariable updates the value of an Actions variable, renaming
+// it if newName is not empty.
+// PUT /repos/{owner}/{repo}/actions/variables/{variablename}
+// PUT /orgs/{org}/actions/variables/{variablename}
+// PUT /user/actions/variables/{variablename}
+func (c *Client) MyUpdateActionVariable(scope, owner, repo, name, newName, value string) error {
+	prefix, err := ActionsScopeEndpoint(scope, owner, repo)
+	if err != nil {
+		return err
+	}
+	body := map[string]string{"value": value}
+	if newName != "" {
+		body["name"] = newName
+	}
+	return c.sendSimpleRequest("PUT", prefix+"/variables/"+name, body, nil)
+}
+
+// MyDeleteActionVariable deletes an Actions variable.
+// DELETE /repos/{owner}/{repo}/actions/variables/{variablename}
+// DELETE /orgs/{org}/actions/variables/{variablename}
+// DELETE /user/actions/variables/{variablename}
+func (c *Client) MyDeleteActionVariable(scope, owner, repo, name string) error {
+	prefix, err := ActionsScopeEndpoint(scope, owner, repo)
+	if err != nil {
+		return err
+	}
+	return c.sendSimpleRequest("DELETE", prefix+"/variables/"+name, nil, nil)
+}
+
+// RunnersEndpoint returns the endpoint of runners at scope. Instance-wide
+// runners, managed by site administrators, use the admin scope.
+func RunnersEndpoint(scope, owner, repo string) (string, error) {
+	if scope == ActionsScopeAdmin {
+		return "/api/v1/admin/actions/runners", nil
+	}
+	prefix, err := ActionsScopeEndpoint(scope, owner, repo)
+	if err != nil {
+		return "", err
+	}
+	return prefix + "/runners", nil
+}
+
+// MyListActionRunners lists the runners registered at scope.
+// GET /repos/{owner}/{repo}/actions/runners
+// GET /orgs/{org}/actions/runners
+// GET /user/actions/runners
+// GET /admin/actions/runners
+func (c *Client) MyListActionRunners(scope, owner, repo string) (*types.MyActionRunnerResponse, error) {
+	if err := c.CheckAPI(APIActionRunners); err != nil {
+		return nil, err
+	}
+	endpoint, err := RunnersEndpoint(scope, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var result types.MyActionRunnerResponse
+	err = c.sendSimpleRequest("GET", endpoint, nil, &result)
+	if err != nil {
+		return nil, apiError(APIActionRunners, err)
+	}
+
+	return &result, nil
+}
+
+// MyGetRunnerRegistrationToken gets a token to register runners at scope.
+// GET /repos/{owner}/{repo}/actions/runners/registration-token
+// GET /orgs/{org}/actions/runners/registration-token
+// GET /user/actions/runners/registration-token
+// GET /admin/runners/registration-token
+func (c *Client) MyGetRunnerRegistrationToken(scope, owner, repo string) (*types.MyRunnerRegistrationToken, error) {
+	endpoint := "/api/v1/admin/runners/registration-token"
+	if scope != ActionsScopeAdmin {
+		prefix, err := RunnersEndpoint(scope, owner, repo)
+		if err != nil {
+			return nil, err
+		}
+		endpoint = prefix + "/registration-token"
+	}
+
+	var result types.MyRunnerRegistrationToken
+	err := c.sendSimpleRequest("GET", endpoint, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// MyDeleteActionRunner deletes a runner registered at scope.
+// DELETE /repos/{owner}/{repo}/actions/runners/{runner_id}
+// DELETE /orgs/{org}/actions/runners/{runner_id}
+// DELETE /user/actions/runners/{runner_id}
+// DELETE /admin/actions/runners/{runner_id}
+func (c *Client) MyDeleteActionRunner(scope, owner, repo string, runnerID int64) error {
+	if err := c.CheckAPI(APIActionRunners); err != nil {
+		return err
+	}
+	endpoint, err := RunnersEndpoint(scope, owner, repo)
+	if err != nil {
+		return err
+	}
+	err = c.sendSimpleRequest("DELETE", fmt.Sprintf("%s/%d", endpoint, runnerID), nil, nil)
+	return c.itemError(APIActionRunners, endpoint, fmt.Sprintf("runner %d", runnerID), err)
+}
@@ -8,6 +8,8 @@ package tools
 
 import (
 	"fmt"
+	"io"
+	"net/url"
 
 	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
 )
@@ -60,3 +62,38 @@ func (c *Client) MyEditIssueAttachment(owner, repo string, index, attachmentID i
 
 	return &result, nil
 }
+
+// MaxAttachmentSize is the maximum size of attachments downloaded by
+// MyDownloadAttachment.
+const MaxAttachmentSize = 32 << 20
+
+// MyDownloadAttachment downloads the content of an issue or comment
+// attachment. Attachments larger than MaxAttachmentSize are rejected.
+// GET /attachments/{uuid}
+func (c *Client) MyDownloadAttachment(attachment *forgejo.Attachment) ([]byte, error) {
+	endpoint := "/attachments/" + url.PathEscape(attachment.UUID)
+
+	data, err := c.sendRawRequest(endpoint, MaxAttachmentSize+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxAttachmentSize {
+		return nil, fmt.Errorf("attachment exceeds %d bytes", MaxAttachmentSize)
+	}
+
+	return data, nil
+}
+
+// MyCreateIssueAttachment uploads a file as an attachment of an issue.
+// POST /repos/{owner}/{repo}/issues/{index}/assets
+func (c *Client) MyCreateIssueAttachment(owner, repo string, index int64, filename string, file io.Reader) (*forgejo.Attachment, error) {
+	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/issues/%d/assets", owner, repo, index)
+
+	var result forgejo.Attachment
+	err := c.sendUploadRequest(endpoint, filename, file, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -31,18 +31,12 @@ func (c *Client) MyAddIssueDependency(owner, repo string, index int64, dependenc
 }
 
 // MyListIssueDependencies lists all dependencies of an issue.
-// Returns issues that must be closed before the current issue can be closed.
+// Returns issues that must be closed before the current issue can be closed,
+// fetching every page.
 // GET /repos/{owner}/{repo}/issues/{index}/dependencies
 func (c *Client) MyListIssueDependencies(owner, repo string, index int64) ([]*forgejo.Issue, error) {
 	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/issues/%d/dependencies", owner, repo, index)
-
-	var result []*forgejo.Issue
-	err := c.sendSimpleRequest("GET", endpoint, nil, &result)
-	if err != nil {
-		return nil, err
-	}
-
-	return result, nil
+	return listAll[forgejo.Issue](c, endpoint)
 }
 
 // MyRemoveIssueDependency removes a dependency from an issue.
@@ -61,14 +55,12 @@ func (c *Client) MyRemoveIssueDependency(owner, repo string, index int64, depend
 }
 
 // MyListIssueBlocking lists all issues blocked by this issue.
-// Returns issues that cannot be closed until the current issue is closed.
+// Returns issues that cannot be closed until the current issue is closed,
+// fetching every page.
 // GET /repos/{owner}/{repo}/issues/{index}/blocks
 func (c *Client) MyListIssueBlocking(owner, repo string, index int64) ([]*forgejo.Issue, error) {
 	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/issues/%d/blocks", owner, repo, index)
-
-	var issues []*forgejo.Issue
-	err := c.sendSimpleRequest("GET", endpoint, nil, &issues)
-	return issues, err
+	return listAll[forgejo.Issue](c, endpoint)
 }
 
 // MyAddIssueBlocking blocks the issue given in the body by the issue in path.
@@ -0,0 +1,42 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package tools
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestClient_MyListIssueDependencies_pages(t *testing.T) {
+	const total = 70
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		var issues []map[string]int
+		for i := (page-1)*limit + 1; i <= min(page*limit, total); i++ {
+			issues = append(issues, map[string]int{"number": i})
+		}
+		json.NewEncoder(w).Encode(issues)
+	}))
+	defer server.Close()
+	client, err := NewClient(server.URL, "test-token", forgejo_version_to_test, server.Client())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	deps, err := client.MyListIssueDependencies("owner", "repo", 1)
+	if err != nil || len(deps) != total {
+		t.Errorf("Expected %d dependencies, got %d, %v", total, len(deps), err)
+	}
+	blocks, err := client.MyListIssueBlocking("owner", "repo", 1)
+	if err != nil || len(blocks) != total {
+		t.Errorf("Expected %d blocked issues, got %d, %v", total, len(blocks), err)
+	}
+}
@@ -0,0 +1,93 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package tools
+
+import (
+	"fmt"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+)
+
+// MyLockIssue locks the conversation of an issue or pull request, so only
+// collaborators can comment. Reason is optional.
+// PUT /repos/{owner}/{repo}/issues/{index}/lock
+func (c *Client) MyLockIssue(owner, repo string, index int64, reason string) error {
+	if err := c.CheckAPI(APIIssueLock); err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/issues/%d/lock", owner, repo, index)
+	var body any
+	if reason != "" {
+		body = map[string]string{"lock_reason": reason}
+	}
+	return c.lockError(owner, repo, index, c.sendSimpleRequest("PUT", endpoint, body, nil))
+}
+
+// MyUnlockIssue unlocks the conversation of an issue or pull request.
+// DELETE /repos/{owner}/{repo}/issues/{index}/lock
+func (c *Client) MyUnlockIssue(owner, repo string, index int64) error {
+	if err := c.CheckAPI(APIIssueLock); err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/issues/%d/lock", owner, repo, index)
+	return c.lockError(owner, repo, index, c.sendSimpleRequest("DELETE", endpoint, nil, nil))
+}
+
+// lockError explains a 404 response of the lock endpoint: the issue does not
+// exist, or the server lacks the endpoint.
+func (c *Client) lockError(owner, repo string, index int64, err error) error {
+	if !isNotFound(err) {
+		return err
+	}
+	if _, _, ierr := c.GetIssue(owner, repo, index); ierr != nil {
+		return fmt.Errorf("issue #%d not found: %w", index, err)
+	}
+	return apiError(APIIssueLock, err)
+}
+
+// MyPinIssue pins an issue or pull request at the end of the pinned list.
+// POST /repos/{owner}/{repo}/issues/{index}/pin
+func (c *Client) MyPinIssue(owner, repo string, index int64) error {
+	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/issues/%d/pin", owner, repo, index)
+	return c.sendSimpleRequest("POST", endpoint, nil, nil)
+}
+
+// MyUnpinIssue unpins an issue or pull request.
+// DELETE /repos/{owner}/{repo}/issues/{index}/pin
+func (c *Client) MyUnpinIssue(owner, repo string, index int64) error {
+	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/issues/%d/pin", owner, repo, index)
+	return c.sendSimpleRequest("DELETE", endpoint, nil, nil)
+}
+
+// MyMoveIssuePin moves a pinned issue or pull request to position, starting
+// from 1.
+// PATCH /repos/{owner}/{repo}/issues/{index}/pin/{position}
+func (c *Client) MyMoveIssuePin(owner, repo string, index, position int64) error {
+	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/issues/%d/pin/%d", owner, repo, index, position)
+	return c.sendSimpleRequest("PATCH", endpoint, nil, nil)
+}
+
+// MyListPinnedIssues lists the pinned issues of a repository in pin order.
+// GET /repos/{owner}/{repo}/issues/pinned
+func (c *Client) MyListPinnedIssues(owner, repo string) ([]*forgejo.Issue, error) {
+	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/issues/pinned", owner, repo)
+
+	var issues []*forgejo.Issue
+	err := c.sendSimpleRequest("GET", endpoint, nil, &issues)
+	return issues, err
+}
+
+// MyListPinnedPullRequests lists the pinned pull requests of a repository in
+// pin order.
+// GET /repos/{owner}/{repo}/pulls/pinned
+func (c *Client) MyListPinnedPullRequests(owner, repo string) ([]*forgejo.PullRequest, error) {
+	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/pulls/pinned", owner, repo)
+
+	var pulls []*forgejo.PullRequest
+	err := c.sendSimpleRequest("GET", endpoint, nil, &pulls)
+	return pulls, err
+}
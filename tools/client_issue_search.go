@@ -0,0 +1,98 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package tools
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+)
+
+// MySearchIssuesOptions represents the filters for searching issues across
+// repositories. Empty fields are not sent. The SDK's ListIssueOption lacks the
+// "by me" filters of the search endpoint.
+type MySearchIssuesOptions struct {
+	Page       int
+	Limit      int
+	State      string // open, closed or all
+	Type       string // issues or pulls
+	Query      string
+	Labels     []string
+	Milestones []string
+	Owner      string
+	Team       string
+	Since      time.Time
+	Before     time.Time
+	// The following filters select issues related to the authenticated user.
+	Assigned        bool
+	Created         bool
+	Mentioned       bool
+	ReviewRequested bool
+	Reviewed        bool
+}
+
+func (o MySearchIssuesOptions) query() url.Values {
+	query := url.Values{}
+	if o.Page > 0 {
+		query.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+	for k, v := range map[string]string{
+		"state":      o.State,
+		"type":       o.Type,
+		"q":          o.Query,
+		"labels":     strings.Join(o.Labels, ","),
+		"milestones": strings.Join(o.Milestones, ","),
+		"owner":      o.Owner,
+		"team":       o.Team,
+	} {
+		if v != "" {
+			query.Set(k, v)
+		}
+	}
+	if !o.Since.IsZero() {
+		query.Set("since", o.Since.Format(time.RFC3339))
+	}
+	if !o.Before.IsZero() {
+		query.Set("before", o.Before.Format(time.RFC3339))
+	}
+	for k, v := range map[string]bool{
+		"assigned":         o.Assigned,
+		"created":          o.Created,
+		"mentioned":        o.Mentioned,
+		"review_requested": o.ReviewRequested,
+		"reviewed":         o.Reviewed,
+	} {
+		if v {
+			query.Set(k, "true")
+		}
+	}
+	return query
+}
+
+// MySearchIssues searches issues and pull requests in all repositories the
+// authenticated user can see.
+// GET /repos/issues/search
+func (c *Client) MySearchIssues(opt MySearchIssuesOptions) ([]*forgejo.Issue, error) {
+	endpoint := "/api/v1/repos/issues/search"
+	if query := opt.query(); len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var result []*forgejo.Issue
+	err := c.sendSimpleRequest("GET", endpoint, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
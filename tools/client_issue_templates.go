@@ -0,0 +1,25 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package tools
+
+import (
+	"fmt"
+
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// MyListIssueTemplates lists the issue templates and issue forms of a
+// repository. The server reads them from the template directories, like
+// .forgejo/ISSUE_TEMPLATE, of the default branch.
+// GET /repos/{owner}/{repo}/issue_templates
+func (c *Client) MyListIssueTemplates(owner, repo string) ([]*types.MyIssueTemplate, error) {
+	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/issue_templates", owner, repo)
+
+	var templates []*types.MyIssueTemplate
+	err := c.sendSimpleRequest("GET", endpoint, nil, &templates)
+	return templates, err
+}
@@ -0,0 +1,44 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package tools
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// MyListIssueTimeline lists all events in the timeline of an issue or pull
+// request, oldest first. If since is not zero, only events updated after it
+// are returned.
+// GET /repos/{owner}/{repo}/issues/{index}/timeline
+func (c *Client) MyListIssueTimeline(owner, repo string, index int64, since time.Time) ([]*types.MyTimelineEvent, error) {
+	const limit = 50
+	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/issues/%d/timeline", owner, repo, index)
+
+	var ret []*types.MyTimelineEvent
+	for page := 1; ; page++ {
+		query := url.Values{}
+		query.Set("page", fmt.Sprint(page))
+		query.Set("limit", fmt.Sprint(limit))
+		if !since.IsZero() {
+			query.Set("since", since.Format(time.RFC3339))
+		}
+
+		var result []*types.MyTimelineEvent
+		err := c.sendSimpleRequest("GET", endpoint+"?"+query.Encode(), nil, &result)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, result...)
+		if len(result) < limit {
+			return ret, nil
+		}
+	}
+}
@@ -0,0 +1,21 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package tools
+
+import (
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+)
+
+// MyListMyTrackedTimes lists the tracked times of the authenticated user
+// across all repositories. The SDK's GetMyTrackedTimes does not accept filters.
+// GET /user/times
+func (c *Client) MyListMyTrackedTimes(opt forgejo.ListTrackedTimesOptions) ([]*forgejo.TrackedTime, error) {
+	opt.User = ""
+	var result []*forgejo.TrackedTime
+	err := c.sendSimpleRequest("GET", "/api/v1/user/times?"+opt.QueryEncode(), nil, &result)
+	return result, err
+}
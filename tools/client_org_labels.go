@@ -0,0 +1,20 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package tools
+
+import (
+	"fmt"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+)
+
+// MyListOrgLabels lists the labels of an organization, which issues of all
+// its repositories can use.
+// GET /orgs/{org}/labels
+func (c *Client) MyListOrgLabels(org string) ([]*forgejo.Label, error) {
+	return listAll[forgejo.Label](c, fmt.Sprintf("/api/v1/orgs/%s/labels", org))
+}
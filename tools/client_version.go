@@ -0,0 +1,85 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ErrUnsupported is returned for custom endpoints the server does not provide.
+var ErrUnsupported = errors.New("not supported by the server")
+
+// Custom APIs that are not part of the Forgejo 11 API (swagger of Forgejo
+// 11.0.3), for CheckAPI and the errors of their endpoints. Gitea provides
+// some of them.
+const (
+	APIActionRuns      = "the actions runs API"
+	APIActionArtifacts = "the actions artifacts API"
+	APIActionRunners   = "listing and deleting actions runners"
+	APIIssueLock       = "locking issues"
+)
+
+// LastForgejoWithoutAPIs is the last major version of Forgejo known to lack
+// the APIs above.
+const LastForgejoWithoutAPIs = 11
+
+// serverVersion returns the version reported by the server, fetched once.
+// It is empty if the server does not report it.
+func (c *Client) serverVersion() string {
+	c.versionOnce.Do(func() {
+		c.version, _, _ = c.ServerVersion()
+	})
+	return c.version
+}
+
+// CheckAPI returns ErrUnsupported if the server is Forgejo up to
+// LastForgejoWithoutAPIs, whose API lacks api. Forgejo reports versions like
+// "11.0.3+gitea-1.22.0"; other servers, such as Gitea, and newer Forgejo
+// versions pass, a missing endpoint is reported by apiError.
+func (c *Client) CheckAPI(api string) error {
+	v := c.serverVersion()
+	if !strings.Contains(v, "+gitea-") {
+		return nil
+	}
+	major, _, _ := strings.Cut(v, ".")
+	if n, err := strconv.Atoi(major); err == nil && n <= LastForgejoWithoutAPIs {
+		return fmt.Errorf("%w: the server runs Forgejo %s, whose API does not provide %s", ErrUnsupported, v, api)
+	}
+	return nil
+}
+
+// isNotFound reports whether err is a 404 response.
+func isNotFound(err error) bool {
+	var he *HTTPError
+	return errors.As(err, &he) && he.StatusCode == http.StatusNotFound
+}
+
+// apiError explains a 404 response of a collection endpoint of api, such as
+// the list of runs of a repository, which means the server lacks api.
+func apiError(api string, err error) error {
+	if isNotFound(err) {
+		return fmt.Errorf("%w: %s (HTTP 404: the server does not provide it, or the repository does not exist)", ErrUnsupported, api)
+	}
+	return err
+}
+
+// itemError explains a 404 response for item of api, e.g. "run 12". The
+// collection endpoint listing such items is probed: if it is missing too, the
+// server lacks api, otherwise item does not exist.
+func (c *Client) itemError(api, collection, item string, err error) error {
+	if !isNotFound(err) {
+		return err
+	}
+	if probe := c.sendSimpleRequest("GET", collection+"?limit=1", nil, nil); isNotFound(probe) {
+		return apiError(api, probe)
+	}
+	return fmt.Errorf("%s not found: %w", item, err)
+}
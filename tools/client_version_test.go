@@ -0,0 +1,106 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_CheckAPI(t *testing.T) {
+	newServer := func(version string, called *bool) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/v1/version" && version != "" {
+				json.NewEncoder(w).Encode(map[string]string{"version": version})
+				return
+			}
+			*called = true
+			http.NotFound(w, r)
+		}))
+	}
+
+	// Forgejo 11 is rejected before the request
+	var called bool
+	server := newServer(forgejo_version_to_test, &called)
+	defer server.Close()
+	client, err := NewClient(server.URL, "test-token", forgejo_version_to_test, server.Client())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	_, err = client.MyListActionRuns("owner", "repo", MyListActionRunsOptions{})
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Expected ErrUnsupported, got %v", err)
+	}
+	if called {
+		t.Error("Expected no request to the runs endpoint")
+	}
+
+	// a 404 of a server of unknown version is explained
+	unknown := newServer("", &called)
+	defer unknown.Close()
+	client, err = NewClient(unknown.URL, "test-token", forgejo_version_to_test, unknown.Client())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	_, err = client.MyListActionRuns("owner", "repo", MyListActionRunsOptions{})
+	if !errors.Is(err, ErrUnsupported) || !called {
+		t.Errorf("Expected ErrUnsupported after a request, got %v", err)
+	}
+
+	// other errors are kept
+	if err := apiError(APIActionRuns, &HTTPError{StatusCode: 500, Status: "500 Internal Server Error"}); errors.Is(err, ErrUnsupported) {
+		t.Errorf("Expected HTTP 500 error, got %v", err)
+	}
+
+	// Gitea and newer Forgejo versions are not rejected
+	for _, v := range []string{"1.24.2", "12.0.0+gitea-1.22.0"} {
+		called = false
+		other := newServer(v, &called)
+		client, err = NewClient(other.URL, "test-token", forgejo_version_to_test, other.Client())
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		if err := client.CheckAPI(APIActionRuns); err != nil {
+			t.Errorf("CheckAPI() on %s = %v", v, err)
+		}
+		other.Close()
+	}
+}
+
+func TestClient_itemError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/version":
+			json.NewEncoder(w).Encode(map[string]string{"version": "1.24.2"})
+		case "/api/v1/repos/owner/repo/actions/runs":
+			w.Write([]byte(`{"workflow_runs": [], "total_count": 0}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client, err := NewClient(server.URL, "test-token", forgejo_version_to_test, server.Client())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	// the runs API exists, so the run does not
+	_, err = client.MyGetActionRun("owner", "repo", 42)
+	if err == nil || errors.Is(err, ErrUnsupported) || !strings.Contains(err.Error(), "run 42 not found") {
+		t.Errorf("Expected run not found, got %v", err)
+	}
+
+	// the artifacts API is missing
+	_, err = client.MyGetActionArtifact("owner", "repo", 7)
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Expected ErrUnsupported, got %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package tools
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ConfirmTokenTTL is how long a confirm token issued by Confirmer stays valid.
+const ConfirmTokenTTL = 5 * time.Minute
+
+// ErrInvalidConfirmToken is returned when a confirm token is unknown, expired,
+// or was issued for another operation.
+var ErrInvalidConfirmToken = errors.New("invalid or expired confirm token")
+
+// Confirmer implements the opt-in confirmation policy for destructive tools.
+//
+// Before a destructive tool proceeds, it describes what will be destroyed and
+// asks the user to confirm:
+//   - If the client supports elicitation, the user is asked directly through
+//     an elicitation request.
+//   - Otherwise the first call returns the preview and a confirm token without
+//     doing anything. Calling the tool again with the same arguments and the
+//     token performs the operation. Tokens are single use, bound to the exact
+//     operation and expire after ConfirmTokenTTL.
+//
+// A nil *Confirmer disables the policy: every operation proceeds immediately.
+type Confirmer struct {
+	mu     sync.Mutex
+	now    func() time.Time
+	tokens map[string]pendingConfirm
+}
+
+type pendingConfirm struct {
+	action  string
+	expires time.Time
+}
+
+// NewConfirmer creates a Confirmer with no pending tokens.
+func NewConfirmer() *Confirmer {
+	return &Confirmer{
+		now:    time.Now,
+		tokens: map[string]pendingConfirm{},
+	}
+}
+
+// ConfirmTokenSchema returns the schema of the `confirm_token` tool argument.
+func ConfirmTokenSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "string",
+		Description: "Token returned by a previous call of this tool to confirm the operation (optional, only needed when asked for)",
+	}
+}
+
+// Check asks for confirmation of action, a string identifying the exact
+// operation (e.g. "delete_label:owner/repo:12"). preview is called to describe
+// what will be destroyed; token is the `confirm_token` argument of the call.
+//
+// If the operation may proceed, Check returns (nil, nil). Otherwise the
+// returned result must be sent back to the client instead of performing the
+// operation.
+func (c *Confirmer) Check(ctx context.Context, req *mcp.CallToolRequest, action, token string, preview func() (string, error)) (*mcp.CallToolResult, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	if token != "" {
+		if !c.consume(token, action) {
+			return nil, ErrInvalidConfirmToken
+		}
+		return nil, nil
+	}
+
+	desc, err := preview()
+	if err != nil {
+		return nil, err
+	}
+
+	if supportsElicitation(req) {
+		res, err := req.Session.Elicit(ctx, &mcp.ElicitParams{
+			Message: desc + "\n\nThis cannot be undone. Proceed?",
+			RequestedSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"confirm": {
+						Type:        "boolean",
+						Description: "Confirm the operation",
+					},
+				},
+				Required: []string{"confirm"},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to ask for confirmation: %w", err)
+		}
+		if res.Action == "accept" && res.Content["confirm"] == true {
+			return nil, nil
+		}
+		return textResult("Operation cancelled by user. Nothing was changed."), nil
+	}
+
+	token, err = c.issue(action)
+	if err != nil {
+		return nil, err
+	}
+	return textResult(fmt.Sprintf(
+		"%s\n\n**Confirmation required.** Nothing was changed. Show the above to the user, and if they agree, call this tool again with the same arguments and `confirm_token`: `%s` (valid for %s).",
+		desc, token, ConfirmTokenTTL,
+	)), nil
+}
+
+// issue creates a new token for action.
+func (c *Confirmer) issue(action string) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate confirm token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for k, p := range c.tokens {
+		if !now.Before(p.expires) {
+			delete(c.tokens, k)
+		}
+	}
+	c.tokens[token] = pendingConfirm{action: action, expires: now.Add(ConfirmTokenTTL)}
+	return token, nil
+}
+
+// consume validates and invalidates token.
+func (c *Confirmer) consume(token, action string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p, ok := c.tokens[token]
+	if !ok {
+		return false
+	}
+	delete(c.tokens, token)
+	return p.action == action && c.now().Before(p.expires)
+}
+
+func supportsElicitation(req *mcp.CallToolRequest) bool {
+	if req == nil || req.Session == nil {
+		return false
+	}
+	params := req.Session.InitializeParams()
+	return params != nil && params.Capabilities != nil && params.Capabilities.Elicitation != nil
+}
+
+func textResult(text string) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: text},
+		},
+	}
+}
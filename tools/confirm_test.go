@@ -0,0 +1,104 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package tools
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var confirmTokenRe = regexp.MustCompile("`confirm_token`: `([0-9a-f]+)`")
+
+// requestToken runs Check without a token and extracts the issued token.
+func requestToken(t *testing.T, c *Confirmer, action string) string {
+	t.Helper()
+	res, err := c.Check(context.Background(), &mcp.CallToolRequest{}, action, "", func() (string, error) {
+		return "About to delete label **bug**", nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if res == nil {
+		t.Fatal("Expected confirmation result, got nil")
+	}
+	text := res.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "About to delete label **bug**") {
+		t.Errorf("Expected preview in result, got %q", text)
+	}
+	m := confirmTokenRe.FindStringSubmatch(text)
+	if m == nil {
+		t.Fatalf("Expected confirm token in result, got %q", text)
+	}
+	return m[1]
+}
+
+func TestConfirmer_Check(t *testing.T) {
+	noPreview := func() (string, error) {
+		t.Error("Preview should not be called when a token is given")
+		return "", nil
+	}
+	check := func(c *Confirmer, action, token string) error {
+		res, err := c.Check(context.Background(), &mcp.CallToolRequest{}, action, token, noPreview)
+		if res != nil {
+			t.Errorf("Expected nil result, got %+v", res)
+		}
+		return err
+	}
+
+	t.Run("nil confirmer proceeds", func(t *testing.T) {
+		var c *Confirmer
+		if err := check(c, "delete_label:o/r:1", ""); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("token is single use", func(t *testing.T) {
+		c := NewConfirmer()
+		token := requestToken(t, c, "delete_label:o/r:1")
+		if err := check(c, "delete_label:o/r:1", token); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if err := check(c, "delete_label:o/r:1", token); !errors.Is(err, ErrInvalidConfirmToken) {
+			t.Errorf("Expected ErrInvalidConfirmToken on reuse, got %v", err)
+		}
+	})
+
+	t.Run("token is bound to action", func(t *testing.T) {
+		c := NewConfirmer()
+		token := requestToken(t, c, "delete_label:o/r:1")
+		if err := check(c, "delete_label:o/r:2", token); !errors.Is(err, ErrInvalidConfirmToken) {
+			t.Errorf("Expected ErrInvalidConfirmToken, got %v", err)
+		}
+	})
+
+	t.Run("token expires", func(t *testing.T) {
+		now := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+		c := NewConfirmer()
+		c.now = func() time.Time { return now }
+		token := requestToken(t, c, "delete_label:o/r:1")
+		now = now.Add(ConfirmTokenTTL)
+		if err := check(c, "delete_label:o/r:1", token); !errors.Is(err, ErrInvalidConfirmToken) {
+			t.Errorf("Expected ErrInvalidConfirmToken, got %v", err)
+		}
+	})
+
+	t.Run("preview error", func(t *testing.T) {
+		c := NewConfirmer()
+		_, err := c.Check(context.Background(), &mcp.CallToolRequest{}, "a", "", func() (string, error) {
+			return "", errors.New("not found")
+		})
+		if err == nil {
+			t.Error("Expected preview error to be returned")
+		}
+	})
+}
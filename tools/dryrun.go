@@ -0,0 +1,143 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// APIRequest describes a request a mutating tool would send to Forgejo.
+// It is used to show the request in dry-run mode.
+type APIRequest struct {
+	// Method is the HTTP method.
+	Method string
+	// Endpoint is the API endpoint path (relative to base URL), e.g.
+	// "/api/v1/repos/owner/repo/labels".
+	Endpoint string
+	// Body is the request body, serialized as JSON. nil if no body is sent.
+	Body any
+}
+
+// DryRunSchema returns the schema of the `dry_run` tool argument.
+func DryRunSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "boolean",
+		Description: "Validate the input and preview the change and the API request without sending it (optional, defaults to false)",
+	}
+}
+
+// IsDryRun reports whether a mutating tool should only preview the change,
+// either because the call requested it or the client is in global dry-run
+// mode.
+func (c *Client) IsDryRun(requested bool) bool {
+	return requested || c.DryRun
+}
+
+// DryRunResult renders the result of a mutating tool in dry-run mode: preview
+// describes the change in markdown, followed by the API requests that would be
+// sent.
+func (c *Client) DryRunResult(preview string, reqs ...APIRequest) (*mcp.CallToolResult, error) {
+	var b strings.Builder
+	b.WriteString("**Dry run**: nothing was changed.\n\n")
+	b.WriteString(preview)
+	b.WriteString("\n\n## API Request")
+	if len(reqs) > 1 {
+		b.WriteString("s")
+	}
+	for _, r := range reqs {
+		fmt.Fprintf(&b, "\n\n```http\n%s %s%s\n", r.Method, c.base, r.Endpoint)
+		if r.Body != nil {
+			body, err := json.MarshalIndent(r.Body, "", "  ")
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal request: %w", err)
+			}
+			b.WriteString("Content-Type: application/json\n\n")
+			b.Write(body)
+			b.WriteString("\n")
+		}
+		b.WriteString("```")
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: b.String()},
+		},
+	}, nil
+}
+
+// ResolveLabels fetches the labels of a repository by ID, in the same order as
+// ids. IDs not found in the repository are looked up in the labels of the
+// owner, if it is an organization. It returns an error naming the IDs that do
+// not exist.
+func (c *Client) ResolveLabels(owner, repo string, ids []int64) ([]*forgejo.Label, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	all, _, err := c.ListRepoLabels(owner, repo, forgejo.ListLabelsOptions{
+		ListOptions: forgejo.ListOptions{Page: -1},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+	byID := make(map[int64]*forgejo.Label, len(all))
+	for _, l := range all {
+		byID[l.ID] = l
+	}
+	for _, id := range ids {
+		if byID[id] != nil {
+			continue
+		}
+		// Owners that are users have no labels
+		org, err := c.MyListOrgLabels(owner)
+		if err == nil {
+			for _, l := range org {
+				byID[l.ID] = l
+			}
+		}
+		break
+	}
+
+	ret := make([]*forgejo.Label, 0, len(ids))
+	var missing []string
+	for _, id := range ids {
+		l, ok := byID[id]
+		if !ok {
+			missing = append(missing, fmt.Sprint(id))
+			continue
+		}
+		ret = append(ret, l)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("labels not found in %s/%s: %s", owner, repo, strings.Join(missing, ", "))
+	}
+	return ret, nil
+}
+
+// ResolveUsers fetches users by username. It returns an error naming the
+// users that do not exist.
+func (c *Client) ResolveUsers(names []string) ([]*forgejo.User, error) {
+	ret := make([]*forgejo.User, 0, len(names))
+	var missing []string
+	for _, name := range names {
+		u, _, err := c.GetUserInfo(name)
+		if err != nil {
+			missing = append(missing, name)
+			continue
+		}
+		ret = append(ret, u)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("users not found: %s", strings.Join(missing, ", "))
+	}
+	return ret, nil
+}
@@ -0,0 +1,108 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package tools
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestClient_IsDryRun(t *testing.T) {
+	c := &Client{}
+	if c.IsDryRun(false) {
+		t.Error("Expected no dry run by default")
+	}
+	if !c.IsDryRun(true) {
+		t.Error("Expected dry run when requested")
+	}
+	c.DryRun = true
+	if !c.IsDryRun(false) {
+		t.Error("Expected global dry run to apply")
+	}
+}
+
+func TestClient_DryRunResult(t *testing.T) {
+	c := &Client{base: "https://git.example.com"}
+	res, err := c.DryRunResult("Would create label", APIRequest{
+		Method:   "POST",
+		Endpoint: "/api/v1/repos/owner/repo/labels",
+		Body:     map[string]string{"name": "bug"},
+	}, APIRequest{
+		Method:   "DELETE",
+		Endpoint: "/api/v1/repos/owner/repo/labels/1",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	text := res.Content[0].(*mcp.TextContent).Text
+	for _, want := range []string{
+		"nothing was changed",
+		"Would create label",
+		"## API Requests",
+		"POST https://git.example.com/api/v1/repos/owner/repo/labels\nContent-Type: application/json\n\n{\n  \"name\": \"bug\"\n}",
+		"DELETE https://git.example.com/api/v1/repos/owner/repo/labels/1\n```",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected result to contain %q, got:\n%s", want, text)
+		}
+	}
+}
+
+func TestClient_ResolveLabels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/repos/owner/repo/labels", "/api/v1/repos/user/repo/labels":
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"id": 1, "name": "bug"},
+				{"id": 2, "name": "feature"},
+			})
+		case "/api/v1/orgs/owner/labels":
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"id": 5, "name": "org-wide"},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-token", forgejo_version_to_test, server.Client())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	labels, err := client.ResolveLabels("owner", "repo", []int64{2, 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(labels) != 2 || labels[0].Name != "feature" || labels[1].Name != "bug" {
+		t.Errorf("Expected [feature bug], got %+v", labels)
+	}
+
+	labels, err = client.ResolveLabels("owner", "repo", []int64{1, 5})
+	if err != nil || len(labels) != 2 || labels[1].Name != "org-wide" {
+		t.Errorf("Expected [bug org-wide], got %+v, %v", labels, err)
+	}
+
+	_, err = client.ResolveLabels("owner", "repo", []int64{1, 3, 4})
+	if err == nil || !strings.Contains(err.Error(), "3, 4") {
+		t.Errorf("Expected error naming missing labels, got %v", err)
+	}
+
+	// the owner is a user, without labels of its own
+	_, err = client.ResolveLabels("user", "repo", []int64{3})
+	if err == nil || !strings.Contains(err.Error(), "3") {
+		t.Errorf("Expected error naming missing labels, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// ContainedPath resolves path, relative to dir unless absolute, and checks it
+// stays inside dir after following symbolic links, so tools can only touch
+// files of the host in a directory the server was configured with. The file
+// itself need not exist, but its parent directory must.
+//
+// An empty dir means the tools may not use host files at all; option names
+// the command line flag enabling them, for the error message.
+func ContainedPath(dir, path, option string) (string, error) {
+	if dir == "" {
+		return "", fmt.Errorf("reading or writing files on the server host is disabled, start the server with %s to allow a directory", option)
+	}
+	if path == "" {
+		return "", errors.New("empty path")
+	}
+
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %w", option, err)
+	}
+	if root, err = filepath.Abs(root); err != nil {
+		return "", fmt.Errorf("invalid %s: %w", option, err)
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+
+	resolved, err := filepath.EvalSymlinks(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		var parent string
+		if parent, err = filepath.EvalSymlinks(filepath.Dir(path)); err == nil {
+			resolved = filepath.Join(parent, filepath.Base(path))
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("invalid path %s: %w", path, err)
+	}
+
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %s is outside of %s %s", path, option, dir)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,55 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestContainedPath(t *testing.T) {
+	dir := t.TempDir()
+	outside := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(dir, "escape")); err != nil {
+		t.Fatal(err)
+	}
+	root, _ := filepath.EvalSymlinks(dir)
+
+	for path, want := range map[string]string{
+		"a.json":                        filepath.Join(root, "a.json"),
+		"sub/b.json":                    filepath.Join(root, "sub", "b.json"),
+		filepath.Join(dir, "sub/../c"):  filepath.Join(root, "c"),
+		filepath.Join(root, "sub", "d"): filepath.Join(root, "sub", "d"),
+		"sub/../sub/../e":               filepath.Join(root, "e"),
+	} {
+		got, err := ContainedPath(dir, path, "--dir")
+		if err != nil || got != want {
+			t.Errorf("ContainedPath(%q) = %q, %v, want %q", path, got, err, want)
+		}
+	}
+
+	for _, path := range []string{
+		"",
+		"../a.json",
+		"/etc/passwd",
+		filepath.Join(outside, "a.json"),
+		"escape/a.json",
+		"missing/a.json",
+	} {
+		if got, err := ContainedPath(dir, path, "--dir"); err == nil {
+			t.Errorf("ContainedPath(%q) = %q, expected error", path, got)
+		}
+	}
+
+	if _, err := ContainedPath("", "a.json", "--dir"); err == nil {
+		t.Error("expected error without a directory")
+	}
+}
@@ -108,6 +108,8 @@ type DeleteIssueAttachmentParams struct {
 	Index int `json:"index"`
 	// AttachmentID is the unique identifier of the attachment to delete.
 	AttachmentID string `json:"attachment_id"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // DeleteIssueAttachmentImpl implements the destructive MCP tool for deleting an issue attachment.
@@ -148,6 +150,7 @@ func (DeleteIssueAttachmentImpl) Definition() *mcp.Tool {
 					Type:        "string",
 					Description: "Attachment ID to delete",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "index", "attachment_id"},
 		},
@@ -167,6 +170,12 @@ func (impl DeleteIssueAttachmentImpl) Handler() mcp.ToolHandlerFor[DeleteIssueAt
 			return nil, nil, fmt.Errorf("invalid attachment ID: %w", err)
 		}
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, attachmentID)
+			return res, nil, err
+		}
+
 		// Delete the attachment using the custom client method
 		err = impl.Client.MyDeleteIssueAttachment(p.Owner, p.Repo, int64(p.Index), attachmentID)
 		if err != nil {
@@ -183,6 +192,21 @@ func (impl DeleteIssueAttachmentImpl) Handler() mcp.ToolHandlerFor[DeleteIssueAt
 	}
 }
 
+// dryRun previews the attachment to be deleted.
+func (impl DeleteIssueAttachmentImpl) dryRun(p DeleteIssueAttachmentParams, attachmentID int64) (*mcp.CallToolResult, error) {
+	attachment, err := findIssueAttachment(impl.Client, p.Owner, p.Repo, p.Index, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachmentWrapper := &types.Attachment{Attachment: attachment}
+	preview := fmt.Sprintf("Would delete attachment from issue #%d in %s/%s:\n\n%s", p.Index, p.Owner, p.Repo, attachmentWrapper.ToMarkdown())
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "DELETE",
+		Endpoint: issueEndpoint(p.Owner, p.Repo, p.Index, fmt.Sprintf("/assets/%d", attachmentID)),
+	})
+}
+
 // EditIssueAttachmentParams defines the parameters for editing an issue attachment.
 // It specifies the attachment to edit and its new name.
 type EditIssueAttachmentParams struct {
@@ -196,6 +220,8 @@ type EditIssueAttachmentParams struct {
 	AttachmentID string `json:"attachment_id"`
 	// Name is the new display name for the attachment.
 	Name string `json:"name"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // EditIssueAttachmentImpl implements the MCP tool for editing an issue attachment.
@@ -240,6 +266,7 @@ func (EditIssueAttachmentImpl) Definition() *mcp.Tool {
 					Type:        "string",
 					Description: "New display name for the attachment",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "index", "attachment_id", "name"},
 		},
@@ -264,6 +291,12 @@ func (impl EditIssueAttachmentImpl) Handler() mcp.ToolHandlerFor[EditIssueAttach
 			Name: p.Name,
 		}
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, attachmentID, options)
+			return res, nil, err
+		}
+
 		// Edit the attachment using the custom client method
 		attachment, err := impl.Client.MyEditIssueAttachment(p.Owner, p.Repo, int64(p.Index), attachmentID, options)
 		if err != nil {
@@ -282,3 +315,18 @@ func (impl EditIssueAttachmentImpl) Handler() mcp.ToolHandlerFor[EditIssueAttach
 		}, nil, nil
 	}
 }
+
+// dryRun previews the renaming of the attachment.
+func (impl EditIssueAttachmentImpl) dryRun(p EditIssueAttachmentParams, attachmentID int64, options tools.MyEditAttachmentOptions) (*mcp.CallToolResult, error) {
+	attachment, err := findIssueAttachment(impl.Client, p.Owner, p.Repo, p.Index, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := fmt.Sprintf("Would rename attachment of issue #%d in %s/%s:\n\n- **Name**: %s → %s", p.Index, p.Owner, p.Repo, attachment.Name, options.Name)
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "PATCH",
+		Endpoint: issueEndpoint(p.Owner, p.Repo, p.Index, fmt.Sprintf("/assets/%d", attachmentID)),
+		Body:     options,
+	})
+}
@@ -0,0 +1,637 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+)
+
+const (
+	// maxBulkIssues caps the number of issues a bulk edit can change.
+	maxBulkIssues = 200
+	// maxBulkConcurrency caps the number of issues edited in parallel.
+	maxBulkConcurrency = 10
+)
+
+// BulkEditIssuesParams defines the parameters for the bulk_edit_issues tool.
+// It selects issues by index or by the filters of list_repo_issues, and
+// specifies the changes to apply to each of them.
+type BulkEditIssuesParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Indexes selects issues by number instead of filters.
+	Indexes []int `json:"indexes,omitempty"`
+	// State filters issues by their state (e.g., 'open', 'closed').
+	State string `json:"state,omitempty"`
+	// Labels is a comma-separated list of label names to filter by.
+	Labels string `json:"labels,omitempty"`
+	// Milestones is a comma-separated list of milestone names to filter by.
+	Milestones string `json:"milestones,omitempty"`
+	// Assignees is a comma-separated list of usernames to filter by assignee.
+	Assignees string `json:"assignees,omitempty"`
+	// Q is a search query string to filter issues by.
+	Q string `json:"q,omitempty"`
+	// Since is a timestamp in RFC 3339 format to select only issues updated after this time.
+	Since *string `json:"since,omitempty"`
+	// Before is a timestamp in RFC 3339 format to select only issues updated before this time.
+	Before *string `json:"before,omitempty"`
+	// MaxIssues is the maximum number of issues the filters may select.
+	MaxIssues int `json:"max_issues,omitempty"`
+	// AddLabels is a slice of label IDs to add.
+	AddLabels []int `json:"add_labels,omitempty"`
+	// RemoveLabels is a slice of label IDs to remove.
+	RemoveLabels []int `json:"remove_labels,omitempty"`
+	// SetMilestone is the ID of the milestone to set, 0 removes the milestone.
+	SetMilestone *int `json:"set_milestone,omitempty"`
+	// AddAssignees is a slice of usernames to assign.
+	AddAssignees []string `json:"add_assignees,omitempty"`
+	// RemoveAssignees is a slice of usernames to unassign.
+	RemoveAssignees []string `json:"remove_assignees,omitempty"`
+	// SetState is the new state, 'open' or 'closed'.
+	SetState string `json:"set_state,omitempty"`
+	// SetDueDate is the new due date.
+	SetDueDate time.Time `json:"set_due_date,omitempty"`
+	// RemoveDueDate removes the due date.
+	RemoveDueDate bool `json:"remove_due_date,omitempty"`
+	// Concurrency is the number of issues edited in parallel.
+	Concurrency int `json:"concurrency,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// BulkEditIssuesImpl implements the MCP tool for applying the same changes to
+// many issues. Issues are edited in parallel and a failure on one issue does
+// not stop the others. This is an idempotent operation that uses the Forgejo
+// SDK.
+type BulkEditIssuesImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `bulk_edit_issues` tool. It requires `owner`,
+// `repo`, a selection by `indexes` or filters, and at least one change. It is
+// marked as idempotent since the changes are absolute.
+func (BulkEditIssuesImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "bulk_edit_issues",
+		Title:       "Bulk Edit Issues",
+		Description: "Apply label, milestone, assignee, state and due date changes to many issues at once. Select issues by index list or by the filters of list_repo_issues. Reports the result of every issue; use dry_run to preview the selection and changes first.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"indexes": {
+					Type: "array",
+					Items: &jsonschema.Schema{
+						Type: "integer",
+					},
+					Description: "Issue index numbers to edit, instead of filters (optional)",
+				},
+				"state": {
+					Type:        "string",
+					Description: "Select issues by state: 'open', 'closed', or 'all' (optional, defaults to 'open')",
+					Enum:        []any{"open", "closed", "all"},
+				},
+				"labels": {
+					Type:        "string",
+					Description: "Select issues by comma-separated label names (optional)",
+				},
+				"milestones": {
+					Type:        "string",
+					Description: "Select issues by comma-separated milestone names or IDs (optional)",
+				},
+				"assignees": {
+					Type:        "string",
+					Description: "Select issues by comma-separated assignee usernames (optional)",
+				},
+				"q": {
+					Type:        "string",
+					Description: "Select issues by search query (optional)",
+				},
+				"since": {
+					Type:        "string",
+					Description: "Select issues updated after this time (RFC 3339 format, optional)",
+					Format:      "date-time",
+				},
+				"before": {
+					Type:        "string",
+					Description: "Select issues updated before this time (RFC 3339 format, optional)",
+					Format:      "date-time",
+				},
+				"max_issues": {
+					Type:        "integer",
+					Description: fmt.Sprintf("Fail if the filters select more issues than this (optional, defaults to 50, max %d)", maxBulkIssues),
+					Minimum:     tools.Float64Ptr(1),
+					Maximum:     tools.Float64Ptr(maxBulkIssues),
+				},
+				"add_labels": {
+					Type: "array",
+					Items: &jsonschema.Schema{
+						Type: "integer",
+					},
+					Description: "Label IDs to add (optional)",
+				},
+				"remove_labels": {
+					Type: "array",
+					Items: &jsonschema.Schema{
+						Type: "integer",
+					},
+					Description: "Label IDs to remove (optional)",
+				},
+				"set_milestone": {
+					Type:        "integer",
+					Description: "Milestone ID to set, 0 removes the milestone (optional)",
+					Minimum:     tools.Float64Ptr(0),
+				},
+				"add_assignees": {
+					Type: "array",
+					Items: &jsonschema.Schema{
+						Type: "string",
+					},
+					Description: "Usernames to assign (optional)",
+				},
+				"remove_assignees": {
+					Type: "array",
+					Items: &jsonschema.Schema{
+						Type: "string",
+					},
+					Description: "Usernames to unassign (optional)",
+				},
+				"set_state": {
+					Type:        "string",
+					Description: "New state (optional)",
+					Enum:        []any{"open", "closed"},
+				},
+				"set_due_date": {
+					Type:        "string",
+					Description: "New due date in ISO 8601 format (e.g., '2024-12-31T23:59:59Z') (optional)",
+					Format:      "date-time",
+				},
+				"remove_due_date": {
+					Type:        "boolean",
+					Description: "Remove the due date (optional)",
+				},
+				"concurrency": {
+					Type:        "integer",
+					Description: fmt.Sprintf("Number of issues edited in parallel (optional, defaults to 4, max %d)", maxBulkConcurrency),
+					Minimum:     tools.Float64Ptr(1),
+					Maximum:     tools.Float64Ptr(maxBulkConcurrency),
+				},
+				"dry_run": tools.DryRunSchema(),
+			},
+			Required: []string{"owner", "repo"},
+		},
+	}
+}
+
+// Handler implements the logic for bulk editing. It selects the issues with
+// the Forgejo SDK's `ListRepoIssues` or `GetIssue` functions, and applies the
+// changes with `EditIssue`, `AddIssueLabels` and `DeleteIssueLabel`.
+func (impl BulkEditIssuesImpl) Handler() mcp.ToolHandlerFor[BulkEditIssuesParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args BulkEditIssuesParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		change, err := resolveBulkChange(impl.Client, p)
+		if err != nil {
+			return nil, nil, err
+		}
+		issues, err := selectBulkIssues(impl.Client, p)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		report := &bulkReport{Owner: p.Owner, Repo: p.Repo}
+		for _, issue := range issues {
+			report.Items = append(report.Items, &bulkItem{Issue: issue, Plan: change.plan(issue)})
+		}
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			return impl.dryRun(p, report), nil, nil
+		}
+
+		concurrency := p.Concurrency
+		if concurrency <= 0 {
+			concurrency = 4
+		}
+		concurrency = min(concurrency, maxBulkConcurrency)
+
+		applyBulk(ctx, impl.Client, p.Owner, p.Repo, report.Items, concurrency)
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: report.ToMarkdown(),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// dryRun previews the selected issues and their changes.
+func (impl BulkEditIssuesImpl) dryRun(p BulkEditIssuesParams, report *bulkReport) *mcp.CallToolResult {
+	report.DryRun = true
+	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s/issues/{index}", p.Owner, p.Repo)
+
+	var reqs []tools.APIRequest
+	var edit, add, remove bool
+	for _, item := range report.Items {
+		edit = edit || item.Plan.Edit != nil
+		add = add || len(item.Plan.AddLabels) > 0
+		remove = remove || len(item.Plan.RemoveLabels) > 0
+	}
+	if edit {
+		reqs = append(reqs, tools.APIRequest{Method: "PATCH", Endpoint: endpoint})
+	}
+	if add {
+		reqs = append(reqs, tools.APIRequest{Method: "POST", Endpoint: endpoint + "/labels"})
+	}
+	if remove {
+		reqs = append(reqs, tools.APIRequest{Method: "DELETE", Endpoint: endpoint + "/labels/{id}"})
+	}
+
+	// DryRunResult only fails to marshal request bodies, which are not set
+	res, _ := impl.Client.DryRunResult(report.ToMarkdown(), reqs...)
+	return res
+}
+
+// bulkChange is a validated set of changes to apply to every selected issue.
+type bulkChange struct {
+	AddLabels       []*forgejo.Label
+	RemoveLabels    []*forgejo.Label
+	Milestone       *forgejo.Milestone
+	ClearMilestone  bool
+	AddAssignees    []string
+	RemoveAssignees []string
+	State           forgejo.StateType
+	Deadline        *time.Time
+	RemoveDeadline  bool
+}
+
+// resolveBulkChange validates the requested changes and resolves the
+// referenced labels, milestone and users.
+func resolveBulkChange(cl *tools.Client, p BulkEditIssuesParams) (*bulkChange, error) {
+	c := &bulkChange{
+		AddAssignees:    p.AddAssignees,
+		RemoveAssignees: p.RemoveAssignees,
+		State:           forgejo.StateType(p.SetState),
+		RemoveDeadline:  p.RemoveDueDate,
+	}
+	if c.State != "" && c.State != forgejo.StateOpen && c.State != forgejo.StateClosed {
+		return nil, fmt.Errorf("invalid set_state %q, must be 'open' or 'closed'", p.SetState)
+	}
+	if !p.SetDueDate.IsZero() {
+		if p.RemoveDueDate {
+			return nil, fmt.Errorf("set_due_date and remove_due_date cannot be used together")
+		}
+		c.Deadline = &p.SetDueDate
+	}
+
+	var err error
+	if c.AddLabels, err = cl.ResolveLabels(p.Owner, p.Repo, toInt64s(p.AddLabels)); err != nil {
+		return nil, err
+	}
+	if c.RemoveLabels, err = cl.ResolveLabels(p.Owner, p.Repo, toInt64s(p.RemoveLabels)); err != nil {
+		return nil, err
+	}
+	if _, err = cl.ResolveUsers(p.AddAssignees); err != nil {
+		return nil, err
+	}
+	if p.SetMilestone != nil {
+		if *p.SetMilestone == 0 {
+			c.ClearMilestone = true
+		} else {
+			c.Milestone, _, err = cl.GetMilestone(p.Owner, p.Repo, int64(*p.SetMilestone))
+			if err != nil {
+				return nil, fmt.Errorf("milestone %d not found in %s/%s: %w", *p.SetMilestone, p.Owner, p.Repo, err)
+			}
+		}
+	}
+
+	if len(c.AddLabels) == 0 && len(c.RemoveLabels) == 0 && p.SetMilestone == nil &&
+		len(c.AddAssignees) == 0 && len(c.RemoveAssignees) == 0 && c.State == "" &&
+		c.Deadline == nil && !c.RemoveDeadline {
+		return nil, fmt.Errorf("no changes given")
+	}
+	return c, nil
+}
+
+// selectBulkIssues fetches the issues listed in p.Indexes, or the issues
+// matching the filters in p. Filters may select at most p.MaxIssues issues.
+// Pull requests are rejected in indexes and skipped by filters.
+func selectBulkIssues(cl *tools.Client, p BulkEditIssuesParams) ([]*forgejo.Issue, error) {
+	hasFilter := p.Labels != "" || p.Milestones != "" || p.Assignees != "" || p.Q != "" ||
+		p.Since != nil || p.Before != nil
+	if len(p.Indexes) > 0 {
+		if hasFilter || p.State != "" {
+			return nil, fmt.Errorf("use either indexes or filters, not both")
+		}
+		if len(p.Indexes) > maxBulkIssues {
+			return nil, fmt.Errorf("too many indexes, at most %d issues can be edited at once", maxBulkIssues)
+		}
+		issues := make([]*forgejo.Issue, 0, len(p.Indexes))
+		for _, index := range p.Indexes {
+			issue, _, err := cl.GetIssue(p.Owner, p.Repo, int64(index))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get issue #%d: %w", index, err)
+			}
+			if issue.PullRequest != nil {
+				return nil, fmt.Errorf("#%d is a pull request, only issues can be bulk edited", index)
+			}
+			issues = append(issues, issue)
+		}
+		return issues, nil
+	}
+	if !hasFilter {
+		return nil, fmt.Errorf("select issues by indexes or at least one of labels, milestones, assignees, q, since and before")
+	}
+
+	maxIssues := p.MaxIssues
+	if maxIssues <= 0 {
+		maxIssues = 50
+	}
+	maxIssues = min(maxIssues, maxBulkIssues)
+
+	since, before, err := parseTimeWindow(p.Since, p.Before)
+	if err != nil {
+		return nil, err
+	}
+	opt := forgejo.ListIssueOption{
+		State:      forgejo.StateType(p.State),
+		Type:       forgejo.IssueTypeIssue,
+		KeyWord:    p.Q,
+		AssignedBy: p.Assignees,
+		Since:      since,
+		Before:     before,
+	}
+	if p.Labels != "" {
+		opt.Labels = strings.Split(p.Labels, ",")
+	}
+	if p.Milestones != "" {
+		opt.Milestones = strings.Split(p.Milestones, ",")
+	}
+
+	const pageSize = 50
+	var issues []*forgejo.Issue
+	for page := 1; len(issues) <= maxIssues; page++ {
+		opt.ListOptions = forgejo.ListOptions{Page: page, PageSize: pageSize}
+		list, _, err := cl.ListRepoIssues(p.Owner, p.Repo, opt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list issues: %w", err)
+		}
+		for _, issue := range list {
+			if issue.PullRequest == nil {
+				issues = append(issues, issue)
+			}
+		}
+		if len(list) < pageSize {
+			break
+		}
+	}
+	if len(issues) > maxIssues {
+		return nil, fmt.Errorf("the filters select more than %d issues, narrow them or raise max_issues", maxIssues)
+	}
+	return issues, nil
+}
+
+// bulkPlan is the set of requests needed to apply a bulkChange to an issue.
+type bulkPlan struct {
+	// Edit is nil if the issue itself is not changed.
+	Edit         *forgejo.EditIssueOption
+	AddLabels    []int64
+	RemoveLabels []int64
+	// Changes describes each change, e.g. "+label bug".
+	Changes []string
+}
+
+// plan computes the requests to apply c to issue, skipping changes that are
+// already in effect.
+func (c *bulkChange) plan(issue *forgejo.Issue) *bulkPlan {
+	ret := &bulkPlan{}
+	edit := forgejo.EditIssueOption{}
+	changed := false
+
+	has := map[int64]bool{}
+	for _, l := range issue.Labels {
+		has[l.ID] = true
+	}
+	for _, l := range c.AddLabels {
+		if !has[l.ID] {
+			ret.AddLabels = append(ret.AddLabels, l.ID)
+			ret.Changes = append(ret.Changes, "+label "+l.Name)
+		}
+	}
+	for _, l := range c.RemoveLabels {
+		if has[l.ID] {
+			ret.RemoveLabels = append(ret.RemoveLabels, l.ID)
+			ret.Changes = append(ret.Changes, "-label "+l.Name)
+		}
+	}
+
+	var current int64
+	if issue.Milestone != nil {
+		current = issue.Milestone.ID
+	}
+	switch {
+	case c.Milestone != nil && c.Milestone.ID != current:
+		edit.Milestone = &c.Milestone.ID
+		ret.Changes = append(ret.Changes, "milestone "+c.Milestone.Title)
+		changed = true
+	case c.ClearMilestone && current != 0:
+		var none int64
+		edit.Milestone = &none
+		ret.Changes = append(ret.Changes, "-milestone "+issue.Milestone.Title)
+		changed = true
+	}
+
+	assignees := make([]string, 0, len(issue.Assignees))
+	for _, a := range issue.Assignees {
+		assignees = append(assignees, a.UserName)
+	}
+	newAssignees := slices.DeleteFunc(slices.Clone(assignees), func(a string) bool {
+		return slices.Contains(c.RemoveAssignees, a)
+	})
+	for _, a := range c.RemoveAssignees {
+		if slices.Contains(assignees, a) {
+			ret.Changes = append(ret.Changes, "-assignee "+a)
+		}
+	}
+	for _, a := range c.AddAssignees {
+		if !slices.Contains(newAssignees, a) {
+			newAssignees = append(newAssignees, a)
+			ret.Changes = append(ret.Changes, "+assignee "+a)
+		}
+	}
+	if !slices.Equal(assignees, newAssignees) {
+		// an empty list, not nil, unassigns everyone
+		edit.Assignees = append([]string{}, newAssignees...)
+		changed = true
+	}
+
+	if c.State != "" && c.State != issue.State {
+		edit.State = &c.State
+		ret.Changes = append(ret.Changes, "state "+string(c.State))
+		changed = true
+	}
+
+	switch {
+	case c.Deadline != nil && (issue.Deadline == nil || !issue.Deadline.Equal(*c.Deadline)):
+		edit.Deadline = c.Deadline
+		ret.Changes = append(ret.Changes, "due "+c.Deadline.Format("2006-01-02"))
+		changed = true
+	case c.RemoveDeadline && issue.Deadline != nil:
+		edit.RemoveDeadline = tools.BoolPtr(true)
+		ret.Changes = append(ret.Changes, "-due date")
+		changed = true
+	}
+
+	if changed {
+		ret.Edit = &edit
+	}
+	return ret
+}
+
+// bulkItem is the plan and result of editing one issue.
+type bulkItem struct {
+	Issue *forgejo.Issue
+	Plan  *bulkPlan
+	// Err is the error of the first failed request.
+	Err error
+}
+
+// applyBulk applies the plans of items with at most concurrency issues in
+// parallel, recording errors in the items. Issues not started when ctx is
+// done fail with the error of ctx.
+func applyBulk(ctx context.Context, cl *tools.Client, owner, repo string, items []*bulkItem, concurrency int) {
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, item := range items {
+		if len(item.Plan.Changes) == 0 {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			item.Err = ctx.Err()
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			item.Err = applyPlan(cl, owner, repo, item.Issue.Index, item.Plan)
+		}()
+	}
+	wg.Wait()
+}
+
+// applyPlan sends the requests of plan for issue index, stopping at the
+// first failure.
+func applyPlan(cl *tools.Client, owner, repo string, index int64, plan *bulkPlan) error {
+	if plan.Edit != nil {
+		if _, _, err := cl.EditIssue(owner, repo, index, *plan.Edit); err != nil {
+			return fmt.Errorf("failed to edit issue: %w", err)
+		}
+	}
+	if len(plan.AddLabels) > 0 {
+		_, _, err := cl.AddIssueLabels(owner, repo, index, forgejo.IssueLabelsOption{Labels: plan.AddLabels})
+		if err != nil {
+			return fmt.Errorf("failed to add labels: %w", err)
+		}
+	}
+	for _, id := range plan.RemoveLabels {
+		if _, err := cl.DeleteIssueLabel(owner, repo, index, id); err != nil {
+			return fmt.Errorf("failed to remove label %d: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// bulkReport is the per-issue result of a bulk edit.
+type bulkReport struct {
+	Owner, Repo string
+	DryRun      bool
+	Items       []*bulkItem
+}
+
+// ToMarkdown renders a summary line and a table with the result and changes
+// of every issue.
+// Example:
+// Bulk edit of owner/repo: 2 issues, 1 updated, 0 failed, 1 unchanged
+//
+// | Issue | Title | Result | Changes |
+// |-------|-------|--------|---------|
+// | #12 | Fix login bug | updated | +label bug, milestone v1.0 |
+// | #13 | Add search | unchanged | |
+func (r *bulkReport) ToMarkdown() string {
+	if len(r.Items) == 0 {
+		return "*No issues selected*"
+	}
+
+	updated, failed, unchanged := 0, 0, 0
+	var rows strings.Builder
+	for _, item := range r.Items {
+		result := "updated"
+		switch {
+		case len(item.Plan.Changes) == 0:
+			result = "unchanged"
+			unchanged++
+		case item.Err != nil:
+			result = "failed: " + item.Err.Error()
+			failed++
+		case r.DryRun:
+			result = "would update"
+			updated++
+		default:
+			updated++
+		}
+		fmt.Fprintf(&rows, "| #%d | %s | %s | %s |\n", item.Issue.Index,
+			escapeCell(item.Issue.Title), escapeCell(result), strings.Join(item.Plan.Changes, ", "))
+	}
+
+	var b strings.Builder
+	verb := "updated"
+	if r.DryRun {
+		verb = "to update"
+	}
+	fmt.Fprintf(&b, "Bulk edit of %s/%s: %d issues, %d %s, %d failed, %d unchanged\n\n",
+		r.Owner, r.Repo, len(r.Items), updated, verb, failed, unchanged)
+	b.WriteString("| Issue | Title | Result | Changes |\n")
+	b.WriteString("|-------|-------|--------|---------|\n")
+	b.WriteString(rows.String())
+	return b.String()
+}
+
+// escapeCell makes s safe to use in a markdown table cell.
+func escapeCell(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "|", `\|`), "\n", " ")
+}
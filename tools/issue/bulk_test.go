@@ -0,0 +1,187 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+)
+
+func bulkIssue() *forgejo.Issue {
+	due := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	return &forgejo.Issue{
+		Index:     12,
+		Title:     "Fix login bug",
+		State:     forgejo.StateOpen,
+		Labels:    []*forgejo.Label{{ID: 1, Name: "bug"}},
+		Milestone: &forgejo.Milestone{ID: 3, Title: "v1.0"},
+		Assignees: []*forgejo.User{{UserName: "alice"}},
+		Deadline:  &due,
+	}
+}
+
+func TestBulkPlan(t *testing.T) {
+	due := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+	c := &bulkChange{
+		AddLabels:       []*forgejo.Label{{ID: 1, Name: "bug"}, {ID: 2, Name: "urgent"}},
+		RemoveLabels:    []*forgejo.Label{{ID: 1, Name: "bug"}, {ID: 5, Name: "wontfix"}},
+		Milestone:       &forgejo.Milestone{ID: 4, Title: "v2.0"},
+		AddAssignees:    []string{"bob"},
+		RemoveAssignees: []string{"alice"},
+		State:           forgejo.StateClosed,
+		Deadline:        &due,
+	}
+
+	plan := c.plan(bulkIssue())
+	if !slices.Equal(plan.AddLabels, []int64{2}) {
+		t.Errorf("AddLabels = %v, want [2]", plan.AddLabels)
+	}
+	if !slices.Equal(plan.RemoveLabels, []int64{1}) {
+		t.Errorf("RemoveLabels = %v, want [1]", plan.RemoveLabels)
+	}
+	if plan.Edit == nil {
+		t.Fatal("Edit is nil, want changes")
+	}
+	if plan.Edit.Milestone == nil || *plan.Edit.Milestone != 4 {
+		t.Errorf("Milestone = %v, want 4", plan.Edit.Milestone)
+	}
+	if !slices.Equal(plan.Edit.Assignees, []string{"bob"}) {
+		t.Errorf("Assignees = %v, want [bob]", plan.Edit.Assignees)
+	}
+	if plan.Edit.State == nil || *plan.Edit.State != forgejo.StateClosed {
+		t.Errorf("State = %v, want closed", plan.Edit.State)
+	}
+	if plan.Edit.Deadline == nil || !plan.Edit.Deadline.Equal(due) {
+		t.Errorf("Deadline = %v, want %v", plan.Edit.Deadline, due)
+	}
+
+	want := []string{"+label urgent", "-label bug", "milestone v2.0", "-assignee alice", "+assignee bob", "state closed", "due 2024-02-29"}
+	if !slices.Equal(plan.Changes, want) {
+		t.Errorf("Changes = %q, want %q", plan.Changes, want)
+	}
+}
+
+func TestBulkPlanUnchanged(t *testing.T) {
+	c := &bulkChange{
+		AddLabels:    []*forgejo.Label{{ID: 1, Name: "bug"}},
+		RemoveLabels: []*forgejo.Label{{ID: 5, Name: "wontfix"}},
+		Milestone:    &forgejo.Milestone{ID: 3, Title: "v1.0"},
+		AddAssignees: []string{"alice"},
+		State:        forgejo.StateOpen,
+	}
+
+	plan := c.plan(bulkIssue())
+	if plan.Edit != nil || len(plan.AddLabels) > 0 || len(plan.RemoveLabels) > 0 || len(plan.Changes) > 0 {
+		t.Errorf("plan = %+v, want no changes", plan)
+	}
+}
+
+func TestBulkPlanRemove(t *testing.T) {
+	c := &bulkChange{
+		ClearMilestone:  true,
+		RemoveAssignees: []string{"alice"},
+		RemoveDeadline:  true,
+	}
+
+	plan := c.plan(bulkIssue())
+	if plan.Edit == nil {
+		t.Fatal("Edit is nil, want changes")
+	}
+	if plan.Edit.Milestone == nil || *plan.Edit.Milestone != 0 {
+		t.Errorf("Milestone = %v, want 0", plan.Edit.Milestone)
+	}
+	if plan.Edit.Assignees == nil || len(plan.Edit.Assignees) != 0 {
+		t.Errorf("Assignees = %#v, want empty non-nil slice", plan.Edit.Assignees)
+	}
+	if plan.Edit.RemoveDeadline == nil || !*plan.Edit.RemoveDeadline {
+		t.Error("RemoveDeadline not set")
+	}
+}
+
+func TestBulkReport(t *testing.T) {
+	failed := bulkIssue()
+	failed.Index, failed.Title = 13, "Add | search"
+	unchanged := bulkIssue()
+	unchanged.Index = 14
+
+	report := &bulkReport{
+		Owner: "owner",
+		Repo:  "repo",
+		Items: []*bulkItem{
+			{Issue: bulkIssue(), Plan: &bulkPlan{Changes: []string{"+label urgent", "state closed"}}},
+			{Issue: failed, Plan: &bulkPlan{Changes: []string{"+label urgent"}}, Err: errors.New("403 Forbidden")},
+			{Issue: unchanged, Plan: &bulkPlan{}},
+		},
+	}
+
+	output := report.ToMarkdown()
+	for _, want := range []string{
+		"Bulk edit of owner/repo: 3 issues, 1 updated, 1 failed, 1 unchanged",
+		"| Issue | Title | Result | Changes |",
+		"| #12 | Fix login bug | updated | +label urgent, state closed |",
+		`| #13 | Add \| search | failed: 403 Forbidden | +label urgent |`,
+		"| #14 | Fix login bug | unchanged |  |",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("report should contain %q, got:\n%s", want, output)
+		}
+	}
+
+	report.DryRun = true
+	report.Items[1].Err = nil
+	output = report.ToMarkdown()
+	for _, want := range []string{
+		"3 issues, 2 to update, 0 failed, 1 unchanged",
+		"| #12 | Fix login bug | would update |",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("dry-run report should contain %q, got:\n%s", want, output)
+		}
+	}
+
+	empty := (&bulkReport{Owner: "owner", Repo: "repo"}).ToMarkdown()
+	if !strings.Contains(empty, "No issues selected") {
+		t.Errorf("empty report = %q", empty)
+	}
+}
+
+func TestSelectBulkIssuesRejectsPullRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/repos/owner/repo/issues/1":
+			json.NewEncoder(w).Encode(map[string]any{"number": 1, "title": "issue"})
+		case "/api/v1/repos/owner/repo/issues/2":
+			json.NewEncoder(w).Encode(map[string]any{"number": 2, "title": "pr", "pull_request": map[string]any{"merged": false}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	cl, err := tools.NewClient(server.URL, "token", "11.0.1+gitea-1.22.0", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := BulkEditIssuesParams{Owner: "owner", Repo: "repo", Indexes: []int{1}}
+	if issues, err := selectBulkIssues(cl, p); err != nil || len(issues) != 1 {
+		t.Errorf("selectBulkIssues() = %v, %v", issues, err)
+	}
+	p.Indexes = []int{1, 2}
+	if _, err := selectBulkIssues(cl, p); err == nil || !strings.Contains(err.Error(), "#2 is a pull request") {
+		t.Errorf("selectBulkIssues() error = %v", err)
+	}
+}
@@ -157,6 +157,8 @@ type CreateIssueCommentParams struct {
 	Index int `json:"index"`
 	// Body is the markdown content of the comment.
 	Body string `json:"body"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // CreateIssueCommentImpl implements the MCP tool for creating a new comment on an issue.
@@ -197,6 +199,7 @@ func (CreateIssueCommentImpl) Definition() *mcp.Tool {
 					Type:        "string",
 					Description: "Comment body content (markdown supported)",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "index", "body"},
 		},
@@ -213,6 +216,12 @@ func (impl CreateIssueCommentImpl) Handler() mcp.ToolHandlerFor[CreateIssueComme
 			Body: p.Body,
 		}
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, opt)
+			return res, nil, err
+		}
+
 		comment, _, err := impl.Client.CreateIssueComment(p.Owner, p.Repo, int64(p.Index), opt)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to create comment: %w", err)
@@ -229,6 +238,24 @@ func (impl CreateIssueCommentImpl) Handler() mcp.ToolHandlerFor[CreateIssueComme
 	}
 }
 
+// dryRun validates opt and previews the comment to be posted.
+func (impl CreateIssueCommentImpl) dryRun(p CreateIssueCommentParams, opt forgejo.CreateIssueCommentOption) (*mcp.CallToolResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+	issue, _, err := impl.Client.GetIssue(p.Owner, p.Repo, int64(p.Index))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+
+	preview := fmt.Sprintf("Would comment on issue #%d (%s) in %s/%s:\n\n%s", p.Index, issue.Title, p.Owner, p.Repo, opt.Body)
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "POST",
+		Endpoint: issueEndpoint(p.Owner, p.Repo, p.Index, "/comments"),
+		Body:     opt,
+	})
+}
+
 // EditIssueCommentParams defines the parameters for the edit_issue_comment tool.
 // It specifies the comment to edit by its ID and the new content.
 type EditIssueCommentParams struct {
@@ -240,6 +267,8 @@ type EditIssueCommentParams struct {
 	CommentID int `json:"comment_id"`
 	// Body is the new markdown content for the comment.
 	Body string `json:"body"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // EditIssueCommentImpl implements the MCP tool for editing an existing issue comment.
@@ -280,6 +309,7 @@ func (EditIssueCommentImpl) Definition() *mcp.Tool {
 					Type:        "string",
 					Description: "New comment body content (markdown supported)",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "comment_id", "body"},
 		},
@@ -297,6 +327,12 @@ func (impl EditIssueCommentImpl) Handler() mcp.ToolHandlerFor[EditIssueCommentPa
 			Body: p.Body,
 		}
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, opt)
+			return res, nil, err
+		}
+
 		comment, _, err := impl.Client.EditIssueComment(p.Owner, p.Repo, int64(p.CommentID), opt)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to edit comment: %w", err)
@@ -314,6 +350,25 @@ func (impl EditIssueCommentImpl) Handler() mcp.ToolHandlerFor[EditIssueCommentPa
 	}
 }
 
+// dryRun validates opt and previews the comment before and after the edit.
+func (impl EditIssueCommentImpl) dryRun(p EditIssueCommentParams, opt forgejo.EditIssueCommentOption) (*mcp.CallToolResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+	comment, _, err := impl.Client.GetIssueComment(p.Owner, p.Repo, int64(p.CommentID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	commentWrapper := &types.Comment{Comment: comment}
+	preview := fmt.Sprintf("Would edit comment in %s/%s:\n\n%s\n\n**New content:**\n\n%s", p.Owner, p.Repo, commentWrapper.ToMarkdown(), opt.Body)
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "PATCH",
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/issues/comments/%d", p.Owner, p.Repo, p.CommentID),
+		Body:     opt,
+	})
+}
+
 // DeleteIssueCommentParams defines the parameters for the delete_issue_comment tool.
 // It specifies the comment to be deleted by its ID.
 type DeleteIssueCommentParams struct {
@@ -323,6 +378,8 @@ type DeleteIssueCommentParams struct {
 	Repo string `json:"repo"`
 	// CommentID is the unique identifier of the comment to delete.
 	CommentID int `json:"comment_id"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // DeleteIssueCommentImpl implements the destructive MCP tool for deleting an issue comment.
@@ -360,6 +417,7 @@ func (DeleteIssueCommentImpl) Definition() *mcp.Tool {
 					Type:        "integer",
 					Description: "Comment ID to delete",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "comment_id"},
 		},
@@ -373,6 +431,12 @@ func (impl DeleteIssueCommentImpl) Handler() mcp.ToolHandlerFor[DeleteIssueComme
 	return func(ctx context.Context, req *mcp.CallToolRequest, args DeleteIssueCommentParams) (*mcp.CallToolResult, any, error) {
 		p := args
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p)
+			return res, nil, err
+		}
+
 		_, err := impl.Client.DeleteIssueComment(p.Owner, p.Repo, int64(p.CommentID))
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to delete comment: %w", err)
@@ -387,3 +451,18 @@ func (impl DeleteIssueCommentImpl) Handler() mcp.ToolHandlerFor[DeleteIssueComme
 		}, nil, nil
 	}
 }
+
+// dryRun previews the comment to be deleted.
+func (impl DeleteIssueCommentImpl) dryRun(p DeleteIssueCommentParams) (*mcp.CallToolResult, error) {
+	comment, _, err := impl.Client.GetIssueComment(p.Owner, p.Repo, int64(p.CommentID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	commentWrapper := &types.Comment{Comment: comment}
+	preview := fmt.Sprintf("Would delete comment in %s/%s:\n\n%s", p.Owner, p.Repo, commentWrapper.ToMarkdown())
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "DELETE",
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/issues/comments/%d", p.Owner, p.Repo, p.CommentID),
+	})
+}
@@ -301,6 +301,14 @@ type CreateIssueParams struct {
 	Labels []int `json:"labels,omitempty"`
 	// DueDate is the optional due date for the issue.
 	DueDate time.Time `json:"due_date,omitempty"`
+	// Template is the name or file name of an issue template to create the
+	// issue from, see list_issue_templates.
+	Template string `json:"template,omitempty"`
+	// Fields are the values of the fields of an issue form template, keyed
+	// by field ID.
+	Fields map[string]any `json:"fields,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // CreateIssueImpl implements the MCP tool for creating a new issue.
@@ -310,13 +318,13 @@ type CreateIssueImpl struct {
 }
 
 // Definition describes the `create_issue` tool. It requires `owner`, `repo`,
-// a `title`, and a `body`. It is not idempotent, as multiple calls will create
-// multiple identical issues.
+// a `title`, and a `body` unless a `template` is used. It is not idempotent,
+// as multiple calls will create multiple identical issues.
 func (CreateIssueImpl) Definition() *mcp.Tool {
 	return &mcp.Tool{
 		Name:        "create_issue",
 		Title:       "Create Issue",
-		Description: "Create a new issue in a repository. Specify title, body, and optional metadata like labels, assignees, milestone.",
+		Description: "Create a new issue in a repository. Specify title, body, and optional metadata like labels, assignees, milestone. With a template from list_issue_templates, the title gets the template's prefix, its default labels and assignees (read from the template file) are added, and the body is the markdown template (unless body is given) or the issue form filled from fields.",
 		Annotations: &mcp.ToolAnnotations{
 			ReadOnlyHint:    false,
 			DestructiveHint: tools.BoolPtr(false),
@@ -339,7 +347,7 @@ func (CreateIssueImpl) Definition() *mcp.Tool {
 				},
 				"body": {
 					Type:        "string",
-					Description: "Issue body content (markdown supported)",
+					Description: "Issue body content (markdown supported), defaults to the content of a markdown template",
 				},
 				"assignees": {
 					Type: "array",
@@ -364,8 +372,17 @@ func (CreateIssueImpl) Definition() *mcp.Tool {
 					Description: "Issue due date in ISO 8601 format (e.g., '2024-12-31T23:59:59Z') (optional)",
 					Format:      "date-time",
 				},
+				"template": {
+					Type:        "string",
+					Description: "Name or file name of an issue template to use (optional)",
+				},
+				"fields": {
+					Type:        "object",
+					Description: "Values of the issue form fields keyed by field ID: text for inputs and textareas, the selected option(s) for dropdowns, the checked option labels for checkboxes (only for issue form templates)",
+				},
+				"dry_run": tools.DryRunSchema(),
 			},
-			Required: []string{"owner", "repo", "title", "body"},
+			Required: []string{"owner", "repo", "title"},
 		},
 	}
 }
@@ -401,6 +418,24 @@ func (impl CreateIssueImpl) Handler() mcp.ToolHandlerFor[CreateIssueParams, any]
 			opt.Deadline = &p.DueDate
 		}
 
+		// Fill from the issue template if provided
+		var notes []string
+		if p.Template != "" {
+			var err error
+			notes, err = applyTemplate(impl.Client, p, &opt)
+			if err != nil {
+				return nil, nil, err
+			}
+		} else if len(p.Fields) > 0 {
+			return nil, nil, fmt.Errorf("fields can only be used with a template")
+		}
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, opt, notes)
+			return res, nil, err
+		}
+
 		// Call SDK
 		issue, _, err := impl.Client.CreateIssue(p.Owner, p.Repo, opt)
 		if err != nil {
@@ -409,17 +444,51 @@ func (impl CreateIssueImpl) Handler() mcp.ToolHandlerFor[CreateIssueParams, any]
 
 		// Convert to our type and format
 		issueWrapper := &types.Issue{Issue: issue}
+		content := issueWrapper.ToMarkdown()
+		if len(notes) > 0 {
+			content += "\n\n" + strings.Join(notes, "\n")
+		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{
-					Text: issueWrapper.ToMarkdown(),
+					Text: content,
 				},
 			},
 		}, nil, nil
 	}
 }
 
+// dryRun validates opt, resolves the referenced labels, milestone and
+// assignees, and previews the issue to be created with notes about the
+// template.
+func (impl CreateIssueImpl) dryRun(p CreateIssueParams, opt forgejo.CreateIssueOption, notes []string) (*mcp.CallToolResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+	meta, err := resolveIssueMeta(impl.Client, p.Owner, p.Repo, opt.Labels, opt.Milestone, opt.Assignees)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := fmt.Sprintf("Would create issue in %s/%s:\n\n# %s\n%s", p.Owner, p.Repo, opt.Title, meta)
+	if opt.Deadline != nil {
+		preview += "- **Due Date**: " + opt.Deadline.Format("2006-01-02") + "\n"
+	}
+	if opt.Body != "" {
+		preview += "\n" + opt.Body
+	}
+	if len(notes) > 0 {
+		preview += "\n\n" + strings.Join(notes, "\n")
+	}
+
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "POST",
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/issues", p.Owner, p.Repo),
+		Body:     opt,
+	})
+}
+
 // EditIssueParams defines the parameters for the edit_issue tool.
 // It specifies the issue to edit by ID and the fields to update.
 type EditIssueParams struct {
@@ -441,6 +510,8 @@ type EditIssueParams struct {
 	Milestone int `json:"milestone,omitempty"`
 	// DueDate is the new optional due date for the issue.
 	DueDate time.Time `json:"due_date,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // EditIssueImpl implements the MCP tool for editing an existing issue.
@@ -506,6 +577,7 @@ func (EditIssueImpl) Definition() *mcp.Tool {
 					Description: "Issue due date in ISO 8601 format (e.g., '2024-12-31T23:59:59Z') (optional)",
 					Format:      "date-time",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "index"},
 		},
@@ -550,6 +622,12 @@ func (impl EditIssueImpl) Handler() mcp.ToolHandlerFor[EditIssueParams, any] {
 			opt.Deadline = &p.DueDate
 		}
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, opt)
+			return res, nil, err
+		}
+
 		// Call SDK
 		issue, _, err := impl.Client.EditIssue(p.Owner, p.Repo, int64(p.Index), opt)
 		if err != nil {
@@ -568,3 +646,73 @@ func (impl EditIssueImpl) Handler() mcp.ToolHandlerFor[EditIssueParams, any] {
 		}, nil, nil
 	}
 }
+
+// dryRun validates opt, resolves the referenced milestone and assignees, and
+// previews the changes against the current issue.
+func (impl EditIssueImpl) dryRun(p EditIssueParams, opt forgejo.EditIssueOption) (*mcp.CallToolResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+	issue, _, err := impl.Client.GetIssue(p.Owner, p.Repo, int64(p.Index))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+	var milestone int64
+	if opt.Milestone != nil {
+		milestone = *opt.Milestone
+	}
+	if _, err := resolveIssueMeta(impl.Client, p.Owner, p.Repo, nil, milestone, opt.Assignees); err != nil {
+		return nil, err
+	}
+
+	var changes []string
+	change := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, fmt.Sprintf("- **%s**: %s → %s", field, orNone(from), orNone(to)))
+		}
+	}
+	if opt.Title != "" {
+		change("Title", issue.Title, opt.Title)
+	}
+	if opt.State != nil {
+		change("State", string(issue.State), string(*opt.State))
+	}
+	if len(opt.Assignees) > 0 {
+		current := make([]string, len(issue.Assignees))
+		for i, u := range issue.Assignees {
+			current[i] = u.UserName
+		}
+		change("Assignees", strings.Join(current, ", "), strings.Join(opt.Assignees, ", "))
+	}
+	if opt.Milestone != nil {
+		current := ""
+		if issue.Milestone != nil {
+			current = issue.Milestone.Title
+		}
+		m, _, err := impl.Client.GetMilestone(p.Owner, p.Repo, *opt.Milestone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get milestone: %w", err)
+		}
+		change("Milestone", current, m.Title)
+	}
+	if opt.Deadline != nil {
+		current := ""
+		if issue.Deadline != nil {
+			current = issue.Deadline.Format("2006-01-02")
+		}
+		change("Due Date", current, opt.Deadline.Format("2006-01-02"))
+	}
+	if opt.Body != nil && *opt.Body != issue.Body {
+		changes = append(changes, "- **Body** replaced with:\n\n"+*opt.Body)
+	}
+	if len(changes) == 0 {
+		changes = append(changes, "*No changes*")
+	}
+
+	preview := fmt.Sprintf("Would edit issue #%d (%s) in %s/%s:\n\n%s", p.Index, issue.Title, p.Owner, p.Repo, strings.Join(changes, "\n"))
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "PATCH",
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/issues/%d", p.Owner, p.Repo, p.Index),
+		Body:     opt,
+	})
+}
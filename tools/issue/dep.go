@@ -103,6 +103,8 @@ type AddIssueDependencyParams struct {
 	Index int `json:"index"`
 	// DependencyIndex is the issue number of the issue that `Index` will depend on.
 	DependencyIndex int `json:"dependency_index"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // AddIssueDependencyImpl implements the MCP tool for adding a dependency to an issue.
@@ -144,6 +146,7 @@ func (AddIssueDependencyImpl) Definition() *mcp.Tool {
 					Type:        "integer",
 					Description: "Index of the issue this issue depends on",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "index", "dependency_index"},
 		},
@@ -163,6 +166,12 @@ func (impl AddIssueDependencyImpl) Handler() mcp.ToolHandlerFor[AddIssueDependen
 			Index: int64(p.DependencyIndex),
 		}
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, dependency)
+			return res, nil, err
+		}
+
 		_, err := impl.Client.MyAddIssueDependency(p.Owner, p.Repo, int64(p.Index), dependency)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to add dependency: %w", err)
@@ -180,6 +189,19 @@ func (impl AddIssueDependencyImpl) Handler() mcp.ToolHandlerFor[AddIssueDependen
 	}
 }
 
+// dryRun checks that both issues exist and previews the relationship to add.
+func (impl AddIssueDependencyImpl) dryRun(p AddIssueDependencyParams, dependency types.MyIssueMeta) (*mcp.CallToolResult, error) {
+	relation, err := previewDependency(impl.Client, p.Owner, p.Repo, p.DependencyIndex, p.Index)
+	if err != nil {
+		return nil, err
+	}
+	return impl.Client.DryRunResult("Would add relationship: "+relation, tools.APIRequest{
+		Method:   "POST",
+		Endpoint: issueEndpoint(p.Owner, p.Repo, p.Index, "/dependencies"),
+		Body:     dependency,
+	})
+}
+
 // RemoveIssueDependencyParams defines the parameters for the remove_issue_dependency tool.
 // It specifies the two issues for which to remove the dependency relationship.
 type RemoveIssueDependencyParams struct {
@@ -191,6 +213,8 @@ type RemoveIssueDependencyParams struct {
 	Index int `json:"index"`
 	// DependencyIndex is the issue number of the dependency to be removed.
 	DependencyIndex int `json:"dependency_index"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // RemoveIssueDependencyImpl implements the destructive MCP tool for removing an issue dependency.
@@ -232,6 +256,7 @@ func (RemoveIssueDependencyImpl) Definition() *mcp.Tool {
 					Type:        "integer",
 					Description: "Index of the dependency issue to remove",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "index", "dependency_index"},
 		},
@@ -251,6 +276,12 @@ func (impl RemoveIssueDependencyImpl) Handler() mcp.ToolHandlerFor[RemoveIssueDe
 			Index: int64(p.DependencyIndex),
 		}
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, dependency)
+			return res, nil, err
+		}
+
 		_, err := impl.Client.MyRemoveIssueDependency(p.Owner, p.Repo, int64(p.Index), dependency)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to remove dependency: %w", err)
@@ -268,6 +299,19 @@ func (impl RemoveIssueDependencyImpl) Handler() mcp.ToolHandlerFor[RemoveIssueDe
 	}
 }
 
+// dryRun checks that both issues exist and previews the relationship to remove.
+func (impl RemoveIssueDependencyImpl) dryRun(p RemoveIssueDependencyParams, dependency types.MyIssueMeta) (*mcp.CallToolResult, error) {
+	relation, err := previewDependency(impl.Client, p.Owner, p.Repo, p.DependencyIndex, p.Index)
+	if err != nil {
+		return nil, err
+	}
+	return impl.Client.DryRunResult("Would remove relationship: "+relation, tools.APIRequest{
+		Method:   "DELETE",
+		Endpoint: issueEndpoint(p.Owner, p.Repo, p.Index, "/dependencies"),
+		Body:     dependency,
+	})
+}
+
 // ListIssueBlockingParams defines the parameters for the list_issue_blocking tool.
 // It specifies the issue for which to list blocking relationships.
 type ListIssueBlockingParams struct {
@@ -354,6 +398,8 @@ type AddIssueBlockingParams struct {
 	Index int `json:"index"`
 	// BlockedIndex is the issue number of the issue that will be blocked by `Index`.
 	BlockedIndex int `json:"blocked_index"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // AddIssueBlockingImpl implements the MCP tool for adding a blocking relationship to an issue.
@@ -395,6 +441,7 @@ func (AddIssueBlockingImpl) Definition() *mcp.Tool {
 					Type:        "integer",
 					Description: "Index of the issue that will be blocked by this issue",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "index", "blocked_index"},
 		},
@@ -414,6 +461,12 @@ func (impl AddIssueBlockingImpl) Handler() mcp.ToolHandlerFor[AddIssueBlockingPa
 			Index: int64(p.BlockedIndex),
 		}
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, blocked)
+			return res, nil, err
+		}
+
 		_, err := impl.Client.MyAddIssueBlocking(p.Owner, p.Repo, int64(p.Index), blocked)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to add blocking relationship: %w", err)
@@ -431,6 +484,19 @@ func (impl AddIssueBlockingImpl) Handler() mcp.ToolHandlerFor[AddIssueBlockingPa
 	}
 }
 
+// dryRun checks that both issues exist and previews the relationship to add.
+func (impl AddIssueBlockingImpl) dryRun(p AddIssueBlockingParams, blocked types.MyIssueMeta) (*mcp.CallToolResult, error) {
+	relation, err := previewDependency(impl.Client, p.Owner, p.Repo, p.Index, p.BlockedIndex)
+	if err != nil {
+		return nil, err
+	}
+	return impl.Client.DryRunResult("Would add relationship: "+relation, tools.APIRequest{
+		Method:   "POST",
+		Endpoint: issueEndpoint(p.Owner, p.Repo, p.Index, "/blocks"),
+		Body:     blocked,
+	})
+}
+
 // RemoveIssueBlockingParams defines the parameters for the remove_issue_blocking tool.
 // It specifies the two issues for which to remove the blocking relationship.
 type RemoveIssueBlockingParams struct {
@@ -442,6 +508,8 @@ type RemoveIssueBlockingParams struct {
 	Index int `json:"index"`
 	// BlockedIndex is the issue number of the blocked issue to be unblocked.
 	BlockedIndex int `json:"blocked_index"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // RemoveIssueBlockingImpl implements the destructive MCP tool for removing an issue blocking relationship.
@@ -483,6 +551,7 @@ func (RemoveIssueBlockingImpl) Definition() *mcp.Tool {
 					Type:        "integer",
 					Description: "Index of the blocked issue to remove from blocking relationship",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "index", "blocked_index"},
 		},
@@ -502,6 +571,12 @@ func (impl RemoveIssueBlockingImpl) Handler() mcp.ToolHandlerFor[RemoveIssueBloc
 			Index: int64(p.BlockedIndex),
 		}
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, blocked)
+			return res, nil, err
+		}
+
 		_, err := impl.Client.MyRemoveIssueBlocking(p.Owner, p.Repo, int64(p.Index), blocked)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to remove blocking relationship: %w", err)
@@ -518,3 +593,16 @@ func (impl RemoveIssueBlockingImpl) Handler() mcp.ToolHandlerFor[RemoveIssueBloc
 		}, nil, nil
 	}
 }
+
+// dryRun checks that both issues exist and previews the relationship to remove.
+func (impl RemoveIssueBlockingImpl) dryRun(p RemoveIssueBlockingParams, blocked types.MyIssueMeta) (*mcp.CallToolResult, error) {
+	relation, err := previewDependency(impl.Client, p.Owner, p.Repo, p.Index, p.BlockedIndex)
+	if err != nil {
+		return nil, err
+	}
+	return impl.Client.DryRunResult("Would remove relationship: "+relation, tools.APIRequest{
+		Method:   "DELETE",
+		Endpoint: issueEndpoint(p.Owner, p.Repo, p.Index, "/blocks"),
+		Body:     blocked,
+	})
+}
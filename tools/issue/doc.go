@@ -1,4 +1,7 @@
 // Package issue provides MCP tools for managing Forgejo issues and their comments.
 //
-// It includes tools for listing, retrieving, creating, editing, and deleting issues and comments.
+// It includes tools for listing, searching, retrieving, creating, editing, and deleting issues and comments,
+// for creating issues from issue templates, for editing many issues at once, for moving issues to another
+// repository, for exporting and importing issues, for viewing the timeline of an issue, for rendering
+// dependency graphs, for locking, pinning and subscribing to issues, and for tracking time spent on issues.
 package issue
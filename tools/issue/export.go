@@ -0,0 +1,425 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// ExportIssuesParams defines the parameters for the export_issues tool and
+// the export command. It specifies the repository, the issues to export and
+// the output.
+type ExportIssuesParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Format is the output format, see types.IssueExport.Write.
+	Format string `json:"format,omitempty"`
+	// State filters issues by their state, defaults to 'all'.
+	State string `json:"state,omitempty"`
+	// Labels is a comma-separated list of label names to filter by.
+	Labels string `json:"labels,omitempty"`
+	// Milestones is a comma-separated list of milestone names to filter by.
+	Milestones string `json:"milestones,omitempty"`
+	// Since is a timestamp in RFC 3339 format to select only issues updated after this time.
+	Since *string `json:"since,omitempty"`
+	// Before is a timestamp in RFC 3339 format to select only issues updated before this time.
+	Before *string `json:"before,omitempty"`
+	// SkipComments leaves out the comments of issues.
+	SkipComments bool `json:"skip_comments,omitempty"`
+	// SkipDependencies leaves out the dependency and blocking edges.
+	SkipDependencies bool `json:"skip_dependencies,omitempty"`
+	// Output is the path of a file on the host running the server, inside the
+	// export directory for the tool. The export is returned as an embedded
+	// resource if empty.
+	Output string `json:"output,omitempty"`
+	// Overwrite allows replacing an existing output file.
+	Overwrite bool `json:"overwrite,omitempty"`
+}
+
+// ExportIssuesImpl implements the MCP tool for exporting the issues of a
+// repository with their comments, labels, milestones and dependencies. It
+// does not change anything in Forgejo, but may write a file in Dir on the
+// host running the server.
+type ExportIssuesImpl struct {
+	Client *tools.Client
+	// Dir is the directory output files may be written to. Empty disables
+	// `output`, the export is always returned as an embedded resource.
+	Dir string
+}
+
+// Definition describes the `export_issues` tool. It requires `owner` and
+// `repo`. It is idempotent, as it only reads from Forgejo.
+func (impl ExportIssuesImpl) Definition() *mcp.Tool {
+	def := &mcp.Tool{
+		Name:        "export_issues",
+		Title:       "Export Issues",
+		Description: "Export the issues of a repository with their comments, labels, milestone and dependency/blocking edges as JSON, JSONL (one issue per line) or CSV (comments only counted). Returns the export as an embedded resource, or writes it to a file in the export directory of this server if one is configured. The JSON format can be read by import_issues.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"format": {
+					Type:        "string",
+					Description: "Output format (optional, defaults to 'json')",
+					Enum:        []any{types.ExportJSON, types.ExportJSONL, types.ExportCSV},
+				},
+				"state": {
+					Type:        "string",
+					Description: "Export only issues in this state (optional, defaults to 'all')",
+					Enum:        []any{"open", "closed", "all"},
+				},
+				"labels": {
+					Type:        "string",
+					Description: "Export only issues with these comma-separated label names (optional)",
+				},
+				"milestones": {
+					Type:        "string",
+					Description: "Export only issues in these comma-separated milestone names or IDs (optional)",
+				},
+				"since": {
+					Type:        "string",
+					Description: "Export only issues updated after this time (RFC 3339 format, optional)",
+					Format:      "date-time",
+				},
+				"before": {
+					Type:        "string",
+					Description: "Export only issues updated before this time (RFC 3339 format, optional)",
+					Format:      "date-time",
+				},
+				"skip_comments": {
+					Type:        "boolean",
+					Description: "Leave out comments, saving one request per issue (optional)",
+				},
+				"skip_dependencies": {
+					Type:        "boolean",
+					Description: "Leave out dependency and blocking edges, saving two requests per issue (optional)",
+				},
+			},
+			Required: []string{"owner", "repo"},
+		},
+	}
+	if impl.Dir != "" {
+		def.InputSchema.Properties["output"] = &jsonschema.Schema{
+			Type:        "string",
+			Description: fmt.Sprintf("Path of the file to write on the server host, inside %s (optional, returns an embedded resource if omitted)", impl.Dir),
+		}
+		def.InputSchema.Properties["overwrite"] = &jsonschema.Schema{
+			Type:        "boolean",
+			Description: "Replace the output file if it exists (optional)",
+		}
+	}
+	return def
+}
+
+// Handler implements the logic for exporting issues. It fetches the data with
+// ExportIssues and writes it to the output file, which must be inside Dir, or
+// returns it as an embedded resource.
+func (impl ExportIssuesImpl) Handler() mcp.ToolHandlerFor[ExportIssuesParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args ExportIssuesParams) (*mcp.CallToolResult, any, error) {
+		p := args
+		if p.Format == "" {
+			p.Format = types.ExportJSON
+		}
+		mimeType, err := exportMIMEType(p.Format)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if p.Output != "" {
+			p.Output, err = tools.ContainedPath(impl.Dir, p.Output, "--export-dir")
+			if err != nil {
+				return nil, nil, err
+			}
+			// Fail early instead of after fetching everything
+			if err := checkOutput(p.Output, p.Overwrite); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		export, err := ExportIssues(ctx, impl.Client, p)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if p.Output != "" {
+			if err := writeExport(export, p.Output, p.Format, p.Overwrite); err != nil {
+				return nil, nil, err
+			}
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{
+						Text: fmt.Sprintf("Exported %s to %s.", export.Summary(), p.Output),
+					},
+				},
+			}, nil, nil
+		}
+
+		var buf bytes.Buffer
+		if err := export.Write(&buf, p.Format); err != nil {
+			return nil, nil, fmt.Errorf("failed to encode export: %w", err)
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: "Exported " + export.Summary() + ".",
+				},
+				&mcp.EmbeddedResource{
+					Resource: &mcp.ResourceContents{
+						URI:      fmt.Sprintf("forgejo://%s/%s/issues.%s", p.Owner, p.Repo, p.Format),
+						MIMEType: mimeType,
+						Text:     buf.String(),
+					},
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// checkOutput returns an error if the output file exists and may not be
+// replaced.
+func checkOutput(path string, overwrite bool) error {
+	if overwrite {
+		return nil
+	}
+	if _, err := os.Lstat(path); err == nil {
+		return fmt.Errorf("%s exists, set overwrite to replace it", path)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to check output file: %w", err)
+	}
+	return nil
+}
+
+// writeExport writes export to a temporary file next to path and renames it
+// into place, so a failed write never leaves a truncated or partial file.
+func writeExport(export *types.IssueExport, path, format string, overwrite bool) error {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	if err := export.Write(file, format); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := file.Chmod(0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	// The file may have been created while exporting
+	if err := checkOutput(path, overwrite); err != nil {
+		return err
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// exportMIMEType returns the MIME type of an export format.
+func exportMIMEType(format string) (string, error) {
+	switch format {
+	case types.ExportJSON:
+		return "application/json", nil
+	case types.ExportJSONL:
+		return "application/jsonl", nil
+	case types.ExportCSV:
+		return "text/csv", nil
+	}
+	return "", fmt.Errorf("unsupported export format %q, use json, jsonl or csv", format)
+}
+
+// ExportIssues fetches the labels, milestones and the issues selected by p
+// with their comments and dependency edges. Pull requests are skipped. It is
+// shared by the export_issues tool and the export command.
+func ExportIssues(ctx context.Context, cl *tools.Client, p ExportIssuesParams) (*types.IssueExport, error) {
+	since, before, err := parseTimeWindow(p.Since, p.Before)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &types.IssueExport{
+		Owner:      p.Owner,
+		Repo:       p.Repo,
+		ExportedAt: time.Now().UTC(),
+	}
+
+	labels, _, err := cl.ListRepoLabels(p.Owner, p.Repo, forgejo.ListLabelsOptions{
+		ListOptions: forgejo.ListOptions{Page: -1},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+	for _, l := range labels {
+		export.Labels = append(export.Labels, &types.ExportedLabel{Name: l.Name, Color: l.Color, Description: l.Description})
+	}
+
+	const pageSize = 50
+	for page := 1; ; page++ {
+		milestones, _, err := cl.ListRepoMilestones(p.Owner, p.Repo, forgejo.ListMilestoneOption{
+			ListOptions: forgejo.ListOptions{Page: page, PageSize: pageSize},
+			State:       forgejo.StateAll,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list milestones: %w", err)
+		}
+		for _, m := range milestones {
+			export.Milestones = append(export.Milestones, &types.ExportedMilestone{
+				Title:       m.Title,
+				Description: m.Description,
+				State:       string(m.State),
+				DueDate:     m.Deadline,
+			})
+		}
+		if len(milestones) < pageSize {
+			break
+		}
+	}
+
+	opt := forgejo.ListIssueOption{
+		State:  forgejo.StateAll,
+		Type:   forgejo.IssueTypeIssue,
+		Since:  since,
+		Before: before,
+	}
+	if p.State != "" {
+		opt.State = forgejo.StateType(p.State)
+	}
+	if p.Labels != "" {
+		opt.Labels = strings.Split(p.Labels, ",")
+	}
+	if p.Milestones != "" {
+		opt.Milestones = strings.Split(p.Milestones, ",")
+	}
+	for page := 1; ; page++ {
+		opt.ListOptions = forgejo.ListOptions{Page: page, PageSize: pageSize}
+		issues, _, err := cl.ListRepoIssues(p.Owner, p.Repo, opt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list issues: %w", err)
+		}
+		for _, issue := range issues {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if issue.PullRequest != nil {
+				continue
+			}
+			exported, err := exportIssue(cl, p, issue)
+			if err != nil {
+				return nil, err
+			}
+			export.Issues = append(export.Issues, exported)
+		}
+		if len(issues) < pageSize {
+			break
+		}
+	}
+
+	return export, nil
+}
+
+// exportIssue converts issue and fetches its comments and dependency edges
+// unless p skips them.
+func exportIssue(cl *tools.Client, p ExportIssuesParams, issue *forgejo.Issue) (*types.ExportedIssue, error) {
+	ret := &types.ExportedIssue{
+		Index:     issue.Index,
+		Title:     issue.Title,
+		Body:      issue.Body,
+		State:     string(issue.State),
+		Author:    authorName(issue.Poster, issue.OriginalAuthor),
+		CreatedAt: issue.Created,
+		UpdatedAt: issue.Updated,
+		ClosedAt:  issue.Closed,
+		DueDate:   issue.Deadline,
+		Labels:    []string{},
+		Assignees: []string{},
+	}
+	for _, l := range issue.Labels {
+		ret.Labels = append(ret.Labels, l.Name)
+	}
+	if issue.Milestone != nil {
+		ret.Milestone = issue.Milestone.Title
+	}
+	for _, a := range issue.Assignees {
+		ret.Assignees = append(ret.Assignees, a.UserName)
+	}
+
+	if !p.SkipComments && issue.Comments > 0 {
+		// The endpoint returns all comments, it has no paging
+		comments, _, err := cl.ListIssueComments(p.Owner, p.Repo, issue.Index, forgejo.ListIssueCommentOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list comments of #%d: %w", issue.Index, err)
+		}
+		for _, c := range comments {
+			ret.Comments = append(ret.Comments, &types.ExportedComment{
+				Author:    authorName(c.Poster, c.OriginalAuthor),
+				CreatedAt: c.Created,
+				Body:      c.Body,
+			})
+		}
+	}
+
+	if p.SkipDependencies {
+		return ret, nil
+	}
+	deps, err := cl.MyListIssueDependencies(p.Owner, p.Repo, issue.Index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dependencies of #%d: %w", issue.Index, err)
+	}
+	ret.DependsOn = exportRefs(p.Owner, p.Repo, deps)
+	blocks, err := cl.MyListIssueBlocking(p.Owner, p.Repo, issue.Index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blocked issues of #%d: %w", issue.Index, err)
+	}
+	ret.Blocks = exportRefs(p.Owner, p.Repo, blocks)
+	return ret, nil
+}
+
+// exportRefs returns references to issues, see types.IssueRef. Issues without
+// repository information are assumed to be in owner/repo.
+func exportRefs(owner, repo string, issues []*forgejo.Issue) []string {
+	ret := make([]string, 0, len(issues))
+	for _, i := range issues {
+		o, r := owner, repo
+		if i.Repository != nil && i.Repository.FullName != "" {
+			o, r, _ = strings.Cut(i.Repository.FullName, "/")
+		}
+		ret = append(ret, types.IssueRef(o, r, i.Index))
+	}
+	return ret
+}
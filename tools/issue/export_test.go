@@ -0,0 +1,80 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+func TestExportIssueComments(t *testing.T) {
+	// The comments endpoint ignores paging and always returns everything
+	const total = 60
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/repos/owner/repo/issues/1/comments" {
+			http.NotFound(w, r)
+			return
+		}
+		requests++
+		comments := make([]map[string]any, total)
+		for i := range comments {
+			comments[i] = map[string]any{"id": i + 1, "body": "comment"}
+		}
+		json.NewEncoder(w).Encode(comments)
+	}))
+	defer server.Close()
+	cl, err := tools.NewClient(server.URL, "token", "11.0.1+gitea-1.22.0", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := ExportIssuesParams{Owner: "owner", Repo: "repo", SkipDependencies: true}
+	exported, err := exportIssue(cl, p, &forgejo.Issue{Index: 1, Comments: total})
+	if err != nil {
+		t.Fatalf("exportIssue() error = %v", err)
+	}
+	if len(exported.Comments) != total || requests != 1 {
+		t.Errorf("got %d comments in %d requests, want %d in 1", len(exported.Comments), requests, total)
+	}
+}
+
+func TestWriteExport(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "issues.json")
+	if err := os.WriteFile(path, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	export := &types.IssueExport{Owner: "owner", Repo: "repo"}
+
+	if err := writeExport(export, path, types.ExportJSON, false); err == nil || !strings.Contains(err.Error(), "set overwrite") {
+		t.Errorf("writeExport() without overwrite error = %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "old" {
+		t.Errorf("existing file changed to %q", data)
+	}
+
+	if err := writeExport(export, path, types.ExportJSON, true); err != nil {
+		t.Fatalf("writeExport() error = %v", err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), `"owner"`) {
+		t.Errorf("file = %q", data)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("temporary files left: %v", entries)
+	}
+}
@@ -0,0 +1,368 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+const (
+	defaultGraphNodes = 100
+	maxGraphNodes     = 500
+)
+
+// GetDependencyGraphParams defines the parameters for the get_dependency_graph tool.
+// The graph is built from a single issue, the issues of a milestone, or all
+// issues of the repository.
+type GetDependencyGraphParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Index is the issue number to start from.
+	Index int `json:"index,omitempty"`
+	// Milestone is the name of the milestone whose issues to start from.
+	Milestone string `json:"milestone,omitempty"`
+	// State filters the starting issues of a milestone or repository: open,
+	// closed or all. Defaults to open.
+	State string `json:"state,omitempty"`
+	// Direction is the edges to follow: both, dependencies or blocking.
+	Direction string `json:"direction,omitempty"`
+	// Format is the output format: mermaid or dot.
+	Format string `json:"format,omitempty"`
+	// MaxNodes limits the number of issues in the graph.
+	MaxNodes int `json:"max_nodes,omitempty"`
+}
+
+// GetDependencyGraphImpl implements the read-only MCP tool for rendering the
+// dependency graph of issues. This is a safe, idempotent operation. Note: The
+// dependency endpoints are not supported by the official Forgejo SDK and use a
+// custom HTTP implementation.
+type GetDependencyGraphImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `get_dependency_graph` tool. It requires `owner` and
+// `repo`, and starts from the issue `index`, the issues of `milestone`, or all
+// issues of the repository. It is marked as a safe, read-only operation.
+func (GetDependencyGraphImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "get_dependency_graph",
+		Title:       "Get Dependency Graph",
+		Description: "Walk issue dependencies transitively, including issues in other repositories, and render them as a Mermaid flowchart or Graphviz DOT graph. Start from an issue, the issues of a milestone, or all issues of the repository. Arrows point from an issue to the issues it blocks, nodes are colored by state.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"index": {
+					Type:        "integer",
+					Description: "Issue index number to start from (optional)",
+				},
+				"milestone": {
+					Type:        "string",
+					Description: "Start from the issues of this milestone name (optional, ignored with index). Without index and milestone, starts from all issues of the repository",
+				},
+				"state": {
+					Type:        "string",
+					Description: "State of the starting issues of a milestone or repository (default: open)",
+					Enum:        []any{"open", "closed", "all"},
+				},
+				"direction": {
+					Type:        "string",
+					Description: "Edges to follow: dependencies (issues blocking the start), blocking (issues blocked by the start) or both (default: both)",
+					Enum:        []any{"both", "dependencies", "blocking"},
+				},
+				"format": {
+					Type:        "string",
+					Description: "Output format (default: mermaid)",
+					Enum:        []any{"mermaid", "dot"},
+				},
+				"max_nodes": {
+					Type:        "integer",
+					Description: fmt.Sprintf("Maximum number of issues in the graph (default: %d)", defaultGraphNodes),
+					Minimum:     tools.Float64Ptr(1),
+					Maximum:     tools.Float64Ptr(maxGraphNodes),
+				},
+			},
+			Required: []string{"owner", "repo"},
+		},
+	}
+}
+
+// Handler implements the logic for rendering the dependency graph. It lists the
+// starting issues with the Forgejo SDK's `GetIssue` or `ListRepoIssues`
+// functions, then walks the custom `/issues/{index}/dependencies` and
+// `/issues/{index}/blocks` endpoints of every issue it reaches.
+func (impl GetDependencyGraphImpl) Handler() mcp.ToolHandlerFor[GetDependencyGraphParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args GetDependencyGraphParams) (*mcp.CallToolResult, any, error) {
+		p := args
+		if p.Format == "" {
+			p.Format = "mermaid"
+		}
+		if p.Format != "mermaid" && p.Format != "dot" {
+			return nil, nil, fmt.Errorf("unsupported format %q, use mermaid or dot", p.Format)
+		}
+		if p.Direction == "" {
+			p.Direction = "both"
+		}
+		if p.Direction != "both" && p.Direction != "dependencies" && p.Direction != "blocking" {
+			return nil, nil, fmt.Errorf("unsupported direction %q, use both, dependencies or blocking", p.Direction)
+		}
+		if p.MaxNodes <= 0 {
+			p.MaxNodes = defaultGraphNodes
+		}
+		p.MaxNodes = min(p.MaxNodes, maxGraphNodes)
+
+		seeds, scope, more, err := impl.seeds(p)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		w := newGraphWalker(p.Owner, p.Repo, p.Direction, p.MaxNodes, impl.fetch)
+		if err := w.walk(ctx, seeds, p.Index > 0); err != nil {
+			return nil, nil, err
+		}
+		g := w.graph
+		g.Truncated = g.Truncated || more
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "## Dependency graph of %s\n\n", scope)
+		if len(g.Edges) == 0 && p.Index <= 0 {
+			b.WriteString("No dependencies found.\n")
+		} else {
+			fmt.Fprintf(&b, "%d issues, %d dependencies. Arrows point from an issue to the issues it blocks; open issues are green, closed issues purple.\n",
+				len(g.Nodes), len(g.Edges))
+			if g.Truncated {
+				fmt.Fprintf(&b, "\n**Note:** The graph was truncated at %d issues, raise `max_nodes` to see more.\n", p.MaxNodes)
+			}
+			if p.Format == "dot" {
+				fmt.Fprintf(&b, "\n```dot\n%s```\n", g.DOT())
+			} else {
+				fmt.Fprintf(&b, "\n```mermaid\n%s```\n", g.Mermaid())
+			}
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: b.String(),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// seeds returns the issues to start from and a description of them. At most
+// p.MaxNodes issues are listed, more reports that the scope has more.
+func (impl GetDependencyGraphImpl) seeds(p GetDependencyGraphParams) (issues []*forgejo.Issue, scope string, more bool, err error) {
+	if p.Index > 0 {
+		issue, _, err := impl.Client.GetIssue(p.Owner, p.Repo, int64(p.Index))
+		if err != nil {
+			return nil, "", false, fmt.Errorf("failed to get issue: %w", err)
+		}
+		return []*forgejo.Issue{issue}, issueRef(p.Owner, p.Repo, issue), false, nil
+	}
+
+	opt := forgejo.ListIssueOption{
+		State: forgejo.StateOpen,
+		Type:  forgejo.IssueTypeIssue,
+	}
+	if p.State != "" {
+		opt.State = forgejo.StateType(p.State)
+	}
+	scope = fmt.Sprintf("%s/%s", p.Owner, p.Repo)
+	if p.Milestone != "" {
+		m, _, err := impl.Client.GetMilestoneByName(p.Owner, p.Repo, p.Milestone)
+		if err != nil {
+			return nil, "", false, fmt.Errorf("failed to get milestone: %w", err)
+		}
+		opt.Milestones = []string{m.Title}
+		scope = fmt.Sprintf("milestone %q in %s", m.Title, scope)
+	}
+
+	const pageSize = 50
+	for page := 1; ; page++ {
+		opt.ListOptions = forgejo.ListOptions{Page: page, PageSize: pageSize}
+		list, _, err := impl.Client.ListRepoIssues(p.Owner, p.Repo, opt)
+		if err != nil {
+			return nil, "", false, fmt.Errorf("failed to list issues: %w", err)
+		}
+		for _, issue := range list {
+			if issue.PullRequest != nil {
+				continue
+			}
+			if len(issues) == p.MaxNodes {
+				return issues, scope, true, nil
+			}
+			issues = append(issues, issue)
+		}
+		if len(list) < pageSize {
+			break
+		}
+	}
+	return issues, scope, false, nil
+}
+
+// fetch lists the dependencies of an issue, or the issues it blocks.
+func (impl GetDependencyGraphImpl) fetch(owner, repo string, index int64, blocking bool) ([]*forgejo.Issue, error) {
+	if blocking {
+		issues, err := impl.Client.MyListIssueBlocking(owner, repo, index)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list issues blocked by %s: %w", types.IssueRef(owner, repo, index), err)
+		}
+		return issues, nil
+	}
+	issues, err := impl.Client.MyListIssueDependencies(owner, repo, index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dependencies of %s: %w", types.IssueRef(owner, repo, index), err)
+	}
+	return issues, nil
+}
+
+// graphWalker builds a dependency graph breadth-first. Issues are added to the
+// graph once they have an edge, so starting issues without dependencies are
+// left out, and no issue is added beyond the node limit.
+type graphWalker struct {
+	graph     *types.DependencyGraph
+	direction string
+	max       int
+	fetch     func(owner, repo string, index int64, blocking bool) ([]*forgejo.Issue, error)
+
+	nodes   map[string]*types.GraphNode
+	edges   map[types.GraphEdge]bool
+	visited map[string]bool
+	queue   []*types.GraphNode
+}
+
+func newGraphWalker(owner, repo, direction string, max int, fetch func(string, string, int64, bool) ([]*forgejo.Issue, error)) *graphWalker {
+	return &graphWalker{
+		graph:     &types.DependencyGraph{Owner: owner, Repo: repo},
+		direction: direction,
+		max:       max,
+		fetch:     fetch,
+		nodes:     map[string]*types.GraphNode{},
+		edges:     map[types.GraphEdge]bool{},
+		visited:   map[string]bool{},
+	}
+}
+
+// walk follows the edges of seeds transitively. With keep, the seeds are in
+// the graph even without edges.
+func (w *graphWalker) walk(ctx context.Context, seeds []*forgejo.Issue, keep bool) error {
+	for _, issue := range seeds {
+		n := graphNode(w.graph.Owner, w.graph.Repo, issue)
+		n.Seed = true
+		if keep {
+			w.include(n)
+		}
+		w.enqueue(n)
+	}
+
+	// Once truncated, walking further only fetches edges that cannot be added
+	for len(w.queue) > 0 && !w.graph.Truncated {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n := w.queue[0]
+		w.queue = w.queue[1:]
+
+		if w.direction != "blocking" {
+			deps, err := w.fetch(n.Owner, n.Repo, n.Index, false)
+			if err != nil {
+				return err
+			}
+			for _, issue := range deps {
+				w.link(n, graphNode(n.Owner, n.Repo, issue), false)
+			}
+		}
+		if w.direction != "dependencies" {
+			blocked, err := w.fetch(n.Owner, n.Repo, n.Index, true)
+			if err != nil {
+				return err
+			}
+			for _, issue := range blocked {
+				w.link(n, graphNode(n.Owner, n.Repo, issue), true)
+			}
+		}
+	}
+	return nil
+}
+
+// link adds the edge between n and its neighbor m, n blocking m if blocks is
+// set, and queues m to be walked.
+func (w *graphWalker) link(n, m *types.GraphNode, blocks bool) {
+	if !w.include(n) || !w.include(m) {
+		return
+	}
+	n, m = w.nodes[n.Ref()], w.nodes[m.Ref()]
+	e := types.GraphEdge{From: m.Ref(), To: n.Ref()}
+	if blocks {
+		e = types.GraphEdge{From: n.Ref(), To: m.Ref()}
+	}
+	if !w.edges[e] {
+		w.edges[e] = true
+		w.graph.Edges = append(w.graph.Edges, e)
+	}
+	w.enqueue(m)
+}
+
+// include adds n to the graph, reporting false if the graph is full.
+func (w *graphWalker) include(n *types.GraphNode) bool {
+	if have, ok := w.nodes[n.Ref()]; ok {
+		have.Seed = have.Seed || n.Seed
+		return true
+	}
+	if len(w.graph.Nodes) >= w.max {
+		w.graph.Truncated = true
+		return false
+	}
+	w.nodes[n.Ref()] = n
+	w.graph.Nodes = append(w.graph.Nodes, n)
+	return true
+}
+
+func (w *graphWalker) enqueue(n *types.GraphNode) {
+	if !w.visited[n.Ref()] {
+		w.visited[n.Ref()] = true
+		w.queue = append(w.queue, n)
+	}
+}
+
+// graphNode converts issue to a node. Issues without a repository, like
+// those from GetIssue, belong to owner/repo.
+func graphNode(owner, repo string, issue *forgejo.Issue) *types.GraphNode {
+	if issue.Repository != nil && issue.Repository.FullName != "" {
+		owner, repo, _ = strings.Cut(issue.Repository.FullName, "/")
+	}
+	return &types.GraphNode{
+		Owner: owner,
+		Repo:  repo,
+		Index: issue.Index,
+		Title: issue.Title,
+		State: string(issue.State),
+	}
+}
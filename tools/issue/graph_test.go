@@ -0,0 +1,126 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// fakeDependencies serves the dependency endpoints from a list of edges
+// "blocker blocked", by issue reference.
+func fakeDependencies(edges [][2]string) func(string, string, int64, bool) ([]*forgejo.Issue, error) {
+	issue := func(ref string) *forgejo.Issue {
+		fullName, index, _ := strings.Cut(ref, "#")
+		n, _ := strconv.ParseInt(index, 10, 64)
+		return &forgejo.Issue{
+			Index:      n,
+			Title:      "Issue " + ref,
+			State:      forgejo.StateOpen,
+			Repository: &forgejo.RepositoryMeta{FullName: fullName},
+		}
+	}
+	return func(owner, repo string, index int64, blocking bool) ([]*forgejo.Issue, error) {
+		ref := types.IssueRef(owner, repo, index)
+		var ret []*forgejo.Issue
+		for _, e := range edges {
+			if blocking && e[0] == ref {
+				ret = append(ret, issue(e[1]))
+			}
+			if !blocking && e[1] == ref {
+				ret = append(ret, issue(e[0]))
+			}
+		}
+		return ret, nil
+	}
+}
+
+func TestGraphWalker(t *testing.T) {
+	fetch := fakeDependencies([][2]string{
+		{"o/r#1", "o/r#2"},
+		{"o/r#2", "o/r#3"},
+		{"x/lib#9", "o/r#1"},
+		{"o/r#4", "o/r#3"},
+		{"o/r#5", "o/r#6"},
+	})
+	seed := []*forgejo.Issue{{Index: 2, Title: "Issue o/r#2", State: forgejo.StateOpen}}
+	edges := func(g *types.DependencyGraph) []string {
+		var ret []string
+		for _, e := range g.Edges {
+			ret = append(ret, e.From+" -> "+e.To)
+		}
+		slices.Sort(ret)
+		return ret
+	}
+
+	w := newGraphWalker("o", "r", "both", 100, fetch)
+	if err := w.walk(context.Background(), seed, true); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"o/r#1 -> o/r#2", "o/r#2 -> o/r#3", "o/r#4 -> o/r#3", "x/lib#9 -> o/r#1"}
+	if got := edges(w.graph); !slices.Equal(got, want) {
+		t.Errorf("edges = %v, want %v", got, want)
+	}
+	if len(w.graph.Nodes) != 5 || !w.graph.Nodes[0].Seed || w.graph.Truncated {
+		t.Errorf("unexpected nodes %+v, truncated %v", w.graph.Nodes, w.graph.Truncated)
+	}
+
+	w = newGraphWalker("o", "r", "dependencies", 100, fetch)
+	if err := w.walk(context.Background(), seed, true); err != nil {
+		t.Fatal(err)
+	}
+	want = []string{"o/r#1 -> o/r#2", "x/lib#9 -> o/r#1"}
+	if got := edges(w.graph); !slices.Equal(got, want) {
+		t.Errorf("dependencies edges = %v, want %v", got, want)
+	}
+
+	w = newGraphWalker("o", "r", "both", 3, fetch)
+	if err := w.walk(context.Background(), seed, true); err != nil {
+		t.Fatal(err)
+	}
+	if len(w.graph.Nodes) != 3 || !w.graph.Truncated {
+		t.Errorf("expected 3 nodes and truncation, got %d nodes, truncated %v", len(w.graph.Nodes), w.graph.Truncated)
+	}
+
+	// starting issues without dependencies are left out
+	seeds := []*forgejo.Issue{
+		{Index: 5, Title: "Issue o/r#5", State: forgejo.StateOpen},
+		{Index: 7, Title: "Issue o/r#7", State: forgejo.StateOpen},
+	}
+	w = newGraphWalker("o", "r", "both", 100, fetch)
+	if err := w.walk(context.Background(), seeds, false); err != nil {
+		t.Fatal(err)
+	}
+	if len(w.graph.Nodes) != 2 || w.graph.Nodes[0].Ref() != "o/r#5" || !w.graph.Nodes[0].Seed || w.graph.Nodes[1].Seed {
+		t.Errorf("unexpected nodes %+v", w.graph.Nodes)
+	}
+
+	// no edges are fetched once the graph is truncated
+	calls := 0
+	counted := func(owner, repo string, index int64, blocking bool) ([]*forgejo.Issue, error) {
+		calls++
+		return fetch(owner, repo, index, blocking)
+	}
+	many := slices.Clone(seeds)
+	for i := int64(10); i < 30; i++ {
+		many = append(many, &forgejo.Issue{Index: i, State: forgejo.StateOpen})
+	}
+	w = newGraphWalker("o", "r", "both", 1, counted)
+	if err := w.walk(context.Background(), many, false); err != nil {
+		t.Fatal(err)
+	}
+	if !w.graph.Truncated || calls != 2 {
+		t.Errorf("expected truncation after the edges of the first issue, got %d calls, truncated %v", calls, w.graph.Truncated)
+	}
+}
@@ -0,0 +1,726 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// ImportIssuesParams defines the parameters for the import_issues tool and
+// the import command. It specifies the target repository, the input and the
+// mapping file.
+type ImportIssuesParams struct {
+	// Owner is the username or organization name that owns the target repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the target repository.
+	Repo string `json:"repo"`
+	// Input is the path of a file on the host running the server, inside the
+	// export directory for the tool.
+	Input string `json:"input,omitempty"`
+	// Data is the content to import, used instead of Input.
+	Data string `json:"data,omitempty"`
+	// Format is the input format, see types.ReadIssueExport. Defaults to the
+	// extension of Input, or json.
+	Format string `json:"format,omitempty"`
+	// Source is the "owner/repo" the issues were exported from, used to tell
+	// references between imported issues from references to other
+	// repositories. Defaults to the repository of JSON exports.
+	Source string `json:"source,omitempty"`
+	// Mapping is the path of a file recording which issues were imported as
+	// which new issues, so reruns skip them. Like Input, it must be inside the
+	// export directory for the tool.
+	Mapping string `json:"mapping,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// ImportIssuesImpl implements the MCP tool for importing issues exported by
+// export_issues or from GitHub. Labels and milestones are created when
+// missing, issues and comments are recreated with a line attributing the
+// original author, and dependencies are restored. With a mapping file, reruns
+// skip the issues already imported. Files are only read and written in Dir.
+type ImportIssuesImpl struct {
+	Client *tools.Client
+	// Dir is the directory input and mapping files may be in. Empty disables
+	// `input` and `mapping`, the content must be passed in `data`.
+	Dir string
+}
+
+// Definition describes the `import_issues` tool. It requires `owner`, `repo`
+// and either `input` or `data`. It is idempotent only with a mapping file.
+func (impl ImportIssuesImpl) Definition() *mcp.Tool {
+	def := &mcp.Tool{
+		Name:        "import_issues",
+		Title:       "Import Issues",
+		Description: "Import issues into a repository from JSON, JSONL or CSV written by export_issues, or from GitHub (a JSON array from the REST API or `gh issue list --json`). Creates missing labels and milestones, recreates issues and comments with a line naming the original author and time, and restores dependencies. If the server has an export directory, input can be a file there and a mapping file makes reruns skip issues already imported. Use dry_run to preview.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  false,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Owner of the repository to import into (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Name of the repository to import into",
+				},
+				"data": {
+					Type:        "string",
+					Description: "Content to import",
+				},
+				"format": {
+					Type:        "string",
+					Description: "Input format (optional, defaults to the extension of input, or 'json')",
+					Enum:        []any{types.ExportJSON, types.ExportJSONL, types.ExportCSV, types.ExportGitHub},
+				},
+				"source": {
+					Type:        "string",
+					Description: "The 'owner/repo' the issues were exported from, to restore dependencies between them (optional, defaults to the repository of JSON exports)",
+				},
+				"dry_run": tools.DryRunSchema(),
+			},
+			Required: []string{"owner", "repo"},
+		},
+	}
+	if impl.Dir != "" {
+		def.InputSchema.Properties["data"].Description = "Content to import, instead of input (optional)"
+		def.InputSchema.Properties["input"] = &jsonschema.Schema{
+			Type:        "string",
+			Description: fmt.Sprintf("Path of the file to import on the server host, inside %s (optional if data is given)", impl.Dir),
+		}
+		def.InputSchema.Properties["mapping"] = &jsonschema.Schema{
+			Type:        "string",
+			Description: fmt.Sprintf("Path of a file on the server host recording the imported issues, inside %s; reruns skip them (optional but recommended)", impl.Dir),
+		}
+	}
+	return def
+}
+
+// Handler implements the logic for importing issues. It checks the input and
+// mapping files are inside Dir, plans the import with PlanImport, then
+// previews or runs it.
+func (impl ImportIssuesImpl) Handler() mcp.ToolHandlerFor[ImportIssuesParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args ImportIssuesParams) (*mcp.CallToolResult, any, error) {
+		p := args
+		var err error
+		if p.Input != "" {
+			if p.Input, err = tools.ContainedPath(impl.Dir, p.Input, "--export-dir"); err != nil {
+				return nil, nil, err
+			}
+		}
+		if p.Mapping != "" {
+			if p.Mapping, err = tools.ContainedPath(impl.Dir, p.Mapping, "--export-dir"); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		plan, err := PlanImport(impl.Client, p)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.Client.DryRunResult(plan.Preview(), plan.Requests()...)
+			return res, nil, err
+		}
+
+		text, err := plan.Run(ctx, impl.Client)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: text,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// importMapping records which source issues were imported as which issues of
+// the target repository. It is saved as JSON after every created issue and
+// copied comment.
+type importMapping struct {
+	Target string `json:"target"`
+	// Issues maps source references (see ImportPlan.key) to new indexes.
+	Issues map[string]int64 `json:"issues"`
+	// Comments counts the comments copied so far to the issues whose
+	// comments are not all copied yet, by source reference. Reruns resume
+	// copying from there.
+	Comments map[string]int `json:"comments,omitempty"`
+
+	path string
+}
+
+// loadMapping reads the mapping file at path, or returns an empty mapping if
+// path is empty or the file does not exist yet.
+func loadMapping(path, target string) (*importMapping, error) {
+	m := &importMapping{Target: target, Issues: map[string]int64{}, Comments: map[string]int{}, path: path}
+	if path == "" {
+		return m, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("invalid mapping file %s: %w", path, err)
+	}
+	if !strings.EqualFold(m.Target, target) {
+		return nil, fmt.Errorf("mapping file %s is for %s, not %s", path, m.Target, target)
+	}
+	if m.Issues == nil {
+		m.Issues = map[string]int64{}
+	}
+	if m.Comments == nil {
+		m.Comments = map[string]int{}
+	}
+	return m, nil
+}
+
+// save writes the mapping file, if any.
+func (m *importMapping) save() error {
+	if m.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(m.path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write mapping file: %w", err)
+	}
+	return nil
+}
+
+// ImportPlan is a validated import: the issues to create and the labels and
+// milestones missing in the target repository. It is shared by the
+// import_issues tool and the import command.
+type ImportPlan struct {
+	Owner, Repo string
+	// Source is the "owner/repo" the issues were exported from, may be empty.
+	Source string
+	Export *types.IssueExport
+	// Pending are the issues not imported yet, or whose comments are not all
+	// copied yet, ordered by index.
+	Pending []*types.ExportedIssue
+
+	mapping    *importMapping
+	labels     map[string]*forgejo.Label
+	milestones map[string]*forgejo.Milestone
+	// missingLabels and missingMilestones are created before the issues.
+	missingLabels     []string
+	missingMilestones []string
+}
+
+// PlanImport reads the input of p and compares it with the target repository
+// and the mapping file.
+func PlanImport(cl *tools.Client, p ImportIssuesParams) (*ImportPlan, error) {
+	export, err := readImport(p)
+	if err != nil {
+		return nil, err
+	}
+	if len(export.Issues) == 0 {
+		return nil, fmt.Errorf("no issues to import")
+	}
+
+	plan := &ImportPlan{
+		Owner:      p.Owner,
+		Repo:       p.Repo,
+		Source:     p.Source,
+		Export:     export,
+		labels:     map[string]*forgejo.Label{},
+		milestones: map[string]*forgejo.Milestone{},
+	}
+	if plan.Source == "" && export.Owner != "" && export.Repo != "" {
+		plan.Source = export.Owner + "/" + export.Repo
+	}
+	plan.mapping, err = loadMapping(p.Mapping, p.Owner+"/"+p.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	for _, issue := range export.Issues {
+		if strings.TrimSpace(issue.Title) == "" {
+			return nil, fmt.Errorf("issue #%d has no title", issue.Index)
+		}
+		key := plan.key(issue.Index)
+		if seen[key] {
+			return nil, fmt.Errorf("issue #%d appears more than once", issue.Index)
+		}
+		seen[key] = true
+		_, imported := plan.mapping.Issues[key]
+		_, copying := plan.mapping.Comments[key]
+		if !imported || copying {
+			plan.Pending = append(plan.Pending, issue)
+		}
+	}
+	slices.SortFunc(plan.Pending, func(a, b *types.ExportedIssue) int { return cmp.Compare(a.Index, b.Index) })
+
+	labels, _, err := cl.ListRepoLabels(p.Owner, p.Repo, forgejo.ListLabelsOptions{
+		ListOptions: forgejo.ListOptions{Page: -1},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+	for _, l := range labels {
+		plan.labels[strings.ToLower(l.Name)] = l
+	}
+	for _, name := range export.LabelNames() {
+		if plan.labels[strings.ToLower(name)] == nil {
+			plan.missingLabels = append(plan.missingLabels, name)
+		}
+	}
+
+	const pageSize = 50
+	for page := 1; ; page++ {
+		milestones, _, err := cl.ListRepoMilestones(p.Owner, p.Repo, forgejo.ListMilestoneOption{
+			ListOptions: forgejo.ListOptions{Page: page, PageSize: pageSize},
+			State:       forgejo.StateAll,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list milestones: %w", err)
+		}
+		for _, m := range milestones {
+			plan.milestones[strings.ToLower(m.Title)] = m
+		}
+		if len(milestones) < pageSize {
+			break
+		}
+	}
+	for _, title := range export.MilestoneTitles() {
+		if plan.milestones[strings.ToLower(title)] == nil {
+			plan.missingMilestones = append(plan.missingMilestones, title)
+		}
+	}
+
+	return plan, nil
+}
+
+// readImport reads the input or data of p in its format.
+func readImport(p ImportIssuesParams) (*types.IssueExport, error) {
+	format := p.Format
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(p.Input)) {
+		case ".csv":
+			format = types.ExportCSV
+		case ".jsonl", ".ndjson":
+			format = types.ExportJSONL
+		default:
+			format = types.ExportJSON
+		}
+	}
+
+	switch {
+	case p.Input != "" && p.Data != "":
+		return nil, fmt.Errorf("only one of input and data can be given")
+	case p.Data != "":
+		return types.ReadIssueExport(strings.NewReader(p.Data), format)
+	case p.Input != "":
+		f, err := os.Open(p.Input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open input: %w", err)
+		}
+		defer f.Close()
+		return types.ReadIssueExport(f, format)
+	}
+	return nil, fmt.Errorf("input or data is required")
+}
+
+// key returns the mapping key of the source issue index, e.g.
+// "owner/repo#12", or "#12" if the source is unknown.
+func (plan *ImportPlan) key(index int64) string {
+	return fmt.Sprintf("%s#%d", plan.Source, index)
+}
+
+// issueRefPattern matches references to issues in exports, "owner/repo#12"
+// or "#12".
+var issueRefPattern = regexp.MustCompile(`^(?:([^/\s#]+)/([^/\s#]+))?#(\d+)$`)
+
+// resolveRef resolves a reference of the export to an issue of this server.
+// Issues of the source repository must have been imported.
+func (plan *ImportPlan) resolveRef(ref string) (types.MyIssueMeta, error) {
+	m := issueRefPattern.FindStringSubmatch(strings.TrimSpace(ref))
+	if m == nil {
+		return types.MyIssueMeta{}, fmt.Errorf("invalid issue reference %q", ref)
+	}
+	index, _ := strconv.ParseInt(m[3], 10, 64)
+	if m[1] == "" || strings.EqualFold(m[1]+"/"+m[2], plan.Source) {
+		target, ok := plan.mapping.Issues[plan.key(index)]
+		if !ok {
+			return types.MyIssueMeta{}, fmt.Errorf("%s was not imported", ref)
+		}
+		return types.MyIssueMeta{Index: target, Owner: plan.Owner, Name: plan.Repo}, nil
+	}
+	return types.MyIssueMeta{Index: index, Owner: m[1], Name: m[2]}, nil
+}
+
+// Preview describes the labels, milestones and issues the import would
+// create.
+func (plan *ImportPlan) Preview() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Would import %d issues into %s/%s", len(plan.Pending), plan.Owner, plan.Repo)
+	if skipped := len(plan.Export.Issues) - len(plan.Pending); skipped > 0 {
+		fmt.Fprintf(&b, ", skipping %d already imported", skipped)
+	}
+	b.WriteString(":\n\n")
+	fmt.Fprintf(&b, "- **New labels**: %s\n", orNone(strings.Join(plan.missingLabels, ", ")))
+	fmt.Fprintf(&b, "- **New milestones**: %s\n", orNone(strings.Join(plan.missingMilestones, ", ")))
+	if plan.mapping.path == "" {
+		b.WriteString("- **Mapping file**: (none), a rerun would duplicate the issues\n")
+	} else {
+		fmt.Fprintf(&b, "- **Mapping file**: %s\n", plan.mapping.path)
+	}
+
+	if len(plan.Pending) > 0 {
+		b.WriteString("\nIssues:\n")
+	}
+	for _, issue := range plan.Pending {
+		fmt.Fprintf(&b, "- %s %s (%s", plan.key(issue.Index), issue.Title, cmp.Or(issue.State, "open"))
+		if index, ok := plan.mapping.Issues[plan.key(issue.Index)]; ok {
+			fmt.Fprintf(&b, ", already #%d, %d of %d comments copied", index, plan.mapping.Comments[plan.key(issue.Index)], len(issue.Comments))
+		} else if n := len(issue.Comments); n > 0 {
+			fmt.Fprintf(&b, ", %d comments", n)
+		}
+		if n := len(issue.DependsOn); n > 0 {
+			fmt.Fprintf(&b, ", %d dependencies", n)
+		}
+		b.WriteString(")\n")
+	}
+	return b.String()
+}
+
+// Requests returns representative requests of the import for dry-run
+// previews.
+func (plan *ImportPlan) Requests() []tools.APIRequest {
+	endpoint := fmt.Sprintf("/api/v1/repos/%s/%s", plan.Owner, plan.Repo)
+	var reqs []tools.APIRequest
+	if len(plan.missingLabels) > 0 {
+		reqs = append(reqs, tools.APIRequest{Method: "POST", Endpoint: endpoint + "/labels"})
+	}
+	if len(plan.missingMilestones) > 0 {
+		reqs = append(reqs, tools.APIRequest{Method: "POST", Endpoint: endpoint + "/milestones"})
+	}
+	if len(plan.Pending) > 0 {
+		reqs = append(reqs, tools.APIRequest{Method: "POST", Endpoint: endpoint + "/issues"})
+	}
+	comments, deps := false, false
+	for _, issue := range plan.Export.Issues {
+		comments = comments || len(issue.Comments) > 0
+		deps = deps || len(issue.DependsOn) > 0 || len(issue.Blocks) > 0
+	}
+	if comments {
+		reqs = append(reqs, tools.APIRequest{Method: "POST", Endpoint: endpoint + "/issues/{new_index}/comments"})
+	}
+	if deps {
+		reqs = append(reqs, tools.APIRequest{Method: "POST", Endpoint: endpoint + "/issues/{new_index}/dependencies"})
+	}
+	return reqs
+}
+
+// Run creates the missing labels and milestones, the pending issues with
+// their comments, and the dependencies, and describes the result. The
+// mapping file is saved after each issue and comment, so a failed import can
+// be rerun.
+func (plan *ImportPlan) Run(ctx context.Context, cl *tools.Client) (string, error) {
+	var notes []string
+
+	colors := map[string]*types.ExportedLabel{}
+	for _, l := range plan.Export.Labels {
+		colors[l.Name] = l
+	}
+	for _, name := range plan.missingLabels {
+		opt := forgejo.CreateLabelOption{Name: name, Color: "#ededed"}
+		if l := colors[name]; l != nil {
+			opt.Description = l.Description
+			if c := "#" + strings.TrimPrefix(l.Color, "#"); labelColorPattern.MatchString(c) {
+				opt.Color = c
+			}
+		}
+		label, _, err := cl.CreateLabel(plan.Owner, plan.Repo, opt)
+		if err != nil {
+			return "", fmt.Errorf("failed to create label %q: %w", name, err)
+		}
+		plan.labels[strings.ToLower(name)] = label
+	}
+
+	metas := map[string]*types.ExportedMilestone{}
+	for _, m := range plan.Export.Milestones {
+		metas[m.Title] = m
+	}
+	for _, title := range plan.missingMilestones {
+		opt := forgejo.CreateMilestoneOption{Title: title}
+		if m := metas[title]; m != nil {
+			opt.Description = m.Description
+			opt.Deadline = m.DueDate
+			opt.State = forgejo.StateType(m.State)
+		}
+		milestone, _, err := cl.CreateMilestone(plan.Owner, plan.Repo, opt)
+		if err != nil {
+			return "", fmt.Errorf("failed to create milestone %q: %w", title, err)
+		}
+		plan.milestones[strings.ToLower(title)] = milestone
+	}
+
+	users := map[string]bool{}
+	var created, resumed []string
+	comments := 0
+	for _, issue := range plan.Pending {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		_, exists := plan.mapping.Issues[plan.key(issue.Index)]
+		index, n, issueNotes, err := plan.createIssue(cl, issue, users)
+		notes = append(notes, issueNotes...)
+		if err != nil {
+			return "", fmt.Errorf("imported %d issues, then %w", len(created), err)
+		}
+		comments += n
+		if exists {
+			resumed = append(resumed, fmt.Sprintf("- %s → #%d (comments resumed)", plan.key(issue.Index), index))
+			continue
+		}
+		created = append(created, fmt.Sprintf("- %s → #%d", plan.key(issue.Index), index))
+	}
+
+	deps, depNotes := plan.restoreDependencies(cl)
+	notes = append(notes, depNotes...)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Imported into %s/%s: %d labels, %d milestones, %d issues, %d comments and %d dependencies created",
+		plan.Owner, plan.Repo, len(plan.missingLabels), len(plan.missingMilestones), len(created), comments, deps)
+	if skipped := len(plan.Export.Issues) - len(plan.Pending); skipped > 0 {
+		fmt.Fprintf(&b, ", %d issues skipped as already imported", skipped)
+	}
+	b.WriteString(".\n")
+	if lines := append(created, resumed...); len(lines) > 0 {
+		b.WriteString("\n" + strings.Join(lines, "\n") + "\n")
+	}
+	if plan.mapping.path == "" {
+		notes = append(notes, "No mapping file was given, a rerun would duplicate the issues.")
+	}
+	if len(notes) > 0 {
+		b.WriteString("\n" + strings.Join(notes, "\n") + "\n")
+	}
+	return b.String(), nil
+}
+
+// labelColorPattern matches label colors accepted by Forgejo.
+var labelColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// createIssue creates issue with its comments and records it in the mapping.
+// Issues created by an earlier run only get the comments not copied yet.
+// Assignees who are not users of this server are skipped, users caches the
+// lookups. It returns the new index and the number of comments copied.
+func (plan *ImportPlan) createIssue(cl *tools.Client, issue *types.ExportedIssue, users map[string]bool) (int64, int, []string, error) {
+	key := plan.key(issue.Index)
+	if index, ok := plan.mapping.Issues[key]; ok {
+		n, err := plan.copyComments(cl, issue, index)
+		return index, n, nil, err
+	}
+
+	var notes []string
+	opt := forgejo.CreateIssueOption{
+		Title:    issue.Title,
+		Body:     importBody(plan.key(issue.Index), issue),
+		Deadline: issue.DueDate,
+		Closed:   strings.EqualFold(issue.State, string(forgejo.StateClosed)),
+	}
+	for _, name := range issue.Labels {
+		opt.Labels = append(opt.Labels, plan.labels[strings.ToLower(name)].ID)
+	}
+	if issue.Milestone != "" {
+		opt.Milestone = plan.milestones[strings.ToLower(issue.Milestone)].ID
+	}
+	for _, name := range issue.Assignees {
+		exists, ok := users[name]
+		if !ok {
+			_, _, err := cl.GetUserInfo(name)
+			exists = err == nil
+			users[name] = exists
+		}
+		if exists {
+			opt.Assignees = append(opt.Assignees, name)
+		} else {
+			notes = append(notes, fmt.Sprintf("Assignee %s of %s is not a user here and was skipped.", name, plan.key(issue.Index)))
+		}
+	}
+
+	created, _, err := cl.CreateIssue(plan.Owner, plan.Repo, opt)
+	if err != nil && len(opt.Assignees) > 0 {
+		// Users may exist but not be allowed to be assigned
+		notes = append(notes, fmt.Sprintf("Assignees of %s could not be assigned and were skipped.", plan.key(issue.Index)))
+		opt.Assignees = nil
+		created, _, err = cl.CreateIssue(plan.Owner, plan.Repo, opt)
+	}
+	if err != nil {
+		return 0, 0, notes, fmt.Errorf("failed to create %s: %w", plan.key(issue.Index), err)
+	}
+	plan.mapping.Issues[key] = created.Index
+	plan.mapping.Comments[key] = 0
+	if err := plan.mapping.save(); err != nil {
+		return 0, 0, notes, err
+	}
+
+	n, err := plan.copyComments(cl, issue, created.Index)
+	return created.Index, n, notes, err
+}
+
+// copyComments copies the comments of issue not copied yet to the issue
+// index, recording the progress in the mapping after each one. It returns the
+// number of comments copied.
+func (plan *ImportPlan) copyComments(cl *tools.Client, issue *types.ExportedIssue, index int64) (int, error) {
+	key := plan.key(issue.Index)
+	start := plan.mapping.Comments[key]
+	for i := start; i < len(issue.Comments); i++ {
+		_, _, err := cl.CreateIssueComment(plan.Owner, plan.Repo, index, forgejo.CreateIssueCommentOption{
+			Body: importComment(issue.Comments[i]),
+		})
+		if err != nil {
+			return i - start, fmt.Errorf("created #%d from %s but failed to copy comment %d of %d: %w",
+				index, key, i+1, len(issue.Comments), err)
+		}
+		plan.mapping.Comments[key] = i + 1
+		if err := plan.mapping.save(); err != nil {
+			return i + 1 - start, err
+		}
+	}
+	delete(plan.mapping.Comments, key)
+	return len(issue.Comments) - start, plan.mapping.save()
+}
+
+// restoreDependencies adds the dependency edges of all imported issues that
+// do not exist yet. Failures are returned as notes, as edges may reference
+// issues that are not accessible.
+func (plan *ImportPlan) restoreDependencies(cl *tools.Client) (int, []string) {
+	type edge struct {
+		dependent, dependency types.MyIssueMeta
+	}
+	var edges []edge
+	seen := map[edge]bool{}
+	var notes []string
+	add := func(dependent, dependency string) {
+		from, err := plan.resolveRef(dependent)
+		if err == nil {
+			var to types.MyIssueMeta
+			if to, err = plan.resolveRef(dependency); err == nil {
+				e := edge{from, to}
+				if !seen[e] {
+					seen[e] = true
+					edges = append(edges, e)
+				}
+				return
+			}
+		}
+		notes = append(notes, fmt.Sprintf("Dependency of %s on %s skipped: %v.", dependent, dependency, err))
+	}
+	for _, issue := range plan.Export.Issues {
+		ref := plan.key(issue.Index)
+		for _, dep := range issue.DependsOn {
+			add(ref, dep)
+		}
+		for _, blocked := range issue.Blocks {
+			add(blocked, ref)
+		}
+	}
+
+	existing := map[types.MyIssueMeta][]*forgejo.Issue{}
+	count := 0
+	for _, e := range edges {
+		deps, ok := existing[e.dependent]
+		if !ok {
+			var err error
+			deps, err = cl.MyListIssueDependencies(e.dependent.Owner, e.dependent.Name, e.dependent.Index)
+			if err != nil {
+				notes = append(notes, fmt.Sprintf("Dependencies of %s skipped: %v.", types.IssueRef(e.dependent.Owner, e.dependent.Name, e.dependent.Index), err))
+				continue
+			}
+			existing[e.dependent] = deps
+		}
+		if slices.ContainsFunc(deps, func(i *forgejo.Issue) bool { return sameIssue(i, e.dependency) }) {
+			continue
+		}
+		if _, err := cl.MyAddIssueDependency(e.dependent.Owner, e.dependent.Name, e.dependent.Index, e.dependency); err != nil {
+			notes = append(notes, fmt.Sprintf("Dependency of %s on %s failed: %v.",
+				types.IssueRef(e.dependent.Owner, e.dependent.Name, e.dependent.Index),
+				types.IssueRef(e.dependency.Owner, e.dependency.Name, e.dependency.Index), err))
+			continue
+		}
+		count++
+	}
+	return count, notes
+}
+
+// sameIssue reports whether issue, as returned by the dependency endpoints,
+// is the one meta refers to.
+func sameIssue(issue *forgejo.Issue, meta types.MyIssueMeta) bool {
+	if issue.Index != meta.Index {
+		return false
+	}
+	return issue.Repository == nil || strings.EqualFold(issue.Repository.FullName, meta.Owner+"/"+meta.Name)
+}
+
+// importBody returns the body of an imported issue: a line naming its source
+// and original author, followed by the original body. Authors are not
+// mentioned with @, as they may be different users on this server.
+func importBody(ref string, issue *types.ExportedIssue) string {
+	line := "_Imported from " + ref
+	if issue.Author != "" {
+		line += ", originally opened by **" + issue.Author + "**"
+	}
+	if !issue.CreatedAt.IsZero() {
+		line += " on " + moveTime(issue.CreatedAt)
+	}
+	line += "._"
+	if strings.TrimSpace(issue.Body) == "" {
+		return line
+	}
+	return line + "\n\n" + issue.Body
+}
+
+// importComment returns the body of an imported comment: a line naming its
+// original author and time, followed by the original body.
+func importComment(c *types.ExportedComment) string {
+	line := "_Originally posted"
+	if c.Author != "" {
+		line += " by **" + c.Author + "**"
+	}
+	if !c.CreatedAt.IsZero() {
+		line += " on " + moveTime(c.CreatedAt)
+	}
+	return line + "._\n\n" + c.Body
+}
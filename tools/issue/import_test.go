@@ -0,0 +1,164 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+func TestImportBody(t *testing.T) {
+	issue := &types.ExportedIssue{
+		Index:     12,
+		Body:      "Login fails.",
+		Author:    "alice",
+		CreatedAt: time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC),
+	}
+	want := "_Imported from old/repo#12, originally opened by **alice** on 2024-01-15 14:30 UTC._\n\nLogin fails."
+	if got := importBody("old/repo#12", issue); got != want {
+		t.Errorf("importBody() =\n%s\nwant\n%s", got, want)
+	}
+
+	// CSV imports may lack author and time
+	if got := importBody("#3", &types.ExportedIssue{Index: 3}); got != "_Imported from #3._" {
+		t.Errorf("importBody() without metadata = %q", got)
+	}
+
+	c := &types.ExportedComment{Author: "bob", CreatedAt: issue.CreatedAt, Body: "Reproduced."}
+	want = "_Originally posted by **bob** on 2024-01-15 14:30 UTC._\n\nReproduced."
+	if got := importComment(c); got != want {
+		t.Errorf("importComment() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestImportPlanResolveRef(t *testing.T) {
+	plan := &ImportPlan{
+		Owner:   "new",
+		Repo:    "repo",
+		Source:  "old/repo",
+		mapping: &importMapping{Issues: map[string]int64{"old/repo#3": 40}},
+	}
+
+	tests := []struct {
+		ref  string
+		want types.MyIssueMeta
+		err  string
+	}{
+		{"old/repo#3", types.MyIssueMeta{Index: 40, Owner: "new", Name: "repo"}, ""},
+		{"#3", types.MyIssueMeta{Index: 40, Owner: "new", Name: "repo"}, ""},
+		{"Old/Repo#3", types.MyIssueMeta{Index: 40, Owner: "new", Name: "repo"}, ""},
+		{"org/lib#7", types.MyIssueMeta{Index: 7, Owner: "org", Name: "lib"}, ""},
+		{"old/repo#4", types.MyIssueMeta{}, "was not imported"},
+		{"owner/repo/3", types.MyIssueMeta{}, "invalid issue reference"},
+	}
+	for _, tt := range tests {
+		got, err := plan.resolveRef(tt.ref)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("resolveRef(%q) error = %v, want %q", tt.ref, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("resolveRef(%q) = %+v, %v, want %+v", tt.ref, got, err, tt.want)
+		}
+	}
+}
+
+func TestImportMapping(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mapping.json")
+
+	m, err := loadMapping(path, "new/repo")
+	if err != nil {
+		t.Fatalf("loadMapping() of missing file error = %v", err)
+	}
+	m.Issues["old/repo#3"] = 40
+	if err := m.save(); err != nil {
+		t.Fatalf("save() error = %v", err)
+	}
+
+	m, err = loadMapping(path, "New/Repo")
+	if err != nil {
+		t.Fatalf("loadMapping() error = %v", err)
+	}
+	if m.Issues["old/repo#3"] != 40 {
+		t.Errorf("Issues = %v", m.Issues)
+	}
+
+	if _, err := loadMapping(path, "other/repo"); err == nil || !strings.Contains(err.Error(), "is for new/repo") {
+		t.Errorf("loadMapping() for another target error = %v", err)
+	}
+}
+
+func TestImportPlanCopyCommentsResumes(t *testing.T) {
+	var bodies []string
+	fail := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/repos/new/repo/issues/40/comments" {
+			http.NotFound(w, r)
+			return
+		}
+		var opt struct{ Body string }
+		json.NewDecoder(r.Body).Decode(&opt)
+		if len(bodies) == 1 && fail {
+			fail = false
+			http.Error(w, "boom", http.StatusInternalServerError)
+			return
+		}
+		bodies = append(bodies, opt.Body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+	cl, err := tools.NewClient(server.URL, "token", "11.0.1+gitea-1.22.0", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "mapping.json")
+	issue := &types.ExportedIssue{Index: 3, Title: "t", Comments: []*types.ExportedComment{
+		{Author: "a", Body: "one"}, {Author: "b", Body: "two"}, {Author: "c", Body: "three"},
+	}}
+	newPlan := func() *ImportPlan {
+		m, err := loadMapping(path, "new/repo")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &ImportPlan{Owner: "new", Repo: "repo", Source: "old/repo", mapping: m}
+	}
+
+	plan := newPlan()
+	plan.mapping.Issues["old/repo#3"] = 40
+	plan.mapping.Comments["old/repo#3"] = 0
+	if n, err := plan.copyComments(cl, issue, 40); err == nil || n != 1 {
+		t.Fatalf("copyComments() = %d, %v, want 1 and an error", n, err)
+	}
+
+	// the rerun reads the progress from the mapping file
+	plan = newPlan()
+	if got := plan.mapping.Comments["old/repo#3"]; got != 1 {
+		t.Fatalf("Comments = %v, want 1 copied", plan.mapping.Comments)
+	}
+	index, n, _, err := plan.createIssue(cl, issue, nil)
+	if err != nil || index != 40 || n != 2 {
+		t.Fatalf("createIssue() = #%d, %d, %v, want #40 and 2 comments", index, n, err)
+	}
+	if len(bodies) != 3 || !strings.Contains(bodies[1], "two") || !strings.Contains(bodies[2], "three") {
+		t.Errorf("comments = %q", bodies)
+	}
+	if plan = newPlan(); len(plan.mapping.Comments) != 0 {
+		t.Errorf("Comments = %v, want none pending", plan.mapping.Comments)
+	}
+}
@@ -29,6 +29,8 @@ type AddIssueLabelsParams struct {
 	Index int `json:"index"`
 	// Labels is a slice of label IDs to add to the issue.
 	Labels []int `json:"labels"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // AddIssueLabelsImpl implements the MCP tool for adding labels to an issue.
@@ -73,6 +75,7 @@ func (AddIssueLabelsImpl) Definition() *mcp.Tool {
 					Description: "Array of label IDs to add to this issue",
 					MinItems:    tools.IntPtr(1),
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "index", "labels"},
 		},
@@ -96,6 +99,12 @@ func (impl AddIssueLabelsImpl) Handler() mcp.ToolHandlerFor[AddIssueLabelsParams
 			Labels: labelIDs,
 		}
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, opt)
+			return res, nil, err
+		}
+
 		labels, _, err := impl.Client.AddIssueLabels(p.Owner, p.Repo, int64(p.Index), opt)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to add labels: %w", err)
@@ -120,6 +129,20 @@ func (impl AddIssueLabelsImpl) Handler() mcp.ToolHandlerFor[AddIssueLabelsParams
 	}
 }
 
+// dryRun previews which labels will be added to the issue.
+func (impl AddIssueLabelsImpl) dryRun(p AddIssueLabelsParams, opt forgejo.IssueLabelsOption) (*mcp.CallToolResult, error) {
+	issue, changes, err := previewIssueLabels(impl.Client, p.Owner, p.Repo, p.Index, opt.Labels, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	preview := fmt.Sprintf("Would add labels to issue #%d (%s) in %s/%s:\n\n%s", p.Index, issue.Title, p.Owner, p.Repo, changes)
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "POST",
+		Endpoint: issueEndpoint(p.Owner, p.Repo, p.Index, "/labels"),
+		Body:     opt,
+	})
+}
+
 // RemoveIssueLabelParams defines the parameters for the remove_issue_label tool.
 // It specifies the issue and the single label ID to be removed.
 type RemoveIssueLabelParams struct {
@@ -131,6 +154,8 @@ type RemoveIssueLabelParams struct {
 	Index int `json:"index"`
 	// Label is the ID of the label to remove from the issue.
 	Label int `json:"label"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // RemoveIssueLabelImpl implements the MCP tool for removing a label from an issue.
@@ -171,6 +196,7 @@ func (RemoveIssueLabelImpl) Definition() *mcp.Tool {
 					Type:        "integer",
 					Description: "Label ID to remove from this issue",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "index", "label"},
 		},
@@ -184,6 +210,12 @@ func (impl RemoveIssueLabelImpl) Handler() mcp.ToolHandlerFor[RemoveIssueLabelPa
 	return func(ctx context.Context, req *mcp.CallToolRequest, args RemoveIssueLabelParams) (*mcp.CallToolResult, any, error) {
 		p := args
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p)
+			return res, nil, err
+		}
+
 		_, err := impl.Client.DeleteIssueLabel(p.Owner, p.Repo, int64(p.Index), int64(p.Label))
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to remove label: %w", err)
@@ -199,6 +231,19 @@ func (impl RemoveIssueLabelImpl) Handler() mcp.ToolHandlerFor[RemoveIssueLabelPa
 	}
 }
 
+// dryRun previews the removal of the label from the issue.
+func (impl RemoveIssueLabelImpl) dryRun(p RemoveIssueLabelParams) (*mcp.CallToolResult, error) {
+	issue, changes, err := previewIssueLabels(impl.Client, p.Owner, p.Repo, p.Index, nil, []int64{int64(p.Label)}, false)
+	if err != nil {
+		return nil, err
+	}
+	preview := fmt.Sprintf("Would remove label from issue #%d (%s) in %s/%s:\n\n%s", p.Index, issue.Title, p.Owner, p.Repo, changes)
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "DELETE",
+		Endpoint: issueEndpoint(p.Owner, p.Repo, p.Index, fmt.Sprintf("/labels/%d", p.Label)),
+	})
+}
+
 // ReplaceIssueLabelsParams defines the parameters for the replace_issue_labels tool.
 // It specifies the issue and the new set of label IDs.
 type ReplaceIssueLabelsParams struct {
@@ -210,25 +255,32 @@ type ReplaceIssueLabelsParams struct {
 	Index int `json:"index"`
 	// Labels is a slice of label IDs that will replace all existing labels on the issue.
 	Labels []int `json:"labels"`
+	// ConfirmToken confirms the operation, see tools.Confirmer.
+	ConfirmToken string `json:"confirm_token,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // ReplaceIssueLabelsImpl implements the MCP tool for replacing all labels on an issue.
 // This is an idempotent operation that uses the Forgejo SDK to set the definitive
-// list of labels for an issue.
+// list of labels for an issue. It is destructive as existing labels not in the
+// new list are removed.
 type ReplaceIssueLabelsImpl struct {
-	Client *tools.Client
+	Client  *tools.Client
+	Confirm *tools.Confirmer
 }
 
 // Definition describes the `replace_issue_labels` tool. It requires the issue's
-// `index` and an array of `labels` (IDs) to apply. It is marked as idempotent.
-func (ReplaceIssueLabelsImpl) Definition() *mcp.Tool {
-	return &mcp.Tool{
+// `index` and an array of `labels` (IDs) to apply. It is marked as idempotent
+// and destructive.
+func (impl ReplaceIssueLabelsImpl) Definition() *mcp.Tool {
+	def := &mcp.Tool{
 		Name:        "replace_issue_labels",
 		Title:       "Replace Issue Labels",
 		Description: "Replace all labels on an issue with a new set of labels.",
 		Annotations: &mcp.ToolAnnotations{
 			ReadOnlyHint:    false,
-			DestructiveHint: tools.BoolPtr(false),
+			DestructiveHint: tools.BoolPtr(true),
 			IdempotentHint:  true,
 		},
 		InputSchema: &jsonschema.Schema{
@@ -253,10 +305,15 @@ func (ReplaceIssueLabelsImpl) Definition() *mcp.Tool {
 					},
 					Description: "Array of label IDs to set on this issue (replaces all existing labels)",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "index", "labels"},
 		},
 	}
+	if impl.Confirm != nil {
+		def.InputSchema.Properties["confirm_token"] = tools.ConfirmTokenSchema()
+	}
+	return def
 }
 
 // Handler implements the logic for replacing issue labels. It calls the Forgejo
@@ -266,6 +323,21 @@ func (impl ReplaceIssueLabelsImpl) Handler() mcp.ToolHandlerFor[ReplaceIssueLabe
 	return func(ctx context.Context, req *mcp.CallToolRequest, args ReplaceIssueLabelsParams) (*mcp.CallToolResult, any, error) {
 		p := args
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p)
+			return res, nil, err
+		}
+
+		// Ask for confirmation if required
+		action := fmt.Sprintf("replace_issue_labels:%s/%s#%d:%v", p.Owner, p.Repo, p.Index, p.Labels)
+		res, err := impl.Confirm.Check(ctx, req, action, p.ConfirmToken, func() (string, error) {
+			return impl.preview(p)
+		})
+		if err != nil || res != nil {
+			return res, nil, err
+		}
+
 		// Convert int labels to int64
 		labelIDs := make([]int64, len(p.Labels))
 		for i, label := range p.Labels {
@@ -299,3 +371,27 @@ func (impl ReplaceIssueLabelsImpl) Handler() mcp.ToolHandlerFor[ReplaceIssueLabe
 		}, nil, nil
 	}
 }
+
+// dryRun previews which labels of the issue will be removed and added.
+func (impl ReplaceIssueLabelsImpl) dryRun(p ReplaceIssueLabelsParams) (*mcp.CallToolResult, error) {
+	issue, changes, err := previewIssueLabels(impl.Client, p.Owner, p.Repo, p.Index, toInt64s(p.Labels), nil, true)
+	if err != nil {
+		return nil, err
+	}
+	preview := fmt.Sprintf("Would replace labels of issue #%d (%s) in %s/%s:\n\n%s", p.Index, issue.Title, p.Owner, p.Repo, changes)
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "PUT",
+		Endpoint: issueEndpoint(p.Owner, p.Repo, p.Index, "/labels"),
+		Body:     forgejo.IssueLabelsOption{Labels: toInt64s(p.Labels)},
+	})
+}
+
+// preview describes which labels of the issue will be removed and added.
+func (impl ReplaceIssueLabelsImpl) preview(p ReplaceIssueLabelsParams) (string, error) {
+	issue, changes, err := previewIssueLabels(impl.Client, p.Owner, p.Repo, p.Index, toInt64s(p.Labels), nil, true)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("About to replace labels of issue #%d (%s) in %s/%s:\n\n%s",
+		p.Index, issue.Title, p.Owner, p.Repo, changes), nil
+}
@@ -0,0 +1,243 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+)
+
+// LockIssueParams defines the parameters for the lock_issue tool.
+// It specifies the issue to lock and an optional reason.
+type LockIssueParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Index is the issue or pull request number.
+	Index int `json:"index"`
+	// Reason is why the conversation is locked, e.g. "Too heated".
+	Reason string `json:"reason,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// LockIssueImpl implements the MCP tool for locking the conversation of an
+// issue or pull request. This is an idempotent operation. Note: This feature
+// is not supported by the official Forgejo SDK and requires a custom HTTP
+// implementation.
+type LockIssueImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `lock_issue` tool. It requires `owner`, `repo` and
+// `index`. It is marked as idempotent.
+func (LockIssueImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "lock_issue",
+		Title:       "Lock Issue",
+		Description: "Lock the conversation of an issue or pull request so only collaborators can comment, optionally with a reason. Not available on Forgejo 11 or earlier, whose API cannot lock issues.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"index": {
+					Type:        "integer",
+					Description: "Issue or pull request index number",
+				},
+				"reason": {
+					Type:        "string",
+					Description: "Reason for locking, one of the reasons configured on the server, by default 'Too heated', 'Off-topic', 'Resolved' or 'Spam' (optional)",
+				},
+				"dry_run": tools.DryRunSchema(),
+			},
+			Required: []string{"owner", "repo", "index"},
+		},
+	}
+}
+
+// Handler implements the logic for locking an issue. It performs a custom HTTP
+// PUT request to the `/repos/{owner}/{repo}/issues/{index}/lock` endpoint.
+func (impl LockIssueImpl) Handler() mcp.ToolHandlerFor[LockIssueParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args LockIssueParams) (*mcp.CallToolResult, any, error) {
+		p := args
+		if err := impl.Client.CheckAPI(tools.APIIssueLock); err != nil {
+			return nil, nil, err
+		}
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p)
+			return res, nil, err
+		}
+
+		err := impl.Client.MyLockIssue(p.Owner, p.Repo, int64(p.Index), p.Reason)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to lock issue: %w", err)
+		}
+
+		text := fmt.Sprintf("Conversation of %s/%s#%d locked.", p.Owner, p.Repo, p.Index)
+		if p.Reason != "" {
+			text = fmt.Sprintf("Conversation of %s/%s#%d locked as %s.", p.Owner, p.Repo, p.Index, p.Reason)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: text,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// dryRun checks that the issue exists and previews the lock.
+func (impl LockIssueImpl) dryRun(p LockIssueParams) (*mcp.CallToolResult, error) {
+	issue, _, err := impl.Client.GetIssue(p.Owner, p.Repo, int64(p.Index))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+
+	preview := "Would lock the conversation of " + issueRef(p.Owner, p.Repo, issue)
+	if p.Reason != "" {
+		preview += " as " + p.Reason
+	}
+	if issue.IsLocked {
+		preview += "\n\nThe conversation is already locked."
+	}
+
+	var body any
+	if p.Reason != "" {
+		body = map[string]string{"lock_reason": p.Reason}
+	}
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "PUT",
+		Endpoint: issueEndpoint(p.Owner, p.Repo, p.Index, "/lock"),
+		Body:     body,
+	})
+}
+
+// UnlockIssueParams defines the parameters for the unlock_issue tool.
+// It specifies the issue to unlock.
+type UnlockIssueParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Index is the issue or pull request number.
+	Index int `json:"index"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// UnlockIssueImpl implements the MCP tool for unlocking the conversation of an
+// issue or pull request. This is an idempotent operation. Note: This feature
+// is not supported by the official Forgejo SDK and requires a custom HTTP
+// implementation.
+type UnlockIssueImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `unlock_issue` tool. It requires `owner`, `repo`
+// and `index`. It is marked as idempotent.
+func (UnlockIssueImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "unlock_issue",
+		Title:       "Unlock Issue",
+		Description: "Unlock the conversation of an issue or pull request so everyone can comment again. Not available on Forgejo 11 or earlier, whose API cannot unlock issues.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"index": {
+					Type:        "integer",
+					Description: "Issue or pull request index number",
+				},
+				"dry_run": tools.DryRunSchema(),
+			},
+			Required: []string{"owner", "repo", "index"},
+		},
+	}
+}
+
+// Handler implements the logic for unlocking an issue. It performs a custom
+// HTTP DELETE request to the `/repos/{owner}/{repo}/issues/{index}/lock`
+// endpoint.
+func (impl UnlockIssueImpl) Handler() mcp.ToolHandlerFor[UnlockIssueParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args UnlockIssueParams) (*mcp.CallToolResult, any, error) {
+		p := args
+		if err := impl.Client.CheckAPI(tools.APIIssueLock); err != nil {
+			return nil, nil, err
+		}
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p)
+			return res, nil, err
+		}
+
+		err := impl.Client.MyUnlockIssue(p.Owner, p.Repo, int64(p.Index))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to unlock issue: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("Conversation of %s/%s#%d unlocked.", p.Owner, p.Repo, p.Index),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// dryRun checks that the issue exists and previews the unlock.
+func (impl UnlockIssueImpl) dryRun(p UnlockIssueParams) (*mcp.CallToolResult, error) {
+	issue, _, err := impl.Client.GetIssue(p.Owner, p.Repo, int64(p.Index))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+
+	preview := "Would unlock the conversation of " + issueRef(p.Owner, p.Repo, issue)
+	if !issue.IsLocked {
+		preview += "\n\nThe conversation is not locked."
+	}
+
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "DELETE",
+		Endpoint: issueEndpoint(p.Owner, p.Repo, p.Index, "/lock"),
+	})
+}
@@ -0,0 +1,370 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+)
+
+// MoveIssueParams defines the parameters for the move_issue tool. It
+// specifies the issue to move and the target repository.
+type MoveIssueParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Index is the issue number.
+	Index int `json:"index"`
+	// TargetOwner is the owner of the repository to move the issue to.
+	TargetOwner string `json:"target_owner"`
+	// TargetRepo is the name of the repository to move the issue to.
+	TargetRepo string `json:"target_repo"`
+	// KeepOpen leaves the original issue open after moving.
+	KeepOpen bool `json:"keep_open,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// MoveIssueImpl implements the MCP tool for moving an issue to another
+// repository. Forgejo has no API to transfer issues, so the issue is
+// recreated in the target repository with its comments and attachments, and
+// the original is closed with a link to the copy. This is not idempotent:
+// every call creates a new issue.
+type MoveIssueImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `move_issue` tool. It requires `owner`, `repo`,
+// `index`, `target_owner` and `target_repo`. It is not idempotent.
+func (MoveIssueImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "move_issue",
+		Title:       "Move Issue",
+		Description: "Move an issue to another repository. The issue is recreated in the target repository with its body, comments (quoted with original author and time), labels and milestone matched by name, due date, state and attachments; then the original gets a link to the new issue and is closed. Use dry_run to preview what will be copied.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  false,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"index": {
+					Type:        "integer",
+					Description: "Issue index number",
+				},
+				"target_owner": {
+					Type:        "string",
+					Description: "Owner of the repository to move the issue to",
+				},
+				"target_repo": {
+					Type:        "string",
+					Description: "Name of the repository to move the issue to",
+				},
+				"keep_open": {
+					Type:        "boolean",
+					Description: "Leave the original issue open, only adding a link to the new issue (optional)",
+				},
+				"dry_run": tools.DryRunSchema(),
+			},
+			Required: []string{"owner", "repo", "index", "target_owner", "target_repo"},
+		},
+	}
+}
+
+// Handler implements the logic for moving an issue. It creates the new issue
+// with the Forgejo SDK's `CreateIssue` function, copies attachments and
+// comments, then links and closes the original with `CreateIssueComment` and
+// `EditIssue`.
+func (impl MoveIssueImpl) Handler() mcp.ToolHandlerFor[MoveIssueParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args MoveIssueParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		plan, err := planMove(impl.Client, p)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, plan)
+			return res, nil, err
+		}
+
+		text, err := impl.move(p, plan)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: text,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// move executes plan and describes the result. Errors after the new issue
+// is created mention it, so a partial move can be completed by hand.
+func (impl MoveIssueImpl) move(p MoveIssueParams, plan *movePlan) (string, error) {
+	cl := impl.Client
+	created, _, err := cl.CreateIssue(p.TargetOwner, p.TargetRepo, plan.Opt)
+	if err != nil {
+		return "", fmt.Errorf("failed to create issue in %s/%s: %w", p.TargetOwner, p.TargetRepo, err)
+	}
+	target := fmt.Sprintf("%s/%s#%d", p.TargetOwner, p.TargetRepo, created.Index)
+	partial := func(step string, err error) error {
+		return fmt.Errorf("created %s, but failed to %s: %w", target, step, err)
+	}
+
+	// Re-upload attachments and point the body to the copies
+	body := plan.Opt.Body
+	for _, a := range plan.Attachments {
+		data, err := cl.MyDownloadAttachment(a)
+		if err != nil {
+			return "", partial("download attachment "+a.Name, err)
+		}
+		copied, err := cl.MyCreateIssueAttachment(p.TargetOwner, p.TargetRepo, created.Index, a.Name, bytes.NewReader(data))
+		if err != nil {
+			return "", partial("upload attachment "+a.Name, err)
+		}
+		body = strings.ReplaceAll(body, a.DownloadURL, copied.DownloadURL)
+	}
+	if body != plan.Opt.Body {
+		_, _, err := cl.EditIssue(p.TargetOwner, p.TargetRepo, created.Index, forgejo.EditIssueOption{Body: &body})
+		if err != nil {
+			return "", partial("update attachment links", err)
+		}
+	}
+
+	for _, c := range plan.Comments {
+		_, _, err := cl.CreateIssueComment(p.TargetOwner, p.TargetRepo, created.Index, forgejo.CreateIssueCommentOption{
+			Body: quoteComment(c),
+		})
+		if err != nil {
+			return "", partial(fmt.Sprintf("copy comment %d", c.ID), err)
+		}
+	}
+
+	// Link and close the original
+	_, _, err = cl.CreateIssueComment(p.Owner, p.Repo, int64(p.Index), forgejo.CreateIssueCommentOption{
+		Body: "Moved to " + target,
+	})
+	if err != nil {
+		return "", partial("link the original issue", err)
+	}
+	closed := ""
+	if !p.KeepOpen && plan.Issue.State != forgejo.StateClosed {
+		state := forgejo.StateClosed
+		_, _, err := cl.EditIssue(p.Owner, p.Repo, int64(p.Index), forgejo.EditIssueOption{State: &state})
+		if err != nil {
+			return "", partial("close the original issue", err)
+		}
+		closed = " and closed"
+	}
+
+	text := fmt.Sprintf("Moved %s to %s with %d comments and %d attachments. The original was linked%s.",
+		issueRef(p.Owner, p.Repo, plan.Issue), target, len(plan.Comments), len(plan.Attachments), closed)
+	if created.HTMLURL != "" {
+		text += "\n\n" + created.HTMLURL
+	}
+	if len(plan.Notes) > 0 {
+		text += "\n\n" + strings.Join(plan.Notes, "\n")
+	}
+	return text, nil
+}
+
+// dryRun previews the issue to be created and the requests of the move.
+func (impl MoveIssueImpl) dryRun(p MoveIssueParams, plan *movePlan) (*mcp.CallToolResult, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Would move %s to %s/%s:\n\n", issueRef(p.Owner, p.Repo, plan.Issue), p.TargetOwner, p.TargetRepo)
+	fmt.Fprintf(&b, "- **Labels**: %s\n", orNone(strings.Join(plan.LabelNames, ", ")))
+	fmt.Fprintf(&b, "- **Milestone**: %s\n", orNone(plan.MilestoneTitle))
+	fmt.Fprintf(&b, "- **Comments**: %d\n", len(plan.Comments))
+	fmt.Fprintf(&b, "- **Attachments**: %d\n", len(plan.Attachments))
+	switch {
+	case p.KeepOpen:
+		b.WriteString("- **Original**: linked, kept open\n")
+	case plan.Issue.State == forgejo.StateClosed:
+		b.WriteString("- **Original**: linked, already closed\n")
+	default:
+		b.WriteString("- **Original**: linked and closed\n")
+	}
+	if len(plan.Notes) > 0 {
+		b.WriteString("\n" + strings.Join(plan.Notes, "\n") + "\n")
+	}
+	b.WriteString("\n" + plan.Opt.Body)
+
+	targetEndpoint := fmt.Sprintf("/api/v1/repos/%s/%s/issues", p.TargetOwner, p.TargetRepo)
+	reqs := []tools.APIRequest{{Method: "POST", Endpoint: targetEndpoint, Body: plan.Opt}}
+	if len(plan.Attachments) > 0 {
+		reqs = append(reqs, tools.APIRequest{Method: "POST", Endpoint: targetEndpoint + "/{new_index}/assets"})
+	}
+	if len(plan.Comments) > 0 {
+		reqs = append(reqs, tools.APIRequest{Method: "POST", Endpoint: targetEndpoint + "/{new_index}/comments"})
+	}
+	reqs = append(reqs, tools.APIRequest{Method: "POST", Endpoint: issueEndpoint(p.Owner, p.Repo, p.Index, "/comments")})
+	if !p.KeepOpen && plan.Issue.State != forgejo.StateClosed {
+		reqs = append(reqs, tools.APIRequest{
+			Method:   "PATCH",
+			Endpoint: issueEndpoint(p.Owner, p.Repo, p.Index, ""),
+			Body:     map[string]string{"state": "closed"},
+		})
+	}
+
+	return impl.Client.DryRunResult(b.String(), reqs...)
+}
+
+// movePlan is everything needed to recreate an issue in another repository.
+type movePlan struct {
+	Issue       *forgejo.Issue
+	Comments    []*forgejo.Comment
+	Attachments []*forgejo.Attachment
+	// Opt creates the new issue.
+	Opt            forgejo.CreateIssueOption
+	LabelNames     []string
+	MilestoneTitle string
+	// Notes describe what could not be copied.
+	Notes []string
+}
+
+// planMove fetches the issue with its comments and attachments, and maps its
+// labels and milestone to the target repository by name.
+func planMove(cl *tools.Client, p MoveIssueParams) (*movePlan, error) {
+	if strings.EqualFold(p.Owner, p.TargetOwner) && strings.EqualFold(p.Repo, p.TargetRepo) {
+		return nil, fmt.Errorf("the issue is already in %s/%s", p.TargetOwner, p.TargetRepo)
+	}
+
+	issue, _, err := cl.GetIssue(p.Owner, p.Repo, int64(p.Index))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+	if issue.PullRequest != nil {
+		return nil, fmt.Errorf("#%d is a pull request, only issues can be moved", p.Index)
+	}
+	if _, _, err := cl.GetRepo(p.TargetOwner, p.TargetRepo); err != nil {
+		return nil, fmt.Errorf("failed to get target repository %s/%s: %w", p.TargetOwner, p.TargetRepo, err)
+	}
+
+	comments, _, err := cl.ListIssueComments(p.Owner, p.Repo, int64(p.Index), forgejo.ListIssueCommentOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+	attachments, err := cl.MyListIssueAttachments(p.Owner, p.Repo, int64(p.Index))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+
+	plan := &movePlan{
+		Issue:       issue,
+		Comments:    comments,
+		Attachments: attachments,
+		Opt: forgejo.CreateIssueOption{
+			Title:    issue.Title,
+			Body:     movedBody(p.Owner, p.Repo, issue),
+			Deadline: issue.Deadline,
+			Closed:   issue.State == forgejo.StateClosed,
+		},
+	}
+
+	if len(issue.Labels) > 0 {
+		labels, _, err := cl.ListRepoLabels(p.TargetOwner, p.TargetRepo, forgejo.ListLabelsOptions{
+			ListOptions: forgejo.ListOptions{Page: -1},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list labels of %s/%s: %w", p.TargetOwner, p.TargetRepo, err)
+		}
+		byName := make(map[string]*forgejo.Label, len(labels))
+		for _, l := range labels {
+			byName[strings.ToLower(l.Name)] = l
+		}
+		for _, l := range issue.Labels {
+			target, ok := byName[strings.ToLower(l.Name)]
+			if !ok {
+				plan.Notes = append(plan.Notes, fmt.Sprintf("Label %q does not exist in %s/%s and is not copied.", l.Name, p.TargetOwner, p.TargetRepo))
+				continue
+			}
+			plan.Opt.Labels = append(plan.Opt.Labels, target.ID)
+			plan.LabelNames = append(plan.LabelNames, target.Name)
+		}
+	}
+
+	if issue.Milestone != nil {
+		m, _, err := cl.GetMilestoneByName(p.TargetOwner, p.TargetRepo, issue.Milestone.Title)
+		if err != nil || m == nil {
+			plan.Notes = append(plan.Notes, fmt.Sprintf("Milestone %q does not exist in %s/%s and is not copied.", issue.Milestone.Title, p.TargetOwner, p.TargetRepo))
+		} else {
+			plan.Opt.Milestone = m.ID
+			plan.MilestoneTitle = m.Title
+		}
+	}
+
+	if len(issue.Assignees) > 0 {
+		plan.Notes = append(plan.Notes, "Assignees are not copied, assign the new issue if needed.")
+	}
+	return plan, nil
+}
+
+// authorName returns the username of u, or original for issues and comments
+// migrated from other services.
+func authorName(u *forgejo.User, original string) string {
+	if u != nil && u.UserName != "" {
+		return u.UserName
+	}
+	if original != "" {
+		return original
+	}
+	return "ghost"
+}
+
+// moveTime formats timestamps of moved issues and comments.
+func moveTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04 UTC")
+}
+
+// movedBody returns the body of the moved issue: a note about its origin
+// followed by the original body. Authors are named in bold, not mentioned, so
+// recreating the issue does not notify them.
+func movedBody(owner, repo string, issue *forgejo.Issue) string {
+	body := fmt.Sprintf("_Moved from %s/%s#%d, originally opened by **%s** on %s._",
+		owner, repo, issue.Index, authorName(issue.Poster, issue.OriginalAuthor), moveTime(issue.Created))
+	if strings.TrimSpace(issue.Body) != "" {
+		body += "\n\n" + issue.Body
+	}
+	return body
+}
+
+// quoteComment renders a comment for the moved issue, quoted below a line
+// with its original author, in bold like movedBody, and time.
+func quoteComment(c *forgejo.Comment) string {
+	lines := strings.Split(strings.TrimRight(c.Body, "\n"), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight("> "+l, " ")
+	}
+	return fmt.Sprintf("**%s** commented on %s:\n\n%s",
+		authorName(c.Poster, c.OriginalAuthor), moveTime(c.Created), strings.Join(lines, "\n"))
+}
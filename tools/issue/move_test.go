@@ -0,0 +1,55 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+)
+
+func TestMovedBody(t *testing.T) {
+	issue := &forgejo.Issue{
+		Index:   12,
+		Body:    "Login fails with 500.",
+		Poster:  &forgejo.User{UserName: "alice"},
+		Created: time.Date(2024, 1, 15, 22, 30, 0, 0, time.FixedZone("UTC+8", 8*3600)),
+	}
+
+	want := "_Moved from owner/repo#12, originally opened by **alice** on 2024-01-15 14:30 UTC._\n\nLogin fails with 500."
+	if got := movedBody("owner", "repo", issue); got != want {
+		t.Errorf("movedBody() =\n%s\nwant\n%s", got, want)
+	}
+
+	issue.Body = ""
+	issue.Poster = nil
+	issue.OriginalAuthor = "bob"
+	want = "_Moved from owner/repo#12, originally opened by **bob** on 2024-01-15 14:30 UTC._"
+	if got := movedBody("owner", "repo", issue); got != want {
+		t.Errorf("movedBody() without body =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestQuoteComment(t *testing.T) {
+	c := &forgejo.Comment{
+		Body:    "Reproduced.\n\n```\npanic: boom\n```\n",
+		Poster:  &forgejo.User{UserName: "carol"},
+		Created: time.Date(2024, 1, 16, 9, 5, 0, 0, time.UTC),
+	}
+
+	want := "**carol** commented on 2024-01-16 09:05 UTC:\n\n> Reproduced.\n>\n> ```\n> panic: boom\n> ```"
+	if got := quoteComment(c); got != want {
+		t.Errorf("quoteComment() =\n%s\nwant\n%s", got, want)
+	}
+
+	c.Poster = nil
+	if got := quoteComment(c); !strings.HasPrefix(got, "**ghost** ") {
+		t.Errorf("quoteComment() without author = %q", got)
+	}
+}
@@ -0,0 +1,445 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// ListPinnedIssuesParams defines the parameters for the list_pinned_issues tool.
+// It specifies the repository and whether to list issues or pull requests.
+type ListPinnedIssuesParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Type selects pinned issues ("issue") or pull requests ("pr").
+	Type string `json:"type,omitempty"`
+}
+
+// ListPinnedIssuesImpl implements the read-only MCP tool for listing the
+// pinned issues or pull requests of a repository. This is a safe, idempotent
+// operation. Note: This feature is not supported by the official Forgejo SDK
+// and requires a custom HTTP implementation.
+type ListPinnedIssuesImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `list_pinned_issues` tool. It requires `owner` and
+// `repo`. It is marked as a safe, read-only operation.
+func (ListPinnedIssuesImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "list_pinned_issues",
+		Title:       "List Pinned Issues",
+		Description: "List the pinned issues or pull requests of a repository in pin order. The numbers are the positions used by move_issue_pin.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"type": {
+					Type:        "string",
+					Description: "List pinned issues ('issue') or pull requests ('pr') (optional, defaults to 'issue')",
+					Enum:        []any{"issue", "pr"},
+				},
+			},
+			Required: []string{"owner", "repo"},
+		},
+	}
+}
+
+// Handler implements the logic for listing pinned issues. It performs a custom
+// HTTP GET request to the `/repos/{owner}/{repo}/issues/pinned` or
+// `/repos/{owner}/{repo}/pulls/pinned` endpoint and formats the results into
+// a numbered markdown list.
+func (impl ListPinnedIssuesImpl) Handler() mcp.ToolHandlerFor[ListPinnedIssuesParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args ListPinnedIssuesParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		var issues []*forgejo.Issue
+		var err error
+		switch p.Type {
+		case "", "issue":
+			issues, err = impl.Client.MyListPinnedIssues(p.Owner, p.Repo)
+		case "pr":
+			issues, err = listPinnedPulls(impl.Client, p.Owner, p.Repo)
+		default:
+			return nil, nil, fmt.Errorf("invalid type %q, must be 'issue' or 'pr'", p.Type)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list pinned issues: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: types.PinnedIssueList(issues).ToMarkdown(),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// listPinnedPulls lists the pinned pull requests of a repository as issues,
+// so they can be rendered like pinned issues.
+func listPinnedPulls(cl *tools.Client, owner, repo string) ([]*forgejo.Issue, error) {
+	pulls, err := cl.MyListPinnedPullRequests(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*forgejo.Issue, 0, len(pulls))
+	for _, pr := range pulls {
+		if pr == nil {
+			continue
+		}
+		issue := &forgejo.Issue{
+			Index:       pr.Index,
+			Title:       pr.Title,
+			State:       pr.State,
+			Poster:      pr.Poster,
+			Labels:      pr.Labels,
+			Assignees:   pr.Assignees,
+			Comments:    pr.Comments,
+			PullRequest: &forgejo.PullRequestMeta{HasMerged: pr.HasMerged, Merged: pr.Merged},
+		}
+		if pr.Updated != nil {
+			issue.Updated = *pr.Updated
+		}
+		ret = append(ret, issue)
+	}
+	return ret, nil
+}
+
+// pinnedPosition returns the pin position of an issue or pull request,
+// starting from 1, and the number of pinned items of the same kind. The
+// position is 0 if it is not pinned.
+func pinnedPosition(cl *tools.Client, owner, repo string, issue *forgejo.Issue) (int, int, error) {
+	var pinned []*forgejo.Issue
+	var err error
+	if issue.PullRequest != nil {
+		pinned, err = listPinnedPulls(cl, owner, repo)
+	} else {
+		pinned, err = cl.MyListPinnedIssues(owner, repo)
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list pinned issues: %w", err)
+	}
+
+	idx := slices.IndexFunc(pinned, func(i *forgejo.Issue) bool { return i != nil && i.Index == issue.Index })
+	return idx + 1, len(pinned), nil
+}
+
+// IssuePinParams defines the parameters for the pin_issue and unpin_issue
+// tools. It specifies the issue.
+type IssuePinParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Index is the issue or pull request number.
+	Index int `json:"index"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// pinSchema returns the input schema shared by the pin_issue and unpin_issue
+// tools.
+func pinSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"owner": {
+				Type:        "string",
+				Description: "Repository owner (username or organization name)",
+			},
+			"repo": {
+				Type:        "string",
+				Description: "Repository name",
+			},
+			"index": {
+				Type:        "integer",
+				Description: "Issue or pull request index number",
+			},
+			"dry_run": tools.DryRunSchema(),
+		},
+		Required: []string{"owner", "repo", "index"},
+	}
+}
+
+// pinDryRun previews pinning (pin is true) or unpinning an issue.
+func pinDryRun(cl *tools.Client, p IssuePinParams, pin bool) (*mcp.CallToolResult, error) {
+	issue, _, err := cl.GetIssue(p.Owner, p.Repo, int64(p.Index))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+	pos, count, err := pinnedPosition(cl, p.Owner, p.Repo, issue)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := issueRef(p.Owner, p.Repo, issue)
+	method := "POST"
+	var preview string
+	switch {
+	case pin && pos > 0:
+		preview = fmt.Sprintf("%s is already pinned at position %d, nothing would change", ref, pos)
+	case pin:
+		preview = fmt.Sprintf("Would pin %s at position %d", ref, count+1)
+	case pos > 0:
+		method = "DELETE"
+		preview = fmt.Sprintf("Would unpin %s from position %d", ref, pos)
+	default:
+		method = "DELETE"
+		preview = fmt.Sprintf("%s is not pinned, nothing would change", ref)
+	}
+
+	return cl.DryRunResult(preview, tools.APIRequest{
+		Method:   method,
+		Endpoint: issueEndpoint(p.Owner, p.Repo, p.Index, "/pin"),
+	})
+}
+
+// PinIssueImpl implements the MCP tool for pinning an issue or pull request.
+// This is an idempotent operation. Note: This feature is not supported by the
+// official Forgejo SDK and requires a custom HTTP implementation.
+type PinIssueImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `pin_issue` tool. It requires `owner`, `repo` and
+// `index`. It is marked as idempotent.
+func (PinIssueImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "pin_issue",
+		Title:       "Pin Issue",
+		Description: "Pin an issue or pull request to the top of the repository's list. It is added after the pinned ones, use move_issue_pin to reorder.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  true,
+		},
+		InputSchema: pinSchema(),
+	}
+}
+
+// Handler implements the logic for pinning an issue. It performs a custom HTTP
+// POST request to the `/repos/{owner}/{repo}/issues/{index}/pin` endpoint.
+func (impl PinIssueImpl) Handler() mcp.ToolHandlerFor[IssuePinParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args IssuePinParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := pinDryRun(impl.Client, p, true)
+			return res, nil, err
+		}
+
+		err := impl.Client.MyPinIssue(p.Owner, p.Repo, int64(p.Index))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to pin issue: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("%s/%s#%d pinned.", p.Owner, p.Repo, p.Index),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// UnpinIssueImpl implements the MCP tool for unpinning an issue or pull
+// request. This is an idempotent operation. Note: This feature is not
+// supported by the official Forgejo SDK and requires a custom HTTP
+// implementation.
+type UnpinIssueImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `unpin_issue` tool. It requires `owner`, `repo` and
+// `index`. It is marked as idempotent.
+func (UnpinIssueImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "unpin_issue",
+		Title:       "Unpin Issue",
+		Description: "Unpin an issue or pull request. The pinned items after it move up by one position.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  true,
+		},
+		InputSchema: pinSchema(),
+	}
+}
+
+// Handler implements the logic for unpinning an issue. It performs a custom
+// HTTP DELETE request to the `/repos/{owner}/{repo}/issues/{index}/pin`
+// endpoint.
+func (impl UnpinIssueImpl) Handler() mcp.ToolHandlerFor[IssuePinParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args IssuePinParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := pinDryRun(impl.Client, p, false)
+			return res, nil, err
+		}
+
+		err := impl.Client.MyUnpinIssue(p.Owner, p.Repo, int64(p.Index))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to unpin issue: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("%s/%s#%d unpinned.", p.Owner, p.Repo, p.Index),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// MoveIssuePinParams defines the parameters for the move_issue_pin tool.
+// It specifies the pinned issue and its new position.
+type MoveIssuePinParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Index is the issue or pull request number.
+	Index int `json:"index"`
+	// Position is the new pin position, starting from 1.
+	Position int `json:"position"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// MoveIssuePinImpl implements the MCP tool for reordering pinned issues or
+// pull requests. This is an idempotent operation. Note: This feature is not
+// supported by the official Forgejo SDK and requires a custom HTTP
+// implementation.
+type MoveIssuePinImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `move_issue_pin` tool. It requires `owner`, `repo`,
+// `index` and `position`. It is marked as idempotent.
+func (MoveIssuePinImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "move_issue_pin",
+		Title:       "Move Issue Pin",
+		Description: "Move a pinned issue or pull request to another position in the pinned list. Positions start from 1, as shown by list_pinned_issues.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"index": {
+					Type:        "integer",
+					Description: "Index number of the pinned issue or pull request",
+				},
+				"position": {
+					Type:        "integer",
+					Description: "New position in the pinned list, starting from 1",
+					Minimum:     tools.Float64Ptr(1),
+				},
+				"dry_run": tools.DryRunSchema(),
+			},
+			Required: []string{"owner", "repo", "index", "position"},
+		},
+	}
+}
+
+// Handler implements the logic for moving a pinned issue. It performs a custom
+// HTTP PATCH request to the `/repos/{owner}/{repo}/issues/{index}/pin/{position}`
+// endpoint.
+func (impl MoveIssuePinImpl) Handler() mcp.ToolHandlerFor[MoveIssuePinParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args MoveIssuePinParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		if p.Position < 1 {
+			return nil, nil, fmt.Errorf("position must be at least 1")
+		}
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p)
+			return res, nil, err
+		}
+
+		err := impl.Client.MyMoveIssuePin(p.Owner, p.Repo, int64(p.Index), int64(p.Position))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to move issue pin: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("%s/%s#%d moved to pin position %d.", p.Owner, p.Repo, p.Index, p.Position),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// dryRun checks that the issue is pinned and the position is valid, and
+// previews the move.
+func (impl MoveIssuePinImpl) dryRun(p MoveIssuePinParams) (*mcp.CallToolResult, error) {
+	issue, _, err := impl.Client.GetIssue(p.Owner, p.Repo, int64(p.Index))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+	pos, count, err := pinnedPosition(impl.Client, p.Owner, p.Repo, issue)
+	if err != nil {
+		return nil, err
+	}
+	ref := issueRef(p.Owner, p.Repo, issue)
+	if pos == 0 {
+		return nil, fmt.Errorf("%s is not pinned", ref)
+	}
+	if p.Position > count {
+		return nil, fmt.Errorf("position %d is out of range, %d items are pinned", p.Position, count)
+	}
+
+	return impl.Client.DryRunResult(fmt.Sprintf("Would move %s from pin position %d to %d", ref, pos, p.Position), tools.APIRequest{
+		Method:   "PATCH",
+		Endpoint: issueEndpoint(p.Owner, p.Repo, p.Index, fmt.Sprintf("/pin/%d", p.Position)),
+	})
+}
@@ -0,0 +1,161 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"fmt"
+	"strings"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+)
+
+// Helpers shared by the dry-run previews of issue tools.
+
+// resolveIssueMeta checks that the referenced labels, milestone and assignees
+// exist, and renders them as a markdown list. Zero values are skipped.
+func resolveIssueMeta(cl *tools.Client, owner, repo string, labelIDs []int64, milestoneID int64, assignees []string) (string, error) {
+	var b strings.Builder
+
+	if len(assignees) > 0 {
+		if _, err := cl.ResolveUsers(assignees); err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "- **Assignees**: %s\n", strings.Join(assignees, ", "))
+	}
+
+	if len(labelIDs) > 0 {
+		labels, err := cl.ResolveLabels(owner, repo, labelIDs)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "- **Labels**: %s\n", labelNames(labels))
+	}
+
+	if milestoneID > 0 {
+		m, _, err := cl.GetMilestone(owner, repo, milestoneID)
+		if err != nil {
+			return "", fmt.Errorf("milestone %d not found in %s/%s: %w", milestoneID, owner, repo, err)
+		}
+		fmt.Fprintf(&b, "- **Milestone**: %s\n", m.Title)
+	}
+
+	return b.String(), nil
+}
+
+// labelNames joins the names of labels with comma.
+func labelNames(labels []*forgejo.Label) string {
+	names := make([]string, len(labels))
+	for i, l := range labels {
+		names[i] = l.Name
+	}
+	return orNone(strings.Join(names, ", "))
+}
+
+// orNone returns s, or "(none)" if s is empty.
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+// issueRef describes an issue in previews, e.g. "owner/repo#12 (Fix login bug)".
+func issueRef(owner, repo string, issue *forgejo.Issue) string {
+	return fmt.Sprintf("%s/%s#%d (%s)", owner, repo, issue.Index, issue.Title)
+}
+
+// issueEndpoint returns the API endpoint of an issue, followed by suffix.
+func issueEndpoint(owner, repo string, index int, suffix string) string {
+	return fmt.Sprintf("/api/v1/repos/%s/%s/issues/%d%s", owner, repo, index, suffix)
+}
+
+// previewIssueLabels checks that the labels in add and remove exist, and
+// describes how the labels of an issue change when add are added and remove
+// are removed. If replace is true, every current label not in add is removed.
+func previewIssueLabels(cl *tools.Client, owner, repo string, index int, add, remove []int64, replace bool) (*forgejo.Issue, string, error) {
+	issue, _, err := cl.GetIssue(owner, repo, int64(index))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get issue: %w", err)
+	}
+	if _, err := cl.ResolveLabels(owner, repo, remove); err != nil {
+		return nil, "", err
+	}
+	added, err := cl.ResolveLabels(owner, repo, add)
+	if err != nil {
+		return nil, "", err
+	}
+
+	adding := map[int64]bool{}
+	for _, id := range add {
+		adding[id] = true
+	}
+	removing := map[int64]bool{}
+	for _, id := range remove {
+		removing[id] = true
+	}
+
+	current := map[int64]bool{}
+	var removedList, keptList []*forgejo.Label
+	for _, l := range issue.Labels {
+		current[l.ID] = true
+		if removing[l.ID] || (replace && !adding[l.ID]) {
+			removedList = append(removedList, l)
+		} else {
+			keptList = append(keptList, l)
+		}
+	}
+	var addedList []*forgejo.Label
+	for _, l := range added {
+		if !current[l.ID] {
+			addedList = append(addedList, l)
+		}
+	}
+
+	changes := fmt.Sprintf("- **Removed**: %s\n- **Added**: %s\n- **Kept**: %s",
+		labelNames(removedList), labelNames(addedList), labelNames(keptList))
+	return issue, changes, nil
+}
+
+// toInt64s converts IDs from tool arguments to int64.
+func toInt64s(ids []int) []int64 {
+	ret := make([]int64, len(ids))
+	for i, id := range ids {
+		ret[i] = int64(id)
+	}
+	return ret
+}
+
+// findIssueAttachment fetches an attachment of an issue by ID.
+func findIssueAttachment(cl *tools.Client, owner, repo string, index int, id int64) (*forgejo.Attachment, error) {
+	attachments, err := cl.MyListIssueAttachments(owner, repo, int64(index))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issue attachments: %w", err)
+	}
+	for _, a := range attachments {
+		if a.ID == id {
+			return a, nil
+		}
+	}
+	return nil, fmt.Errorf("attachment %d not found in issue #%d", id, index)
+}
+
+// previewDependency checks that both issues exist and describes the
+// relationship where issue blocker blocks issue blocked.
+func previewDependency(cl *tools.Client, owner, repo string, blocker, blocked int) (string, error) {
+	blockerIssue, _, err := cl.GetIssue(owner, repo, int64(blocker))
+	if err != nil {
+		return "", fmt.Errorf("failed to get issue #%d: %w", blocker, err)
+	}
+	blockedIssue, _, err := cl.GetIssue(owner, repo, int64(blocked))
+	if err != nil {
+		return "", fmt.Errorf("failed to get issue #%d: %w", blocked, err)
+	}
+	return fmt.Sprintf("issue #%d (%s) blocks issue #%d (%s) in %s/%s",
+		blocker, blockerIssue.Title, blocked, blockedIssue.Title, owner, repo), nil
+}
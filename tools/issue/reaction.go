@@ -0,0 +1,524 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// reactionContents are the reactions Forgejo accepts by default.
+var reactionContents = []any{"+1", "-1", "laugh", "hooray", "confused", "heart", "rocket", "eyes"}
+
+// reactionTarget selects an issue or a comment to react to.
+type reactionTarget struct {
+	Owner     string
+	Repo      string
+	Index     int
+	CommentID int
+}
+
+// validate checks that exactly one of index and comment_id is given.
+func (t reactionTarget) validate() error {
+	if (t.Index > 0) == (t.CommentID > 0) {
+		return errors.New("exactly one of index and comment_id is required")
+	}
+	return nil
+}
+
+// String describes the target, e.g. "issue #1 in owner/repo".
+func (t reactionTarget) String() string {
+	if t.CommentID > 0 {
+		return fmt.Sprintf("comment %d in %s/%s", t.CommentID, t.Owner, t.Repo)
+	}
+	return fmt.Sprintf("issue #%d in %s/%s", t.Index, t.Owner, t.Repo)
+}
+
+// endpoint returns the reactions endpoint of the target.
+func (t reactionTarget) endpoint() string {
+	if t.CommentID > 0 {
+		return fmt.Sprintf("/api/v1/repos/%s/%s/issues/comments/%d/reactions", t.Owner, t.Repo, t.CommentID)
+	}
+	return issueEndpoint(t.Owner, t.Repo, t.Index, "/reactions")
+}
+
+// list fetches the reactions on the target.
+func (t reactionTarget) list(cl *tools.Client) (types.ReactionList, error) {
+	var reactions []*forgejo.Reaction
+	var err error
+	if t.CommentID > 0 {
+		reactions, _, err = cl.GetIssueCommentReactions(t.Owner, t.Repo, int64(t.CommentID))
+	} else {
+		reactions, _, err = cl.GetIssueReactions(t.Owner, t.Repo, int64(t.Index))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reactions: %w", err)
+	}
+	return reactions, nil
+}
+
+// reactionProperties returns the schema properties selecting a reaction
+// target, merged with extra.
+func reactionProperties(extra map[string]*jsonschema.Schema) map[string]*jsonschema.Schema {
+	ret := map[string]*jsonschema.Schema{
+		"owner": {
+			Type:        "string",
+			Description: "Repository owner (username or organization name)",
+		},
+		"repo": {
+			Type:        "string",
+			Description: "Repository name",
+		},
+		"index": {
+			Type:        "integer",
+			Description: "Issue index number, to react to the issue itself",
+		},
+		"comment_id": {
+			Type:        "integer",
+			Description: "Comment ID, to react to a comment instead of the issue",
+		},
+	}
+	maps.Copy(ret, extra)
+	return ret
+}
+
+// ListIssueReactionsParams defines the parameters for the list_issue_reactions
+// tool. It specifies an issue or a comment.
+type ListIssueReactionsParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Index is the issue number.
+	Index int `json:"index,omitempty"`
+	// CommentID is the ID of the comment.
+	CommentID int `json:"comment_id,omitempty"`
+}
+
+// ListIssueReactionsImpl implements the read-only MCP tool for listing the
+// reactions on an issue or a comment. This is a safe, idempotent operation
+// that uses the Forgejo SDK.
+type ListIssueReactionsImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `list_issue_reactions` tool. It requires `owner`,
+// `repo` and either `index` or `comment_id`. It is marked as a safe, read-only
+// operation.
+func (ListIssueReactionsImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "list_issue_reactions",
+		Title:       "List Issue Reactions",
+		Description: "List the emoji reactions on an issue (index) or on one of its comments (comment_id), grouped by reaction with the users who reacted.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type:       "object",
+			Properties: reactionProperties(nil),
+			Required:   []string{"owner", "repo"},
+		},
+	}
+}
+
+// Handler implements the logic for listing reactions. It calls the Forgejo SDK's
+// `GetIssueReactions` or `GetIssueCommentReactions` function.
+func (impl ListIssueReactionsImpl) Handler() mcp.ToolHandlerFor[ListIssueReactionsParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args ListIssueReactionsParams) (*mcp.CallToolResult, any, error) {
+		t := reactionTarget(args)
+		if err := t.validate(); err != nil {
+			return nil, nil, err
+		}
+
+		reactions, err := t.list(impl.Client)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("Reactions on %s:\n\n%s", t, reactions.ToMarkdown()),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// IssueReactionParams defines the parameters for the add_issue_reaction and
+// remove_issue_reaction tools. It specifies an issue or a comment and the
+// reaction.
+type IssueReactionParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Index is the issue number.
+	Index int `json:"index,omitempty"`
+	// CommentID is the ID of the comment.
+	CommentID int `json:"comment_id,omitempty"`
+	// Reaction is the reaction content, e.g. "+1".
+	Reaction string `json:"reaction"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// target returns the issue or comment of p.
+func (p IssueReactionParams) target() reactionTarget {
+	return reactionTarget{Owner: p.Owner, Repo: p.Repo, Index: p.Index, CommentID: p.CommentID}
+}
+
+// reactionSchema returns the input schema shared by add_issue_reaction and
+// remove_issue_reaction.
+func reactionSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "object",
+		Properties: reactionProperties(map[string]*jsonschema.Schema{
+			"reaction": {
+				Type:        "string",
+				Description: "Reaction, '+1' is thumbs up and '-1' thumbs down",
+				Enum:        reactionContents,
+			},
+			"dry_run": tools.DryRunSchema(),
+		}),
+		Required: []string{"owner", "repo", "reaction"},
+	}
+}
+
+// reactionDryRun previews adding or removing the reaction of p.
+func reactionDryRun(cl *tools.Client, p IssueReactionParams, method, verb string) (*mcp.CallToolResult, error) {
+	t := p.target()
+	reactions, err := t.list(cl)
+	if err != nil {
+		return nil, err
+	}
+	preview := fmt.Sprintf("Would %s reaction **%s** on %s. Current reactions:\n\n%s", verb, p.Reaction, t, reactions.ToMarkdown())
+	return cl.DryRunResult(preview, tools.APIRequest{
+		Method:   method,
+		Endpoint: t.endpoint(),
+		Body:     map[string]string{"content": p.Reaction},
+	})
+}
+
+// AddIssueReactionImpl implements the MCP tool for adding a reaction to an
+// issue or a comment as the authenticated user. This is an idempotent
+// operation that uses the Forgejo SDK.
+type AddIssueReactionImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `add_issue_reaction` tool. It requires `owner`,
+// `repo`, `reaction` and either `index` or `comment_id`. It is marked as
+// idempotent, as adding the same reaction twice has no effect.
+func (AddIssueReactionImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "add_issue_reaction",
+		Title:       "Add Issue Reaction",
+		Description: "Add an emoji reaction to an issue (index) or to one of its comments (comment_id), e.g. '+1' to upvote.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  true,
+		},
+		InputSchema: reactionSchema(),
+	}
+}
+
+// Handler implements the logic for adding a reaction. It calls the Forgejo SDK's
+// `PostIssueReaction` or `PostIssueCommentReaction` function.
+func (impl AddIssueReactionImpl) Handler() mcp.ToolHandlerFor[IssueReactionParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args IssueReactionParams) (*mcp.CallToolResult, any, error) {
+		p := args
+		t := p.target()
+		if err := t.validate(); err != nil {
+			return nil, nil, err
+		}
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := reactionDryRun(impl.Client, p, "POST", "add")
+			return res, nil, err
+		}
+
+		var err error
+		if t.CommentID > 0 {
+			_, _, err = impl.Client.PostIssueCommentReaction(p.Owner, p.Repo, int64(p.CommentID), p.Reaction)
+		} else {
+			_, _, err = impl.Client.PostIssueReaction(p.Owner, p.Repo, int64(p.Index), p.Reaction)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to add reaction: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("Reaction %s added to %s.", p.Reaction, t),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// RemoveIssueReactionImpl implements the MCP tool for removing a reaction of the
+// authenticated user from an issue or a comment. This is an idempotent
+// operation that uses the Forgejo SDK.
+type RemoveIssueReactionImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `remove_issue_reaction` tool. It requires `owner`,
+// `repo`, `reaction` and either `index` or `comment_id`. It is marked as
+// idempotent.
+func (RemoveIssueReactionImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "remove_issue_reaction",
+		Title:       "Remove Issue Reaction",
+		Description: "Remove your emoji reaction from an issue (index) or from one of its comments (comment_id).",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  true,
+		},
+		InputSchema: reactionSchema(),
+	}
+}
+
+// Handler implements the logic for removing a reaction. It calls the Forgejo
+// SDK's `DeleteIssueReaction` or `DeleteIssueCommentReaction` function.
+func (impl RemoveIssueReactionImpl) Handler() mcp.ToolHandlerFor[IssueReactionParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args IssueReactionParams) (*mcp.CallToolResult, any, error) {
+		p := args
+		t := p.target()
+		if err := t.validate(); err != nil {
+			return nil, nil, err
+		}
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := reactionDryRun(impl.Client, p, "DELETE", "remove")
+			return res, nil, err
+		}
+
+		var err error
+		if t.CommentID > 0 {
+			_, err = impl.Client.DeleteIssueCommentReaction(p.Owner, p.Repo, int64(p.CommentID), p.Reaction)
+		} else {
+			_, err = impl.Client.DeleteIssueReaction(p.Owner, p.Repo, int64(p.Index), p.Reaction)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to remove reaction: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("Reaction %s removed from %s.", p.Reaction, t),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// maxVoteIssues is the maximum number of issues list_most_upvoted_issues
+// fetches reactions of in one call.
+const maxVoteIssues = 500
+
+// ListMostUpvotedIssuesParams defines the parameters for the
+// list_most_upvoted_issues tool. It selects the open issues to rank.
+type ListMostUpvotedIssuesParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Labels is a comma-separated list of label names to filter by.
+	Labels string `json:"labels,omitempty"`
+	// Milestones is a comma-separated list of milestone names to filter by.
+	Milestones string `json:"milestones,omitempty"`
+	// Limit is the number of issues to return.
+	Limit int `json:"limit,omitempty"`
+	// MaxIssues is the maximum number of open issues to examine.
+	MaxIssues int `json:"max_issues,omitempty"`
+}
+
+// ListMostUpvotedIssuesImpl implements the read-only MCP tool for ranking the
+// open issues of a repository by thumbs-up reactions. This is a safe,
+// idempotent operation that uses the Forgejo SDK; it needs one request per
+// examined issue.
+type ListMostUpvotedIssuesImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `list_most_upvoted_issues` tool. It requires `owner`
+// and `repo`. It is marked as a safe, read-only operation.
+func (ListMostUpvotedIssuesImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "list_most_upvoted_issues",
+		Title:       "List Most Upvoted Issues",
+		Description: "Rank the open issues of a repository by 👍 (+1) reactions to help prioritize, optionally filtered by labels or milestones. Shows 👍 and 👎 counts per issue.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"labels": {
+					Type:        "string",
+					Description: "Comma-separated list of label names to filter by (optional)",
+				},
+				"milestones": {
+					Type:        "string",
+					Description: "Comma-separated list of milestone names to filter by (optional)",
+				},
+				"limit": {
+					Type:        "integer",
+					Description: "Number of issues to return (optional, defaults to 10)",
+					Minimum:     tools.Float64Ptr(1),
+					Maximum:     tools.Float64Ptr(100),
+				},
+				"max_issues": {
+					Type:        "integer",
+					Description: "Maximum number of open issues to examine, newest first (optional, defaults to 100)",
+					Minimum:     tools.Float64Ptr(1),
+					Maximum:     tools.Float64Ptr(maxVoteIssues),
+				},
+			},
+			Required: []string{"owner", "repo"},
+		},
+	}
+}
+
+// issueVotes is an issue with its vote counts.
+type issueVotes struct {
+	Issue *forgejo.Issue
+	Up    int
+	Down  int
+}
+
+// Handler implements the logic for ranking issues. It pages through the open
+// issues via the Forgejo SDK's `ListRepoIssues` function and fetches the
+// reactions of each with `GetIssueReactions`.
+func (impl ListMostUpvotedIssuesImpl) Handler() mcp.ToolHandlerFor[ListMostUpvotedIssuesParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args ListMostUpvotedIssuesParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		limit := p.Limit
+		if limit <= 0 {
+			limit = 10
+		}
+		maxIssues := p.MaxIssues
+		if maxIssues <= 0 {
+			maxIssues = 100
+		}
+		maxIssues = min(maxIssues, maxVoteIssues)
+
+		opt := forgejo.ListIssueOption{
+			State: forgejo.StateOpen,
+			Type:  forgejo.IssueTypeIssue,
+		}
+		if p.Labels != "" {
+			opt.Labels = strings.Split(p.Labels, ",")
+		}
+		if p.Milestones != "" {
+			opt.Milestones = strings.Split(p.Milestones, ",")
+		}
+
+		const pageSize = 50
+		var issues []*forgejo.Issue
+		for page := 1; len(issues) < maxIssues; page++ {
+			opt.ListOptions = forgejo.ListOptions{Page: page, PageSize: pageSize}
+			list, _, err := impl.Client.ListRepoIssues(p.Owner, p.Repo, opt)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to list issues: %w", err)
+			}
+			issues = append(issues, list...)
+			if len(list) < pageSize {
+				break
+			}
+		}
+		issues = issues[:min(len(issues), maxIssues)]
+
+		votes := make([]*issueVotes, 0, len(issues))
+		for _, issue := range issues {
+			reactions, _, err := impl.Client.GetIssueReactions(p.Owner, p.Repo, issue.Index)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to list reactions of issue #%d: %w", issue.Index, err)
+			}
+			counts := types.ReactionList(reactions).Counts()
+			votes = append(votes, &issueVotes{Issue: issue, Up: counts["+1"], Down: counts["-1"]})
+		}
+
+		ranked := rankVotes(votes)
+		content := fmt.Sprintf("Most upvoted open issues in %s/%s (examined %d issues):\n\n%s",
+			p.Owner, p.Repo, len(issues), renderVotes(ranked[:min(len(ranked), limit)]))
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: content,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// rankVotes returns the issues with upvotes, most upvoted first. Ties are
+// broken by fewer downvotes, then by issue number.
+func rankVotes(votes []*issueVotes) []*issueVotes {
+	ret := slices.DeleteFunc(slices.Clone(votes), func(v *issueVotes) bool { return v.Up == 0 })
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].Up != ret[j].Up {
+			return ret[i].Up > ret[j].Up
+		}
+		if ret[i].Down != ret[j].Down {
+			return ret[i].Down < ret[j].Down
+		}
+		return ret[i].Issue.Index < ret[j].Issue.Index
+	})
+	return ret
+}
+
+// renderVotes renders ranked issues as a markdown table.
+func renderVotes(votes []*issueVotes) string {
+	if len(votes) == 0 {
+		return "*No upvoted issues found*"
+	}
+	var b strings.Builder
+	b.WriteString("| # | Issue | 👍 | 👎 | Labels | Updated |\n")
+	b.WriteString("|---|-------|----|----|--------|---------|\n")
+	for i, v := range votes {
+		labels := make([]string, len(v.Issue.Labels))
+		for j, l := range v.Issue.Labels {
+			labels[j] = l.Name
+		}
+		fmt.Fprintf(&b, "| %d | #%d %s | %d | %d | %s | %s |\n",
+			i+1, v.Issue.Index, v.Issue.Title, v.Up, v.Down, strings.Join(labels, ", "), v.Issue.Updated.Format("2006-01-02"))
+	}
+	return b.String()
+}
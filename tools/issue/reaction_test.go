@@ -0,0 +1,76 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"strings"
+	"testing"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+)
+
+func TestRankVotes(t *testing.T) {
+	issue := func(index int64) *forgejo.Issue {
+		return &forgejo.Issue{Index: index, Title: "Issue", Labels: []*forgejo.Label{{Name: "feature"}}}
+	}
+	votes := []*issueVotes{
+		{Issue: issue(1), Up: 2, Down: 1},
+		{Issue: issue(2), Up: 0, Down: 3},
+		{Issue: issue(3), Up: 5},
+		{Issue: issue(4), Up: 2},
+		{Issue: issue(5), Up: 2, Down: 1},
+	}
+
+	ranked := rankVotes(votes)
+	var got []int64
+	for _, v := range ranked {
+		got = append(got, v.Issue.Index)
+	}
+	want := []int64{3, 4, 1, 5}
+	if len(got) != len(want) {
+		t.Fatalf("rankVotes() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("rankVotes() = %v, want %v", got, want)
+		}
+	}
+	if len(votes) != 5 {
+		t.Errorf("rankVotes() modified its input")
+	}
+
+	md := renderVotes(ranked)
+	if !strings.Contains(md, "| 1 | #3 Issue | 5 | 0 | feature |") {
+		t.Errorf("unexpected table:\n%s", md)
+	}
+	if got := renderVotes(nil); !strings.Contains(got, "No upvoted issues found") {
+		t.Errorf("renderVotes(nil) = %q", got)
+	}
+}
+
+func TestReactionTarget(t *testing.T) {
+	tests := []struct {
+		target   reactionTarget
+		valid    bool
+		endpoint string
+	}{
+		{reactionTarget{Owner: "o", Repo: "r", Index: 1}, true, "/api/v1/repos/o/r/issues/1/reactions"},
+		{reactionTarget{Owner: "o", Repo: "r", CommentID: 7}, true, "/api/v1/repos/o/r/issues/comments/7/reactions"},
+		{reactionTarget{Owner: "o", Repo: "r"}, false, ""},
+		{reactionTarget{Owner: "o", Repo: "r", Index: 1, CommentID: 7}, false, ""},
+	}
+
+	for _, tt := range tests {
+		err := tt.target.validate()
+		if (err == nil) != tt.valid {
+			t.Errorf("%+v: validate() = %v, want valid %v", tt.target, err, tt.valid)
+		}
+		if tt.valid && tt.target.endpoint() != tt.endpoint {
+			t.Errorf("%+v: endpoint() = %q, want %q", tt.target, tt.target.endpoint(), tt.endpoint)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// SearchIssuesParams defines the parameters for the search_issues tool.
+// All filters are optional and combined.
+type SearchIssuesParams struct {
+	// Q is a search query string.
+	Q string `json:"q,omitempty"`
+	// Owner limits the search to repositories of a user or organization.
+	Owner string `json:"owner,omitempty"`
+	// Type filters by 'issue' or 'pr'.
+	Type string `json:"type,omitempty"`
+	// State filters issues by their state (e.g., 'open', 'closed').
+	State string `json:"state,omitempty"`
+	// Labels is a comma-separated list of label names to filter by.
+	Labels string `json:"labels,omitempty"`
+	// Milestones is a comma-separated list of milestone names to filter by.
+	Milestones string `json:"milestones,omitempty"`
+	// Assigned selects issues assigned to the authenticated user.
+	Assigned bool `json:"assigned,omitempty"`
+	// Created selects issues created by the authenticated user.
+	Created bool `json:"created,omitempty"`
+	// Mentioned selects issues mentioning the authenticated user.
+	Mentioned bool `json:"mentioned,omitempty"`
+	// ReviewRequested selects pull requests requesting review from the authenticated user.
+	ReviewRequested bool `json:"review_requested,omitempty"`
+	// Page is the page number for pagination.
+	Page int `json:"page,omitempty"`
+	// Limit is the number of issues to return per page.
+	Limit int `json:"limit,omitempty"`
+	// Since is a timestamp in RFC 3339 format to show only issues updated after this time.
+	Since *string `json:"since,omitempty"`
+	// Before is a timestamp in RFC 3339 format to show only issues updated before this time.
+	Before *string `json:"before,omitempty"`
+}
+
+// SearchIssuesImpl implements the read-only MCP tool for searching issues and
+// pull requests across all repositories visible to the token. This is a safe,
+// idempotent operation. Note: The SDK's ListIssues lacks the "by me" filters,
+// so a custom HTTP implementation is used.
+type SearchIssuesImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `search_issues` tool. All parameters are optional. It
+// is marked as a safe, read-only operation.
+func (SearchIssuesImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "search_issues",
+		Title:       "Search Issues Across Repositories",
+		Description: "Search issues and pull requests across all repositories you can see, e.g. everything assigned to you. Filter by owner, type, state, labels, milestones, search terms, update time, and whether they are assigned to, created by, mentioning or requesting review from you.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"q": {
+					Type:        "string",
+					Description: "Search query string (optional)",
+				},
+				"owner": {
+					Type:        "string",
+					Description: "Only search repositories of this user or organization (optional)",
+				},
+				"type": {
+					Type:        "string",
+					Description: "Only issues ('issue') or pull requests ('pr') (optional, defaults to both)",
+					Enum:        []any{"issue", "pr"},
+				},
+				"state": {
+					Type:        "string",
+					Description: "Issue state filter: 'open', 'closed', or 'all' (optional, defaults to 'open')",
+					Enum:        []any{"open", "closed", "all"},
+				},
+				"labels": {
+					Type:        "string",
+					Description: "Comma-separated list of label names to filter by (optional)",
+				},
+				"milestones": {
+					Type:        "string",
+					Description: "Comma-separated list of milestone names to filter by (optional)",
+				},
+				"assigned": {
+					Type:        "boolean",
+					Description: "Only items assigned to you (optional)",
+				},
+				"created": {
+					Type:        "boolean",
+					Description: "Only items created by you (optional)",
+				},
+				"mentioned": {
+					Type:        "boolean",
+					Description: "Only items mentioning you (optional)",
+				},
+				"review_requested": {
+					Type:        "boolean",
+					Description: "Only pull requests requesting your review (optional)",
+				},
+				"page": {
+					Type:        "integer",
+					Description: "Page number for pagination (optional, defaults to 1)",
+					Minimum:     tools.Float64Ptr(1),
+				},
+				"limit": {
+					Type:        "integer",
+					Description: "Number of items per page (optional, defaults to 20, max 50)",
+					Minimum:     tools.Float64Ptr(1),
+					Maximum:     tools.Float64Ptr(50),
+				},
+				"since": {
+					Type:        "string",
+					Description: "Only show items updated after this time (RFC 3339 format, optional)",
+					Format:      "date-time",
+				},
+				"before": {
+					Type:        "string",
+					Description: "Only show items updated before this time (RFC 3339 format, optional)",
+					Format:      "date-time",
+				},
+			},
+		},
+	}
+}
+
+// Handler implements the logic for searching issues. It performs a custom HTTP
+// GET request to the `/repos/issues/search` endpoint and formats the results
+// into a markdown list including the repository of each item.
+func (impl SearchIssuesImpl) Handler() mcp.ToolHandlerFor[SearchIssuesParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args SearchIssuesParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		page := max(p.Page, 1)
+		limit := p.Limit
+		if limit <= 0 {
+			limit = 20
+		}
+
+		// Build options for custom client call
+		opt := tools.MySearchIssuesOptions{
+			Page:            page,
+			Limit:           limit,
+			State:           p.State,
+			Query:           p.Q,
+			Owner:           p.Owner,
+			Assigned:        p.Assigned,
+			Created:         p.Created,
+			Mentioned:       p.Mentioned,
+			ReviewRequested: p.ReviewRequested,
+		}
+		switch p.Type {
+		case "issue":
+			opt.Type = "issues"
+		case "pr":
+			opt.Type = "pulls"
+		}
+		if p.Labels != "" {
+			opt.Labels = strings.Split(p.Labels, ",")
+		}
+		if p.Milestones != "" {
+			opt.Milestones = strings.Split(p.Milestones, ",")
+		}
+
+		// Handle time-based filters
+		if p.Since != nil {
+			since, err := time.Parse(time.RFC3339, *p.Since)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid since timestamp format (expected RFC 3339): %w", err)
+			}
+			opt.Since = since
+		}
+		if p.Before != nil {
+			before, err := time.Parse(time.RFC3339, *p.Before)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid before timestamp format (expected RFC 3339): %w", err)
+			}
+			opt.Before = before
+		}
+
+		// Call custom client method
+		issues, err := impl.Client.MySearchIssues(opt)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to search issues: %w", err)
+		}
+
+		// Convert to our types and format
+		issueList := types.SearchIssueList(issues)
+		content := fmt.Sprintf("Found %d items on page %d\n\n%s", len(issues), page, issueList.ToMarkdown())
+		if len(issues) >= limit {
+			content += fmt.Sprintf("\nMore items may be available, use page %d to see them.", page+1)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: content,
+				},
+			},
+		}, nil, nil
+	}
+}
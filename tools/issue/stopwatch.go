@@ -0,0 +1,300 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// ListMyStopwatchesParams defines the parameters for the list_my_stopwatches
+// tool. It takes no parameters.
+type ListMyStopwatchesParams struct{}
+
+// ListMyStopwatchesImpl implements the read-only MCP tool for listing the
+// running stopwatches of the authenticated user. This is a safe, idempotent
+// operation that uses the Forgejo SDK.
+type ListMyStopwatchesImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `list_my_stopwatches` tool. It is marked as a safe,
+// read-only operation.
+func (ListMyStopwatchesImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "list_my_stopwatches",
+		Title:       "List My Stopwatches",
+		Description: "List your running issue stopwatches with their issues and elapsed time.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type:       "object",
+			Properties: map[string]*jsonschema.Schema{},
+		},
+	}
+}
+
+// Handler implements the logic for listing stopwatches. It calls the Forgejo
+// SDK's `GetMyStopwatches` function and formats the results into a markdown list.
+func (impl ListMyStopwatchesImpl) Handler() mcp.ToolHandlerFor[ListMyStopwatchesParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args ListMyStopwatchesParams) (*mcp.CallToolResult, any, error) {
+		// Call SDK
+		stopwatches, _, err := impl.Client.GetMyStopwatches()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list stopwatches: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: types.StopWatchList(stopwatches).ToMarkdown(),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// IssueStopwatchParams defines the parameters for the start_issue_stopwatch,
+// stop_issue_stopwatch and cancel_issue_stopwatch tools. It specifies the
+// issue.
+type IssueStopwatchParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Index is the issue number.
+	Index int `json:"index"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// stopwatchSchema returns the input schema shared by the stopwatch tools.
+func stopwatchSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"owner": {
+				Type:        "string",
+				Description: "Repository owner (username or organization name)",
+			},
+			"repo": {
+				Type:        "string",
+				Description: "Repository name",
+			},
+			"index": {
+				Type:        "integer",
+				Description: "Issue index number",
+			},
+			"dry_run": tools.DryRunSchema(),
+		},
+		Required: []string{"owner", "repo", "index"},
+	}
+}
+
+// findStopwatch returns the running stopwatch of the authenticated user on an
+// issue, or nil if there is none.
+func findStopwatch(cl *tools.Client, p IssueStopwatchParams) (*forgejo.StopWatch, error) {
+	stopwatches, _, err := cl.GetMyStopwatches()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stopwatches: %w", err)
+	}
+	for _, s := range stopwatches {
+		if s.RepoOwnerName == p.Owner && s.RepoName == p.Repo && s.IssueIndex == int64(p.Index) {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+// stopwatchDryRun previews a stopwatch action. running reports whether the
+// action needs a running stopwatch on the issue.
+func stopwatchDryRun(cl *tools.Client, p IssueStopwatchParams, action string, running bool) (*mcp.CallToolResult, error) {
+	s, err := findStopwatch(cl, p)
+	if err != nil {
+		return nil, err
+	}
+	ref := fmt.Sprintf("%s/%s#%d", p.Owner, p.Repo, p.Index)
+	if running && s == nil {
+		return nil, fmt.Errorf("no stopwatch is running on %s", ref)
+	}
+	if !running && s != nil {
+		return nil, fmt.Errorf("a stopwatch is already running on %s", ref)
+	}
+
+	preview := fmt.Sprintf("Would %s the stopwatch on %s", action, ref)
+	if s != nil {
+		preview += fmt.Sprintf(", running %s since %s", types.FormatSeconds(s.Seconds), s.Created.Format("2006-01-02 15:04"))
+	}
+	method, path := "POST", action
+	if action == "cancel" {
+		method, path = "DELETE", "delete"
+	}
+	return cl.DryRunResult(preview, tools.APIRequest{
+		Method:   method,
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/issues/%d/stopwatch/%s", p.Owner, p.Repo, p.Index, path),
+	})
+}
+
+// StartIssueStopwatchImpl implements the MCP tool for starting a stopwatch on an
+// issue. This is a non-idempotent operation that uses the Forgejo SDK: it fails
+// if a stopwatch is already running on the issue.
+type StartIssueStopwatchImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `start_issue_stopwatch` tool. It requires `owner`,
+// `repo` and `index`.
+func (StartIssueStopwatchImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "start_issue_stopwatch",
+		Title:       "Start Issue Stopwatch",
+		Description: "Start a stopwatch on an issue to track the time spent on it. Stop it with stop_issue_stopwatch to add the elapsed time.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  false,
+		},
+		InputSchema: stopwatchSchema(),
+	}
+}
+
+// Handler implements the logic for starting a stopwatch. It calls the Forgejo
+// SDK's `StartIssueStopWatch` function.
+func (impl StartIssueStopwatchImpl) Handler() mcp.ToolHandlerFor[IssueStopwatchParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args IssueStopwatchParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := stopwatchDryRun(impl.Client, p, "start", false)
+			return res, nil, err
+		}
+
+		_, err := impl.Client.StartIssueStopWatch(p.Owner, p.Repo, int64(p.Index))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to start stopwatch: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("Stopwatch started on %s/%s#%d.", p.Owner, p.Repo, p.Index),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// StopIssueStopwatchImpl implements the MCP tool for stopping the stopwatch on
+// an issue and adding the elapsed time as tracked time. This is a
+// non-idempotent operation that uses the Forgejo SDK.
+type StopIssueStopwatchImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `stop_issue_stopwatch` tool. It requires `owner`,
+// `repo` and `index`.
+func (StopIssueStopwatchImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "stop_issue_stopwatch",
+		Title:       "Stop Issue Stopwatch",
+		Description: "Stop the running stopwatch on an issue and add the elapsed time as tracked time.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  false,
+		},
+		InputSchema: stopwatchSchema(),
+	}
+}
+
+// Handler implements the logic for stopping a stopwatch. It calls the Forgejo
+// SDK's `StopIssueStopWatch` function.
+func (impl StopIssueStopwatchImpl) Handler() mcp.ToolHandlerFor[IssueStopwatchParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args IssueStopwatchParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := stopwatchDryRun(impl.Client, p, "stop", true)
+			return res, nil, err
+		}
+
+		_, err := impl.Client.StopIssueStopWatch(p.Owner, p.Repo, int64(p.Index))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to stop stopwatch: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("Stopwatch stopped on %s/%s#%d, the elapsed time was added as tracked time.", p.Owner, p.Repo, p.Index),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// CancelIssueStopwatchImpl implements the destructive MCP tool for cancelling
+// the stopwatch on an issue. The elapsed time is discarded. This is an
+// irreversible operation that uses the Forgejo SDK.
+type CancelIssueStopwatchImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `cancel_issue_stopwatch` tool. It requires `owner`,
+// `repo` and `index`. It is marked as a destructive operation since the
+// elapsed time is lost.
+func (CancelIssueStopwatchImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "cancel_issue_stopwatch",
+		Title:       "Cancel Issue Stopwatch",
+		Description: "Cancel the running stopwatch on an issue without tracking the elapsed time. The elapsed time is lost.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(true),
+			IdempotentHint:  false,
+		},
+		InputSchema: stopwatchSchema(),
+	}
+}
+
+// Handler implements the logic for cancelling a stopwatch. It calls the Forgejo
+// SDK's `DeleteIssueStopwatch` function.
+func (impl CancelIssueStopwatchImpl) Handler() mcp.ToolHandlerFor[IssueStopwatchParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args IssueStopwatchParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := stopwatchDryRun(impl.Client, p, "cancel", true)
+			return res, nil, err
+		}
+
+		_, err := impl.Client.DeleteIssueStopwatch(p.Owner, p.Repo, int64(p.Index))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to cancel stopwatch: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("Stopwatch cancelled on %s/%s#%d.", p.Owner, p.Repo, p.Index),
+				},
+			},
+		}, nil, nil
+	}
+}
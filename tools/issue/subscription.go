@@ -0,0 +1,382 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// ListIssueSubscribersParams defines the parameters for the
+// list_issue_subscribers tool. It specifies the issue.
+type ListIssueSubscribersParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Index is the issue or pull request number.
+	Index int `json:"index"`
+}
+
+// ListIssueSubscribersImpl implements the read-only MCP tool for listing the
+// users subscribed to an issue. This is a safe, idempotent operation that
+// uses the Forgejo SDK.
+type ListIssueSubscribersImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `list_issue_subscribers` tool. It requires
+// `owner`, `repo` and `index`. It is marked as a safe, read-only operation.
+func (ListIssueSubscribersImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "list_issue_subscribers",
+		Title:       "List Issue Subscribers",
+		Description: "List the users subscribed to an issue or pull request, who are notified about its updates.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"index": {
+					Type:        "integer",
+					Description: "Issue or pull request index number",
+				},
+			},
+			Required: []string{"owner", "repo", "index"},
+		},
+	}
+}
+
+// Handler implements the logic for listing subscribers. It calls the Forgejo
+// SDK's `GetIssueSubscribers` function and formats the results into a
+// markdown list.
+func (impl ListIssueSubscribersImpl) Handler() mcp.ToolHandlerFor[ListIssueSubscribersParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args ListIssueSubscribersParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		// Call SDK
+		users, _, err := impl.Client.GetIssueSubscribers(p.Owner, p.Repo, int64(p.Index))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list issue subscribers: %w", err)
+		}
+
+		content := fmt.Sprintf("## Subscribers of %s/%s#%d\n\n%s", p.Owner, p.Repo, p.Index, types.UserList(users).ToMarkdown())
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: content,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// CheckIssueSubscriptionParams defines the parameters for the
+// check_issue_subscription tool. It specifies the issue.
+type CheckIssueSubscriptionParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Index is the issue or pull request number.
+	Index int `json:"index"`
+}
+
+// CheckIssueSubscriptionImpl implements the read-only MCP tool for checking
+// whether the authenticated user is subscribed to an issue. This is a safe,
+// idempotent operation that uses the Forgejo SDK.
+type CheckIssueSubscriptionImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `check_issue_subscription` tool. It requires
+// `owner`, `repo` and `index`. It is marked as a safe, read-only operation.
+func (CheckIssueSubscriptionImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "check_issue_subscription",
+		Title:       "Check Issue Subscription",
+		Description: "Check whether you are subscribed to an issue or pull request.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"index": {
+					Type:        "integer",
+					Description: "Issue or pull request index number",
+				},
+			},
+			Required: []string{"owner", "repo", "index"},
+		},
+	}
+}
+
+// Handler implements the logic for checking the subscription. It calls the
+// Forgejo SDK's `CheckIssueSubscription` function.
+func (impl CheckIssueSubscriptionImpl) Handler() mcp.ToolHandlerFor[CheckIssueSubscriptionParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args CheckIssueSubscriptionParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		// Call SDK
+		info, _, err := impl.Client.CheckIssueSubscription(p.Owner, p.Repo, int64(p.Index))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to check issue subscription: %w", err)
+		}
+
+		ref := fmt.Sprintf("%s/%s#%d", p.Owner, p.Repo, p.Index)
+		text := "You are not subscribed to " + ref + "."
+		switch {
+		case info.Ignored:
+			text = "You are ignoring " + ref + "."
+		case info.Subscribed:
+			text = "You are subscribed to " + ref + "."
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: text,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// IssueSubscriptionParams defines the parameters for the subscribe_issue and
+// unsubscribe_issue tools. It specifies the issue and the user.
+type IssueSubscriptionParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Index is the issue or pull request number.
+	Index int `json:"index"`
+	// User is the username to (un)subscribe, defaults to the authenticated
+	// user.
+	User string `json:"user,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// subscriptionSchema returns the input schema shared by the subscribe_issue
+// and unsubscribe_issue tools.
+func subscriptionSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"owner": {
+				Type:        "string",
+				Description: "Repository owner (username or organization name)",
+			},
+			"repo": {
+				Type:        "string",
+				Description: "Repository name",
+			},
+			"index": {
+				Type:        "integer",
+				Description: "Issue or pull request index number",
+			},
+			"user": {
+				Type:        "string",
+				Description: "Username, only repository admins can change the subscription of others (optional, defaults to you)",
+			},
+			"dry_run": tools.DryRunSchema(),
+		},
+		Required: []string{"owner", "repo", "index"},
+	}
+}
+
+// subscriptionUser returns the user to (un)subscribe, which is the
+// authenticated user if p.User is empty.
+func subscriptionUser(cl *tools.Client, p IssueSubscriptionParams) (string, error) {
+	if p.User != "" {
+		return p.User, nil
+	}
+	me, _, err := cl.GetMyUserInfo()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current user: %w", err)
+	}
+	return me.UserName, nil
+}
+
+// subscriptionDryRun previews subscribing (subscribe is true) or
+// unsubscribing a user.
+func subscriptionDryRun(cl *tools.Client, p IssueSubscriptionParams, user string, subscribe bool) (*mcp.CallToolResult, error) {
+	if _, err := cl.ResolveUsers([]string{user}); err != nil {
+		return nil, err
+	}
+	issue, _, err := cl.GetIssue(p.Owner, p.Repo, int64(p.Index))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+	subscribers, _, err := cl.GetIssueSubscribers(p.Owner, p.Repo, int64(p.Index))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issue subscribers: %w", err)
+	}
+	subscribed := slices.ContainsFunc(subscribers, func(u *forgejo.User) bool { return u.UserName == user })
+
+	ref := issueRef(p.Owner, p.Repo, issue)
+	method := "PUT"
+	var preview string
+	switch {
+	case subscribe && subscribed:
+		preview = fmt.Sprintf("%s is already subscribed to %s, nothing would change", user, ref)
+	case subscribe:
+		preview = fmt.Sprintf("Would subscribe %s to %s", user, ref)
+	case subscribed:
+		method = "DELETE"
+		preview = fmt.Sprintf("Would unsubscribe %s from %s", user, ref)
+	default:
+		method = "DELETE"
+		preview = fmt.Sprintf("%s is not subscribed to %s, nothing would change", user, ref)
+	}
+
+	return cl.DryRunResult(preview, tools.APIRequest{
+		Method:   method,
+		Endpoint: issueEndpoint(p.Owner, p.Repo, p.Index, "/subscriptions/"+user),
+	})
+}
+
+// SubscribeIssueImpl implements the MCP tool for subscribing a user to an
+// issue. This is an idempotent operation that uses the Forgejo SDK.
+type SubscribeIssueImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `subscribe_issue` tool. It requires `owner`,
+// `repo` and `index`. It is marked as idempotent.
+func (SubscribeIssueImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "subscribe_issue",
+		Title:       "Subscribe to Issue",
+		Description: "Subscribe a user (you by default) to an issue or pull request, so they are notified about its updates. Subscribing again is not an error.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  true,
+		},
+		InputSchema: subscriptionSchema(),
+	}
+}
+
+// Handler implements the logic for subscribing to an issue. It calls the
+// Forgejo SDK's `AddIssueSubscription` function.
+func (impl SubscribeIssueImpl) Handler() mcp.ToolHandlerFor[IssueSubscriptionParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args IssueSubscriptionParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		user, err := subscriptionUser(impl.Client, p)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := subscriptionDryRun(impl.Client, p, user, true)
+			return res, nil, err
+		}
+
+		// The SDK reports an existing subscription as an error with status 200
+		resp, err := impl.Client.AddIssueSubscription(p.Owner, p.Repo, int64(p.Index), user)
+		if err != nil && (resp == nil || resp.StatusCode != http.StatusOK) {
+			return nil, nil, fmt.Errorf("failed to subscribe to issue: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("%s is subscribed to %s/%s#%d.", user, p.Owner, p.Repo, p.Index),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// UnsubscribeIssueImpl implements the MCP tool for unsubscribing a user from
+// an issue. This is an idempotent operation that uses the Forgejo SDK.
+type UnsubscribeIssueImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `unsubscribe_issue` tool. It requires `owner`,
+// `repo` and `index`. It is marked as idempotent.
+func (UnsubscribeIssueImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "unsubscribe_issue",
+		Title:       "Unsubscribe from Issue",
+		Description: "Unsubscribe a user (you by default) from an issue or pull request. Unsubscribing again is not an error.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  true,
+		},
+		InputSchema: subscriptionSchema(),
+	}
+}
+
+// Handler implements the logic for unsubscribing from an issue. It calls the
+// Forgejo SDK's `DeleteIssueSubscription` function.
+func (impl UnsubscribeIssueImpl) Handler() mcp.ToolHandlerFor[IssueSubscriptionParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args IssueSubscriptionParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		user, err := subscriptionUser(impl.Client, p)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := subscriptionDryRun(impl.Client, p, user, false)
+			return res, nil, err
+		}
+
+		// The SDK reports a missing subscription as an error with status 200
+		resp, err := impl.Client.DeleteIssueSubscription(p.Owner, p.Repo, int64(p.Index), user)
+		if err != nil && (resp == nil || resp.StatusCode != http.StatusOK) {
+			return nil, nil, fmt.Errorf("failed to unsubscribe from issue: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("%s is not subscribed to %s/%s#%d.", user, p.Owner, p.Repo, p.Index),
+				},
+			},
+		}, nil, nil
+	}
+}
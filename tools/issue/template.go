@@ -0,0 +1,433 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// ListIssueTemplatesParams defines the parameters for the list_issue_templates
+// tool. It specifies the repository.
+type ListIssueTemplatesParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+}
+
+// ListIssueTemplatesImpl implements the read-only MCP tool for listing the
+// issue templates and issue forms of a repository. This is a safe, idempotent
+// operation. Note: The SDK cannot decode every issue form, so this tool uses
+// a custom HTTP implementation.
+type ListIssueTemplatesImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `list_issue_templates` tool. It requires `owner`
+// and `repo`. It is marked as a safe, read-only operation.
+func (ListIssueTemplatesImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "list_issue_templates",
+		Title:       "List Issue Templates",
+		Description: "List the issue templates (markdown) and issue forms (YAML) of a repository, with their default title prefix, labels and assignees, and the fields to fill. Pass the template name and fields to create_issue to use one.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+			},
+			Required: []string{"owner", "repo"},
+		},
+	}
+}
+
+// Handler implements the logic for listing issue templates. It performs a
+// custom HTTP GET request to the `/repos/{owner}/{repo}/issue_templates`
+// endpoint and formats the results into markdown.
+func (impl ListIssueTemplatesImpl) Handler() mcp.ToolHandlerFor[ListIssueTemplatesParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args ListIssueTemplatesParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		// Call custom client method
+		templates, err := impl.Client.MyListIssueTemplates(p.Owner, p.Repo)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list issue templates: %w", err)
+		}
+		notes := readAssignees(impl.Client, p.Owner, p.Repo, templates)
+
+		text := types.IssueTemplateList(templates).ToMarkdown()
+		if len(notes) > 0 {
+			text += "\n" + strings.Join(notes, "\n") + "\n"
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: text,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// templateDirs are the directories the server reads issue templates from,
+// for file names without directory.
+var templateDirs = []string{
+	".forgejo/ISSUE_TEMPLATE", ".forgejo/issue_template",
+	".gitea/ISSUE_TEMPLATE", ".gitea/issue_template",
+	".github/ISSUE_TEMPLATE", ".github/issue_template",
+}
+
+// readAssignees fills the default assignees of templates from their files on
+// the default branch, as the API does not return them. It returns notes about
+// the templates whose assignees could not be read.
+func readAssignees(cl *tools.Client, owner, repo string, templates []*types.MyIssueTemplate) []string {
+	var notes []string
+	for _, t := range templates {
+		if t == nil || t.FileName == "" {
+			continue
+		}
+		paths := []string{t.FileName}
+		if !strings.Contains(t.FileName, "/") {
+			paths = paths[:0]
+			for _, dir := range templateDirs {
+				paths = append(paths, dir+"/"+t.FileName)
+			}
+		}
+
+		err := fmt.Errorf("file %s not found", t.FileName)
+		for _, p := range paths {
+			data, _, ferr := cl.GetFile(owner, repo, "", p)
+			if ferr != nil {
+				continue
+			}
+			t.Assignees, err = types.ParseTemplateAssignees(p, data)
+			break
+		}
+		if err != nil {
+			notes = append(notes, fmt.Sprintf("Default assignees of template %q could not be read from its file: %v.", t.Name, err))
+		}
+	}
+	return notes
+}
+
+// findTemplate finds a template by name or file name, ignoring case and the
+// file extension.
+func findTemplate(templates []*types.MyIssueTemplate, name string) (*types.MyIssueTemplate, error) {
+	names := make([]string, 0, len(templates))
+	for _, t := range templates {
+		if t == nil {
+			continue
+		}
+		file := path.Base(t.FileName)
+		for _, n := range []string{t.Name, t.FileName, file, strings.TrimSuffix(file, path.Ext(file))} {
+			if n != "" && strings.EqualFold(n, name) {
+				return t, nil
+			}
+		}
+		names = append(names, t.Name)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("issue template %q not found, the repository has no templates", name)
+	}
+	return nil, fmt.Errorf("issue template %q not found, available: %s", name, strings.Join(names, ", "))
+}
+
+// applyTemplate fills opt from the issue template named p.Template: the title
+// is prefixed, the body is the template content or the rendered form, and
+// the default labels, assignees and ref are added. It returns notes about
+// defaults that could not be applied.
+func applyTemplate(cl *tools.Client, p CreateIssueParams, opt *forgejo.CreateIssueOption) ([]string, error) {
+	templates, err := cl.MyListIssueTemplates(p.Owner, p.Repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issue templates: %w", err)
+	}
+	t, err := findTemplate(templates, p.Template)
+	if err != nil {
+		return nil, err
+	}
+	notes := readAssignees(cl, p.Owner, p.Repo, []*types.MyIssueTemplate{t})
+
+	if t.IsForm() {
+		if p.Body != "" {
+			return nil, fmt.Errorf("template %q is an issue form, fill its fields instead of body", t.Name)
+		}
+		opt.Body, err = renderForm(t, p.Fields)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if len(p.Fields) > 0 {
+			return nil, fmt.Errorf("template %q is a markdown template, fill it in body instead of fields", t.Name)
+		}
+		if opt.Body == "" {
+			opt.Body = t.Content
+		}
+	}
+
+	if t.Title != "" && !strings.HasPrefix(opt.Title, t.Title) {
+		opt.Title = t.Title + opt.Title
+	}
+	if opt.Ref == "" {
+		opt.Ref = t.Ref
+	}
+	for _, a := range t.Assignees {
+		if !slices.Contains(opt.Assignees, a) {
+			opt.Assignees = append(opt.Assignees, a)
+		}
+	}
+
+	if len(t.Labels) == 0 {
+		return notes, nil
+	}
+	labels, _, err := cl.ListRepoLabels(p.Owner, p.Repo, forgejo.ListLabelsOptions{
+		ListOptions: forgejo.ListOptions{Page: -1},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+	for _, name := range t.Labels {
+		i := slices.IndexFunc(labels, func(l *forgejo.Label) bool { return l.Name == name })
+		if i < 0 {
+			notes = append(notes, fmt.Sprintf("Template label %q does not exist in %s/%s and was skipped.", name, p.Owner, p.Repo))
+			continue
+		}
+		if !slices.Contains(opt.Labels, labels[i].ID) {
+			opt.Labels = append(opt.Labels, labels[i].ID)
+		}
+	}
+	return notes, nil
+}
+
+// renderForm validates values against the fields of an issue form and
+// renders the issue body the way the web UI does: a heading per field
+// followed by its value. values are keyed by field ID, or label for fields
+// without ID.
+func renderForm(t *types.MyIssueTemplate, values map[string]any) (string, error) {
+	fields := map[string]*types.MyIssueFormField{}
+	for _, f := range t.Fields {
+		if f == nil || f.Type == "markdown" {
+			continue
+		}
+		fields[f.Key()] = f
+	}
+
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		if _, ok := fields[key]; !ok {
+			errs = append(errs, fmt.Errorf("unknown field %q", key))
+		}
+	}
+
+	var b strings.Builder
+	for _, f := range t.Fields {
+		if f == nil || f.Type == "markdown" {
+			continue
+		}
+		key := f.Key()
+		vals, err := formValues(values[key])
+		if err == nil {
+			err = validateField(f, vals)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("field %q: %w", key, err))
+			continue
+		}
+		writeField(&b, f, vals)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return "", fmt.Errorf("invalid fields for template %q:\n%w", t.Name, err)
+	}
+	return strings.TrimRight(b.String(), "\n"), nil
+}
+
+// formValues converts a field value from tool arguments to strings. A field
+// accepts a string, a number, a boolean or an array of them.
+func formValues(v any) ([]string, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if v == "" {
+			return nil, nil
+		}
+		return []string{v}, nil
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}, nil
+	case bool:
+		return []string{strconv.FormatBool(v)}, nil
+	case []any:
+		ret := make([]string, 0, len(v))
+		for _, item := range v {
+			s, err := formValues(item)
+			if err != nil {
+				return nil, err
+			}
+			if len(s) != 1 {
+				return nil, fmt.Errorf("array items must be strings, numbers or booleans")
+			}
+			ret = append(ret, s[0])
+		}
+		return ret, nil
+	}
+	return nil, fmt.Errorf("unsupported value %v", v)
+}
+
+// validateField checks vals, the given values of field f, against its type
+// and validations.
+func validateField(f *types.MyIssueFormField, vals []string) error {
+	switch f.Type {
+	case "input", "textarea":
+		if len(vals) > 1 {
+			return fmt.Errorf("expected a single value")
+		}
+		if len(vals) == 0 {
+			if f.Required() && f.Value() == "" {
+				return fmt.Errorf("required")
+			}
+			return nil
+		}
+		if f.Type == "input" && f.IsNumber() {
+			if _, err := strconv.ParseFloat(vals[0], 64); err != nil {
+				return fmt.Errorf("%q is not a number", vals[0])
+			}
+		}
+		if r := f.Regex(); r != "" && f.Type == "input" {
+			re, err := regexp.Compile(r)
+			if err != nil {
+				return fmt.Errorf("invalid pattern %q in template: %w", r, err)
+			}
+			if !re.MatchString(vals[0]) {
+				return fmt.Errorf("%q does not match %q", vals[0], r)
+			}
+		}
+	case "dropdown":
+		if len(vals) == 0 {
+			if f.Required() && f.Default() < 0 {
+				return fmt.Errorf("required")
+			}
+			return nil
+		}
+		if len(vals) > 1 && !f.Multiple() {
+			return fmt.Errorf("only one option can be selected")
+		}
+		if err := checkOptions(f, vals); err != nil {
+			return err
+		}
+	case "checkboxes":
+		if err := checkOptions(f, vals); err != nil {
+			return err
+		}
+		for _, o := range f.Options() {
+			if o.Required && !slices.Contains(vals, o.Label) {
+				return fmt.Errorf("option %q must be checked", o.Label)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported field type %q", f.Type)
+	}
+	return nil
+}
+
+// checkOptions checks that vals are labels of options of f.
+func checkOptions(f *types.MyIssueFormField, vals []string) error {
+	opts := f.Options()
+	labels := make([]string, len(opts))
+	for i, o := range opts {
+		labels[i] = o.Label
+	}
+	for _, v := range vals {
+		if !slices.Contains(labels, v) {
+			return fmt.Errorf("%q is not an option, options: %s", v, strings.Join(labels, ", "))
+		}
+	}
+	return nil
+}
+
+// writeField renders a validated field with its values, defaulting to the
+// pre-filled value or option.
+func writeField(b *strings.Builder, f *types.MyIssueFormField, vals []string) {
+	fmt.Fprintf(b, "### %s\n\n", f.Label())
+	const blank = "_No response_\n"
+
+	switch f.Type {
+	case "input", "textarea":
+		value := f.Value()
+		if len(vals) > 0 {
+			value = vals[0]
+		}
+		switch {
+		case value == "":
+			b.WriteString(blank)
+		case f.Type == "textarea" && f.Render() != "":
+			fence := codeFence(value)
+			fmt.Fprintf(b, "%s%s\n%s\n%s\n", fence, f.Render(), value, fence)
+		default:
+			b.WriteString(value + "\n")
+		}
+	case "dropdown":
+		opts := f.Options()
+		if len(vals) == 0 {
+			if i := f.Default(); i >= 0 && i < len(opts) {
+				vals = []string{opts[i].Label}
+			}
+		}
+		if len(vals) == 0 {
+			b.WriteString(blank)
+		} else {
+			b.WriteString(strings.Join(vals, ", ") + "\n")
+		}
+	case "checkboxes":
+		for _, o := range f.Options() {
+			mark := " "
+			if slices.Contains(vals, o.Label) {
+				mark = "x"
+			}
+			fmt.Fprintf(b, "- [%s] %s\n", mark, o.Label)
+		}
+	}
+	b.WriteString("\n")
+}
+
+// codeFence returns a backtick fence longer than any backtick run in s.
+func codeFence(s string) string {
+	longest, run := 0, 0
+	for _, c := range s {
+		if c != '`' {
+			run = 0
+			continue
+		}
+		run++
+		longest = max(longest, run)
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
@@ -0,0 +1,114 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// bugForm is an issue form as returned by GET /repos/{owner}/{repo}/issue_templates.
+const bugForm = `{
+	"name": "Bug Report",
+	"title": "[Bug]: ",
+	"labels": ["bug"],
+	"file_name": "bug.yaml",
+	"body": [
+		{"type": "markdown", "attributes": {"value": "Thanks for reporting!"}},
+		{"type": "textarea", "id": "what", "attributes": {"label": "What happened?"}, "validations": {"required": true}},
+		{"type": "input", "id": "version", "attributes": {"label": "Version"}, "validations": {"required": true, "regex": "^v\\d+"}},
+		{"type": "input", "id": "count", "attributes": {"label": "Count"}, "validations": {"is_number": true}},
+		{"type": "textarea", "id": "logs", "attributes": {"label": "Logs", "render": "shell"}},
+		{"type": "dropdown", "id": "os", "attributes": {"label": "OS", "options": ["Linux", "macOS"], "default": 0}},
+		{"type": "dropdown", "id": "browsers", "attributes": {"label": "Browsers", "options": ["Firefox", "Chrome"], "multiple": true}},
+		{"type": "checkboxes", "id": "terms", "attributes": {"label": "Terms", "options": [{"label": "I searched existing issues", "required": true}, {"label": "I want to fix it"}]}}
+	]
+}`
+
+func loadTemplate(t *testing.T, data string) *types.MyIssueTemplate {
+	t.Helper()
+	var tmpl types.MyIssueTemplate
+	if err := json.Unmarshal([]byte(data), &tmpl); err != nil {
+		t.Fatalf("failed to decode template: %v", err)
+	}
+	return &tmpl
+}
+
+func TestRenderForm(t *testing.T) {
+	tmpl := loadTemplate(t, bugForm)
+	var values map[string]any
+	err := json.Unmarshal([]byte(`{
+		"what": "It crashes",
+		"version": "v1.2",
+		"logs": "panic: boom",
+		"browsers": ["Firefox", "Chrome"],
+		"terms": ["I searched existing issues"]
+	}`), &values)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := renderForm(tmpl, values)
+	if err != nil {
+		t.Fatalf("renderForm() error = %v", err)
+	}
+	want := "### What happened?\n\nIt crashes\n\n" +
+		"### Version\n\nv1.2\n\n" +
+		"### Count\n\n_No response_\n\n" +
+		"### Logs\n\n```shell\npanic: boom\n```\n\n" +
+		"### OS\n\nLinux\n\n" +
+		"### Browsers\n\nFirefox, Chrome\n\n" +
+		"### Terms\n\n- [x] I searched existing issues\n- [ ] I want to fix it"
+	if body != want {
+		t.Errorf("renderForm() =\n%s\nwant\n%s", body, want)
+	}
+}
+
+func TestRenderForm_Validation(t *testing.T) {
+	tmpl := loadTemplate(t, bugForm)
+	_, err := renderForm(tmpl, map[string]any{
+		"version":  "1.2",
+		"count":    "many",
+		"os":       "Windows",
+		"browsers": []any{"Firefox"},
+		"typo":     "x",
+	})
+	if err == nil {
+		t.Fatal("renderForm() should fail")
+	}
+	for _, want := range []string{
+		`unknown field "typo"`,
+		`field "what": required`,
+		`field "version": "1.2" does not match`,
+		`field "count": "many" is not a number`,
+		`field "os": "Windows" is not an option`,
+		`field "terms": option "I searched existing issues" must be checked`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error should contain %q, got:\n%v", want, err)
+		}
+	}
+}
+
+func TestFindTemplate(t *testing.T) {
+	templates := []*types.MyIssueTemplate{
+		loadTemplate(t, bugForm),
+		{Name: "Feature Request", FileName: "feature.md"},
+	}
+	for _, name := range []string{"Bug Report", "bug report", "bug.yaml", "bug"} {
+		if tmpl, err := findTemplate(templates, name); err != nil || tmpl.Name != "Bug Report" {
+			t.Errorf("findTemplate(%q) = %v, %v", name, tmpl, err)
+		}
+	}
+	_, err := findTemplate(templates, "question")
+	if err == nil || !strings.Contains(err.Error(), "available: Bug Report, Feature Request") {
+		t.Errorf("findTemplate() error = %v", err)
+	}
+}
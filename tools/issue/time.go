@@ -0,0 +1,440 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// ListTrackedTimesParams defines the parameters for the list_tracked_times
+// tool. It selects the issue, repository or user and the time window.
+type ListTrackedTimesParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner,omitempty"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo,omitempty"`
+	// Index is the issue number to list tracked times of.
+	Index int `json:"index,omitempty"`
+	// User filters tracked times by username.
+	User string `json:"user,omitempty"`
+	// Since is a timestamp in RFC 3339 format to show only times tracked after this time.
+	Since *string `json:"since,omitempty"`
+	// Before is a timestamp in RFC 3339 format to show only times tracked before this time.
+	Before *string `json:"before,omitempty"`
+	// Page is the page number for pagination.
+	Page int `json:"page,omitempty"`
+	// Limit is the number of tracked times to return per page.
+	Limit int `json:"limit,omitempty"`
+}
+
+// ListTrackedTimesImpl implements the read-only MCP tool for listing tracked
+// times of an issue, a repository or the authenticated user. This is a safe,
+// idempotent operation. Note: The SDK's GetMyTrackedTimes does not accept
+// filters, so a custom HTTP implementation is used for the user's own times.
+type ListTrackedTimesImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `list_tracked_times` tool. All parameters are
+// optional. It is marked as a safe, read-only operation.
+func (ListTrackedTimesImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "list_tracked_times",
+		Title:       "List Tracked Times",
+		Description: "List times tracked on an issue (owner, repo and index), on a repository (owner and repo), or by yourself across all repositories (no owner and repo). Filter by user and date. Returns the entries and their total.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name), requires repo (optional)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name, requires owner (optional)",
+				},
+				"index": {
+					Type:        "integer",
+					Description: "Issue index number, requires owner and repo (optional)",
+				},
+				"user": {
+					Type:        "string",
+					Description: "Only times tracked by this user, with owner and repo (optional)",
+				},
+				"since": {
+					Type:        "string",
+					Description: "Only show times tracked after this time (RFC 3339 format, optional)",
+					Format:      "date-time",
+				},
+				"before": {
+					Type:        "string",
+					Description: "Only show times tracked before this time (RFC 3339 format, optional)",
+					Format:      "date-time",
+				},
+				"page": {
+					Type:        "integer",
+					Description: "Page number for pagination (optional, defaults to 1)",
+					Minimum:     tools.Float64Ptr(1),
+				},
+				"limit": {
+					Type:        "integer",
+					Description: "Number of entries per page (optional, defaults to 50, max 50)",
+					Minimum:     tools.Float64Ptr(1),
+					Maximum:     tools.Float64Ptr(50),
+				},
+			},
+		},
+	}
+}
+
+// Handler implements the logic for listing tracked times. It calls the Forgejo
+// SDK's `ListIssueTrackedTimes` or `ListRepoTrackedTimes` function, or performs
+// a custom HTTP GET request to `/user/times`.
+func (impl ListTrackedTimesImpl) Handler() mcp.ToolHandlerFor[ListTrackedTimesParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args ListTrackedTimesParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		if (p.Owner == "") != (p.Repo == "") {
+			return nil, nil, errors.New("owner and repo must be given together")
+		}
+		if p.Repo == "" && (p.Index > 0 || p.User != "") {
+			return nil, nil, errors.New("index and user require owner and repo")
+		}
+
+		page := max(p.Page, 1)
+		limit := p.Limit
+		if limit <= 0 {
+			limit = 50
+		}
+
+		// Build options for SDK call
+		opt := forgejo.ListTrackedTimesOptions{
+			ListOptions: forgejo.ListOptions{Page: page, PageSize: limit},
+			User:        p.User,
+		}
+		since, before, err := parseTimeWindow(p.Since, p.Before)
+		if err != nil {
+			return nil, nil, err
+		}
+		opt.Since, opt.Before = since, before
+
+		var times []*forgejo.TrackedTime
+		var header string
+		switch {
+		case p.Index > 0:
+			header = fmt.Sprintf("Times tracked on %s/%s#%d", p.Owner, p.Repo, p.Index)
+			times, _, err = impl.Client.ListIssueTrackedTimes(p.Owner, p.Repo, int64(p.Index), opt)
+		case p.Repo != "":
+			header = fmt.Sprintf("Times tracked on %s/%s", p.Owner, p.Repo)
+			times, _, err = impl.Client.ListRepoTrackedTimes(p.Owner, p.Repo, opt)
+		default:
+			header = "Times tracked by you"
+			times, err = impl.Client.MyListMyTrackedTimes(opt)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list tracked times: %w", err)
+		}
+
+		// Convert to our types and format
+		timeList := types.TrackedTimeList(times)
+		content := fmt.Sprintf("%s, page %d:\n\n%s", header, page, timeList.ToMarkdown())
+		if len(times) >= limit {
+			content += fmt.Sprintf("\n\nMore entries may be available, use page %d to see them.", page+1)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: content,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// parseTimeWindow parses optional RFC 3339 since and before arguments.
+func parseTimeWindow(sinceArg, beforeArg *string) (since, before time.Time, err error) {
+	if sinceArg != nil {
+		since, err = time.Parse(time.RFC3339, *sinceArg)
+		if err != nil {
+			return since, before, fmt.Errorf("invalid since timestamp format (expected RFC 3339): %w", err)
+		}
+	}
+	if beforeArg != nil {
+		before, err = time.Parse(time.RFC3339, *beforeArg)
+		if err != nil {
+			return since, before, fmt.Errorf("invalid before timestamp format (expected RFC 3339): %w", err)
+		}
+	}
+	return since, before, nil
+}
+
+// AddTrackedTimeParams defines the parameters for the add_tracked_time tool.
+// It specifies the issue and the time spent.
+type AddTrackedTimeParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Index is the issue number.
+	Index int `json:"index"`
+	// Duration is the time spent, e.g. "1h30m".
+	Duration string `json:"duration"`
+	// Created is the time the work was done in RFC 3339 format.
+	Created *string `json:"created,omitempty"`
+	// User is the user who did the work, repository admins only.
+	User string `json:"user,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// AddTrackedTimeImpl implements the MCP tool for adding tracked time to an
+// issue. This is a non-idempotent operation that uses the Forgejo SDK.
+type AddTrackedTimeImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `add_tracked_time` tool. It requires `owner`, `repo`,
+// `index` and `duration`. It is not idempotent, as every call adds an entry.
+func (AddTrackedTimeImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "add_tracked_time",
+		Title:       "Add Tracked Time",
+		Description: "Add time spent on an issue, e.g. '1h30m'. Every call adds a new entry.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  false,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"index": {
+					Type:        "integer",
+					Description: "Issue index number",
+				},
+				"duration": {
+					Type:        "string",
+					Description: "Time spent, e.g. '45m', '1h30m'",
+				},
+				"created": {
+					Type:        "string",
+					Description: "When the work was done (RFC 3339 format, optional, defaults to now)",
+					Format:      "date-time",
+				},
+				"user": {
+					Type:        "string",
+					Description: "Username of who did the work, repository admins only (optional, defaults to you)",
+				},
+				"dry_run": tools.DryRunSchema(),
+			},
+			Required: []string{"owner", "repo", "index", "duration"},
+		},
+	}
+}
+
+// Handler implements the logic for adding tracked time. It calls the Forgejo
+// SDK's `AddTime` function and formats the new entry into markdown.
+func (impl AddTrackedTimeImpl) Handler() mcp.ToolHandlerFor[AddTrackedTimeParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args AddTrackedTimeParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		d, err := time.ParseDuration(p.Duration)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid duration (expected e.g. '1h30m'): %w", err)
+		}
+		if d < time.Second {
+			return nil, nil, errors.New("duration must be at least one second")
+		}
+
+		// Build options for SDK call
+		opt := forgejo.AddTimeOption{
+			Time: int64(d / time.Second),
+			User: p.User,
+		}
+		if p.Created != nil {
+			created, err := time.Parse(time.RFC3339, *p.Created)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid created timestamp format (expected RFC 3339): %w", err)
+			}
+			opt.Created = created
+		}
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, opt)
+			return res, nil, err
+		}
+
+		// Call SDK
+		t, _, err := impl.Client.AddTime(p.Owner, p.Repo, int64(p.Index), opt)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to add tracked time: %w", err)
+		}
+
+		// Convert to our type and format
+		wrapper := &types.TrackedTime{TrackedTime: t}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: wrapper.ToMarkdown(),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// dryRun checks the issue exists and previews the time to be added.
+func (impl AddTrackedTimeImpl) dryRun(p AddTrackedTimeParams, opt forgejo.AddTimeOption) (*mcp.CallToolResult, error) {
+	issue, _, err := impl.Client.GetIssue(p.Owner, p.Repo, int64(p.Index))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+	user := opt.User
+	if user == "" {
+		user = "you"
+	}
+	preview := fmt.Sprintf("Would add %s by %s to %s/%s#%d %s", types.FormatSeconds(opt.Time), user, p.Owner, p.Repo, issue.Index, issue.Title)
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "POST",
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/issues/%d/times", p.Owner, p.Repo, p.Index),
+		Body:     opt,
+	})
+}
+
+// DeleteTrackedTimeParams defines the parameters for the delete_tracked_time
+// tool. It specifies the tracked time entry by ID.
+type DeleteTrackedTimeParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Index is the issue number.
+	Index int `json:"index"`
+	// TimeID is the ID of the tracked time entry.
+	TimeID int `json:"time_id"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// DeleteTrackedTimeImpl implements the destructive MCP tool for deleting a
+// tracked time entry. This is an idempotent but irreversible operation that
+// uses the Forgejo SDK.
+type DeleteTrackedTimeImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `delete_tracked_time` tool. It requires `owner`,
+// `repo`, `index` and `time_id`. It is marked as a destructive operation to
+// ensure clients can warn the user before execution.
+func (DeleteTrackedTimeImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "delete_tracked_time",
+		Title:       "Delete Tracked Time",
+		Description: "Delete a tracked time entry from an issue. This action cannot be undone.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(true),
+			IdempotentHint:  true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"index": {
+					Type:        "integer",
+					Description: "Issue index number",
+				},
+				"time_id": {
+					Type:        "integer",
+					Description: "Tracked time entry ID, see list_tracked_times",
+				},
+				"dry_run": tools.DryRunSchema(),
+			},
+			Required: []string{"owner", "repo", "index", "time_id"},
+		},
+	}
+}
+
+// Handler implements the logic for deleting tracked time. It calls the Forgejo
+// SDK's `DeleteTime` function.
+func (impl DeleteTrackedTimeImpl) Handler() mcp.ToolHandlerFor[DeleteTrackedTimeParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args DeleteTrackedTimeParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p)
+			return res, nil, err
+		}
+
+		_, err := impl.Client.DeleteTime(p.Owner, p.Repo, int64(p.Index), int64(p.TimeID))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to delete tracked time: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("Tracked time %d successfully deleted.", p.TimeID),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// dryRun previews the tracked time entry to be deleted.
+func (impl DeleteTrackedTimeImpl) dryRun(p DeleteTrackedTimeParams) (*mcp.CallToolResult, error) {
+	times, _, err := impl.Client.ListIssueTrackedTimes(p.Owner, p.Repo, int64(p.Index), forgejo.ListTrackedTimesOptions{
+		ListOptions: forgejo.ListOptions{Page: -1},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tracked times: %w", err)
+	}
+	for _, t := range times {
+		if t.ID != int64(p.TimeID) {
+			continue
+		}
+		wrapper := &types.TrackedTime{TrackedTime: t}
+		preview := fmt.Sprintf("Would delete tracked time in %s/%s:\n\n%s", p.Owner, p.Repo, wrapper.ToMarkdown())
+		return impl.Client.DryRunResult(preview, tools.APIRequest{
+			Method:   "DELETE",
+			Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/issues/%d/times/%d", p.Owner, p.Repo, p.Index, p.TimeID),
+		})
+	}
+	return nil, fmt.Errorf("tracked time %d not found on %s/%s#%d", p.TimeID, p.Owner, p.Repo, p.Index)
+}
@@ -0,0 +1,113 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// GetIssueTimelineParams defines the parameters for the get_issue_timeline tool.
+// It specifies the issue or pull request.
+type GetIssueTimelineParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Index is the issue or pull request number.
+	Index int `json:"index"`
+	// Since is a timestamp in RFC 3339 format to show only events after this time.
+	Since *string `json:"since,omitempty"`
+}
+
+// GetIssueTimelineImpl implements the read-only MCP tool for getting the
+// history of an issue or pull request. This is a safe, idempotent operation.
+// Note: This feature is not supported by the official Forgejo SDK and requires
+// a custom HTTP implementation.
+type GetIssueTimelineImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `get_issue_timeline` tool. It requires `owner`, `repo`
+// and `index`. It is marked as a safe, read-only operation.
+func (GetIssueTimelineImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "get_issue_timeline",
+		Title:       "Get Issue Timeline",
+		Description: "Get the chronological history of an issue or pull request in one view: its opening, comments, label, assignee and milestone changes, state changes, title changes, referencing commits, cross-references, dependencies, pushes and reviews.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"index": {
+					Type:        "integer",
+					Description: "Issue or pull request index number",
+				},
+				"since": {
+					Type:        "string",
+					Description: "Only show events after this time (RFC 3339 format, optional)",
+					Format:      "date-time",
+				},
+			},
+			Required: []string{"owner", "repo", "index"},
+		},
+	}
+}
+
+// Handler implements the logic for getting the timeline. It gets the issue via
+// the Forgejo SDK and its events via a custom HTTP GET request to
+// `/repos/{owner}/{repo}/issues/{index}/timeline`.
+func (impl GetIssueTimelineImpl) Handler() mcp.ToolHandlerFor[GetIssueTimelineParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args GetIssueTimelineParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		since, _, err := parseTimeWindow(p.Since, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		issue, _, err := impl.Client.GetIssue(p.Owner, p.Repo, int64(p.Index))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get issue: %w", err)
+		}
+
+		// Call custom client method
+		events, err := impl.Client.MyListIssueTimeline(p.Owner, p.Repo, int64(p.Index), since)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get issue timeline: %w", err)
+		}
+		sort.SliceStable(events, func(i, j int) bool { return events[i].Created.Before(events[j].Created) })
+
+		timeline := types.IssueTimeline{Issue: issue, Events: events, Since: since}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: timeline.ToMarkdown(),
+				},
+			},
+		}, nil, nil
+	}
+}
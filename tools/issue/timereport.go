@@ -0,0 +1,295 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// GetMilestoneTimeReportParams defines the parameters for the
+// get_milestone_time_report tool. It specifies the milestone and the time
+// window.
+type GetMilestoneTimeReportParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Milestone is the ID of the milestone.
+	Milestone int `json:"milestone"`
+	// Since is a timestamp in RFC 3339 format to count only times tracked after this time.
+	Since *string `json:"since,omitempty"`
+	// Before is a timestamp in RFC 3339 format to count only times tracked before this time.
+	Before *string `json:"before,omitempty"`
+}
+
+// GetMilestoneTimeReportImpl implements the read-only MCP tool for reporting
+// the time tracked on the issues and pull requests of a milestone, aggregated
+// by user. This is a safe, idempotent operation that uses the Forgejo SDK.
+type GetMilestoneTimeReportImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `get_milestone_time_report` tool. It requires
+// `owner`, `repo` and `milestone`. It is marked as a safe, read-only operation.
+func (GetMilestoneTimeReportImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "get_milestone_time_report",
+		Title:       "Get Milestone Time Report",
+		Description: "Report the time tracked on all issues and pull requests of a milestone, optionally in a date range: totals per user, and per issue broken down by user. The server shows the times of all users only to repository administrators, for others the report covers their own times and says so.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"milestone": {
+					Type:        "integer",
+					Description: "Milestone ID",
+				},
+				"since": {
+					Type:        "string",
+					Description: "Only count times tracked after this time (RFC 3339 format, optional)",
+					Format:      "date-time",
+				},
+				"before": {
+					Type:        "string",
+					Description: "Only count times tracked before this time (RFC 3339 format, optional)",
+					Format:      "date-time",
+				},
+			},
+			Required: []string{"owner", "repo", "milestone"},
+		},
+	}
+}
+
+// Handler implements the logic for the time report. It lists the issues of the
+// milestone and the tracked times of the repository, and aggregates the times
+// of the milestone's issues.
+func (impl GetMilestoneTimeReportImpl) Handler() mcp.ToolHandlerFor[GetMilestoneTimeReportParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args GetMilestoneTimeReportParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		since, before, err := parseTimeWindow(p.Since, p.Before)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		milestone, _, err := impl.Client.GetMilestone(p.Owner, p.Repo, int64(p.Milestone))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get milestone: %w", err)
+		}
+
+		const pageSize = 50
+		var issues []*forgejo.Issue
+		for page := 1; ; page++ {
+			list, _, err := impl.Client.ListRepoIssues(p.Owner, p.Repo, forgejo.ListIssueOption{
+				ListOptions: forgejo.ListOptions{Page: page, PageSize: pageSize},
+				State:       forgejo.StateAll,
+				Type:        forgejo.IssueTypeAll,
+				Milestones:  []string{milestone.Title},
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to list issues: %w", err)
+			}
+			issues = append(issues, list...)
+			if len(list) < pageSize {
+				break
+			}
+		}
+
+		var times []*forgejo.TrackedTime
+		for page := 1; ; page++ {
+			list, _, err := impl.Client.ListRepoTrackedTimes(p.Owner, p.Repo, forgejo.ListTrackedTimesOptions{
+				ListOptions: forgejo.ListOptions{Page: page, PageSize: pageSize},
+				Since:       since,
+				Before:      before,
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to list tracked times: %w", err)
+			}
+			times = append(times, list...)
+			if len(list) < pageSize {
+				break
+			}
+		}
+
+		report := buildTimeReport(issues, times)
+		content := fmt.Sprintf("# Time report of milestone %s in %s/%s\n\n", milestone.Title, p.Owner, p.Repo)
+		own, err := onlyOwnTimes(impl.Client, p.Owner, p.Repo)
+		if err != nil {
+			return nil, nil, err
+		}
+		if own != "" {
+			content += fmt.Sprintf("**Only the times tracked by %s are included**: the server returns the times of other users only to repository administrators.\n\n", own)
+		}
+		content += report.ToMarkdown()
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: content,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// onlyOwnTimes returns the name of the authenticated user if the server lists
+// only their own tracked times in the repository, as it does for users who
+// are neither repository nor site administrators. It returns "" otherwise.
+func onlyOwnTimes(cl *tools.Client, owner, repo string) (string, error) {
+	r, _, err := cl.GetRepo(owner, repo)
+	if err != nil {
+		return "", fmt.Errorf("failed to get repository: %w", err)
+	}
+	if r.Permissions != nil && r.Permissions.Admin {
+		return "", nil
+	}
+	me, _, err := cl.GetMyUserInfo()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current user: %w", err)
+	}
+	if me.IsAdmin {
+		return "", nil
+	}
+	return me.UserName, nil
+}
+
+// issueTime is the time tracked on an issue per user.
+type issueTime struct {
+	Issue *forgejo.Issue
+	Total int64
+	Users map[string]int64
+}
+
+// userTime is the time tracked by a user.
+type userTime struct {
+	User   string
+	Total  int64
+	Issues int
+}
+
+// timeReport aggregates tracked times of a set of issues.
+type timeReport struct {
+	Total  int64
+	Users  []*userTime
+	Issues []*issueTime
+}
+
+// buildTimeReport aggregates the times tracked on issues; times of other issues
+// are ignored. Users and issues are sorted by total time, most first.
+func buildTimeReport(issues []*forgejo.Issue, times []*forgejo.TrackedTime) *timeReport {
+	byID := make(map[int64]*issueTime, len(issues))
+	for _, i := range issues {
+		byID[i.ID] = &issueTime{Issue: i, Users: map[string]int64{}}
+	}
+
+	ret := &timeReport{}
+	users := map[string]*userTime{}
+	userIssues := map[string]map[int64]bool{}
+	for _, t := range times {
+		it, ok := byID[t.IssueID]
+		if !ok {
+			continue
+		}
+		ret.Total += t.Time
+		it.Total += t.Time
+		it.Users[t.UserName] += t.Time
+
+		u, ok := users[t.UserName]
+		if !ok {
+			u = &userTime{User: t.UserName}
+			users[t.UserName] = u
+			userIssues[t.UserName] = map[int64]bool{}
+		}
+		u.Total += t.Time
+		userIssues[t.UserName][t.IssueID] = true
+	}
+
+	for name, u := range users {
+		u.Issues = len(userIssues[name])
+		ret.Users = append(ret.Users, u)
+	}
+	sort.Slice(ret.Users, func(i, j int) bool {
+		if ret.Users[i].Total != ret.Users[j].Total {
+			return ret.Users[i].Total > ret.Users[j].Total
+		}
+		return ret.Users[i].User < ret.Users[j].User
+	})
+
+	for _, it := range byID {
+		if it.Total > 0 {
+			ret.Issues = append(ret.Issues, it)
+		}
+	}
+	sort.Slice(ret.Issues, func(i, j int) bool {
+		if ret.Issues[i].Total != ret.Issues[j].Total {
+			return ret.Issues[i].Total > ret.Issues[j].Total
+		}
+		return ret.Issues[i].Issue.Index < ret.Issues[j].Issue.Index
+	})
+	return ret
+}
+
+// ToMarkdown renders the report as a per-user table followed by a per-issue
+// table.
+func (r *timeReport) ToMarkdown() string {
+	if r.Total == 0 {
+		return "*No tracked times found*"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Total: %s\n\n## By user\n\n", types.FormatSeconds(r.Total))
+	b.WriteString("| User | Time | Share | Issues |\n")
+	b.WriteString("|------|------|-------|--------|\n")
+	for _, u := range r.Users {
+		fmt.Fprintf(&b, "| %s | %s | %.1f%% | %d |\n",
+			u.User, types.FormatSeconds(u.Total), float64(u.Total)*100/float64(r.Total), u.Issues)
+	}
+
+	b.WriteString("\n## By issue\n\n")
+	b.WriteString("| Issue | State | Time | Users |\n")
+	b.WriteString("|-------|-------|------|-------|\n")
+	for _, it := range r.Issues {
+		names := make([]string, 0, len(it.Users))
+		for name := range it.Users {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if it.Users[names[i]] != it.Users[names[j]] {
+				return it.Users[names[i]] > it.Users[names[j]]
+			}
+			return names[i] < names[j]
+		})
+		parts := make([]string, len(names))
+		for i, name := range names {
+			parts[i] = fmt.Sprintf("%s (%s)", name, types.FormatSeconds(it.Users[name]))
+		}
+		fmt.Fprintf(&b, "| #%d %s | %s | %s | %s |\n",
+			it.Issue.Index, it.Issue.Title, it.Issue.State, types.FormatSeconds(it.Total), strings.Join(parts, ", "))
+	}
+	return b.String()
+}
@@ -0,0 +1,108 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package issue
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+)
+
+func TestBuildTimeReport(t *testing.T) {
+	issues := []*forgejo.Issue{
+		{ID: 100, Index: 1, Title: "Login", State: "closed"},
+		{ID: 200, Index: 2, Title: "Search", State: "open"},
+		{ID: 300, Index: 3, Title: "Untracked", State: "open"},
+	}
+	times := []*forgejo.TrackedTime{
+		{IssueID: 100, UserName: "alice", Time: 3600},
+		{IssueID: 100, UserName: "bob", Time: 1800},
+		{IssueID: 200, UserName: "alice", Time: 7200},
+		{IssueID: 100, UserName: "alice", Time: 600},
+		// Not in the milestone
+		{IssueID: 999, UserName: "carol", Time: 36000},
+	}
+
+	r := buildTimeReport(issues, times)
+	if r.Total != 13200 {
+		t.Errorf("Total = %d, want 13200", r.Total)
+	}
+	if len(r.Users) != 2 {
+		t.Fatalf("got %d users, want 2", len(r.Users))
+	}
+	if u := r.Users[0]; u.User != "alice" || u.Total != 11400 || u.Issues != 2 {
+		t.Errorf("first user = %+v, want alice with 11400s on 2 issues", *u)
+	}
+	if u := r.Users[1]; u.User != "bob" || u.Total != 1800 || u.Issues != 1 {
+		t.Errorf("second user = %+v, want bob with 1800s on 1 issue", *u)
+	}
+	if len(r.Issues) != 2 || r.Issues[0].Issue.Index != 2 || r.Issues[1].Issue.Index != 1 {
+		t.Errorf("issues not ranked by time: %+v", r.Issues)
+	}
+
+	md := r.ToMarkdown()
+	for _, want := range []string{
+		"Total: 3h 40m",
+		"| alice | 3h 10m | 86.4% | 2 |",
+		"| bob | 30m | 13.6% | 1 |",
+		"| #2 Search | open | 2h | alice (2h) |",
+		"| #1 Login | closed | 1h 40m | alice (1h 10m), bob (30m) |",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("report missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "carol") || strings.Contains(md, "Untracked") {
+		t.Errorf("report includes times outside the milestone:\n%s", md)
+	}
+}
+
+func TestBuildTimeReport_Empty(t *testing.T) {
+	r := buildTimeReport([]*forgejo.Issue{{ID: 1}}, nil)
+	if got := r.ToMarkdown(); !strings.Contains(got, "No tracked times found") {
+		t.Errorf("ToMarkdown() = %q", got)
+	}
+}
+
+func TestOnlyOwnTimes(t *testing.T) {
+	var repoAdmin, siteAdmin bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/repos/owner/repo":
+			json.NewEncoder(w).Encode(map[string]any{"name": "repo", "permissions": map[string]bool{"admin": repoAdmin}})
+		case "/api/v1/user":
+			json.NewEncoder(w).Encode(map[string]any{"login": "me", "is_admin": siteAdmin})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	cl, err := tools.NewClient(server.URL, "token", "11.0.1+gitea-1.22.0", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		repoAdmin, siteAdmin bool
+		want                 string
+	}{
+		{false, false, "me"},
+		{true, false, ""},
+		{false, true, ""},
+	} {
+		repoAdmin, siteAdmin = tc.repoAdmin, tc.siteAdmin
+		if got, err := onlyOwnTimes(cl, "owner", "repo"); err != nil || got != tc.want {
+			t.Errorf("onlyOwnTimes() as repo admin %v, site admin %v = %q, %v, want %q", tc.repoAdmin, tc.siteAdmin, got, err, tc.want)
+		}
+	}
+}
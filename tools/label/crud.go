@@ -9,6 +9,7 @@ package label
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
 	"github.com/google/jsonschema-go/jsonschema"
@@ -114,6 +115,8 @@ type CreateLabelParams struct {
 	Color string `json:"color"`
 	// Description is the optional markdown description of the label.
 	Description string `json:"description,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // CreateLabelImpl implements the MCP tool for creating a new repository label.
@@ -158,6 +161,7 @@ func (CreateLabelImpl) Definition() *mcp.Tool {
 					Type:        "string",
 					Description: "Optional label description",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "name", "color"},
 		},
@@ -177,6 +181,12 @@ func (impl CreateLabelImpl) Handler() mcp.ToolHandlerFor[CreateLabelParams, any]
 			Description: p.Description,
 		}
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, opt)
+			return res, nil, err
+		}
+
 		// Call SDK
 		label, _, err := impl.Client.CreateLabel(p.Owner, p.Repo, opt)
 		if err != nil {
@@ -196,6 +206,37 @@ func (impl CreateLabelImpl) Handler() mcp.ToolHandlerFor[CreateLabelParams, any]
 	}
 }
 
+// dryRun validates opt, checks the name is not taken, and previews the label
+// to be created.
+func (impl CreateLabelImpl) dryRun(p CreateLabelParams, opt forgejo.CreateLabelOption) (*mcp.CallToolResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+	labels, _, err := impl.Client.ListRepoLabels(p.Owner, p.Repo, forgejo.ListLabelsOptions{
+		ListOptions: forgejo.ListOptions{Page: -1},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+	for _, l := range labels {
+		if strings.EqualFold(l.Name, opt.Name) {
+			return nil, fmt.Errorf("label %q already exists in %s/%s (ID %d)", l.Name, p.Owner, p.Repo, l.ID)
+		}
+	}
+
+	labelWrapper := &types.Label{Label: &forgejo.Label{
+		Name:        opt.Name,
+		Color:       strings.TrimPrefix(opt.Color, "#"),
+		Description: opt.Description,
+	}}
+	preview := fmt.Sprintf("Would create label in %s/%s: %s", p.Owner, p.Repo, labelWrapper.ToMarkdown())
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "POST",
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/labels", p.Owner, p.Repo),
+		Body:     opt,
+	})
+}
+
 // EditLabelParams defines the parameters for the edit_label tool.
 // It specifies the label to edit by ID and the fields to update.
 type EditLabelParams struct {
@@ -211,6 +252,8 @@ type EditLabelParams struct {
 	Color string `json:"color,omitempty"`
 	// Description is the new optional markdown description for the label.
 	Description string `json:"description,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // EditLabelImpl implements the MCP tool for editing an existing repository label.
@@ -259,6 +302,7 @@ func (EditLabelImpl) Definition() *mcp.Tool {
 					Type:        "string",
 					Description: "New label description (optional)",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "id"},
 		},
@@ -283,6 +327,12 @@ func (impl EditLabelImpl) Handler() mcp.ToolHandlerFor[EditLabelParams, any] {
 			opt.Description = &p.Description
 		}
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, opt)
+			return res, nil, err
+		}
+
 		// Call SDK
 		label, _, err := impl.Client.EditLabel(p.Owner, p.Repo, int64(p.ID), opt)
 		if err != nil {
@@ -302,6 +352,37 @@ func (impl EditLabelImpl) Handler() mcp.ToolHandlerFor[EditLabelParams, any] {
 	}
 }
 
+// dryRun validates opt and previews the label before and after the edit.
+func (impl EditLabelImpl) dryRun(p EditLabelParams, opt forgejo.EditLabelOption) (*mcp.CallToolResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+	label, _, err := impl.Client.GetRepoLabel(p.Owner, p.Repo, int64(p.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get label: %w", err)
+	}
+
+	before := &types.Label{Label: label}
+	edited := *label
+	if opt.Name != nil {
+		edited.Name = *opt.Name
+	}
+	if opt.Color != nil {
+		edited.Color = strings.TrimPrefix(*opt.Color, "#")
+	}
+	if opt.Description != nil {
+		edited.Description = *opt.Description
+	}
+	after := &types.Label{Label: &edited}
+
+	preview := fmt.Sprintf("Would edit label in %s/%s:\n\n- **Before**: %s\n- **After**: %s", p.Owner, p.Repo, before.ToMarkdown(), after.ToMarkdown())
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "PATCH",
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/labels/%d", p.Owner, p.Repo, p.ID),
+		Body:     opt,
+	})
+}
+
 // DeleteLabelParams defines the parameters for the delete_label tool.
 // It specifies the label to be deleted by its ID.
 type DeleteLabelParams struct {
@@ -311,20 +392,25 @@ type DeleteLabelParams struct {
 	Repo string `json:"repo"`
 	// ID is the unique identifier of the label to delete.
 	ID int `json:"id"`
+	// ConfirmToken confirms the operation, see tools.Confirmer.
+	ConfirmToken string `json:"confirm_token,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // DeleteLabelImpl implements the destructive MCP tool for deleting a repository label.
 // This is an idempotent but irreversible operation that removes a label from a
 // repository using the Forgejo SDK.
 type DeleteLabelImpl struct {
-	Client *tools.Client
+	Client  *tools.Client
+	Confirm *tools.Confirmer
 }
 
 // Definition describes the `delete_label` tool. It requires `owner`, `repo`, and
 // the label `id`. It is marked as a destructive operation to ensure clients
 // can warn the user before execution.
-func (DeleteLabelImpl) Definition() *mcp.Tool {
-	return &mcp.Tool{
+func (impl DeleteLabelImpl) Definition() *mcp.Tool {
+	def := &mcp.Tool{
 		Name:        "delete_label",
 		Title:       "Delete Label",
 		Description: "Delete a label from a repository.",
@@ -348,10 +434,15 @@ func (DeleteLabelImpl) Definition() *mcp.Tool {
 					Type:        "integer",
 					Description: "Label ID to delete",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "id"},
 		},
 	}
+	if impl.Confirm != nil {
+		def.InputSchema.Properties["confirm_token"] = tools.ConfirmTokenSchema()
+	}
+	return def
 }
 
 // Handler implements the logic for deleting a label. It calls the Forgejo SDK's
@@ -361,8 +452,23 @@ func (impl DeleteLabelImpl) Handler() mcp.ToolHandlerFor[DeleteLabelParams, any]
 	return func(ctx context.Context, req *mcp.CallToolRequest, args DeleteLabelParams) (*mcp.CallToolResult, any, error) {
 		p := args
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p)
+			return res, nil, err
+		}
+
+		// Ask for confirmation if required
+		action := fmt.Sprintf("delete_label:%s/%s:%d", p.Owner, p.Repo, p.ID)
+		res, err := impl.Confirm.Check(ctx, req, action, p.ConfirmToken, func() (string, error) {
+			return impl.preview(p)
+		})
+		if err != nil || res != nil {
+			return res, nil, err
+		}
+
 		// Call SDK
-		_, err := impl.Client.DeleteLabel(p.Owner, p.Repo, int64(p.ID))
+		_, err = impl.Client.DeleteLabel(p.Owner, p.Repo, int64(p.ID))
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to delete label: %w", err)
 		}
@@ -379,3 +485,41 @@ func (impl DeleteLabelImpl) Handler() mcp.ToolHandlerFor[DeleteLabelParams, any]
 		}, nil, nil
 	}
 }
+
+// dryRun previews the label to be deleted.
+func (impl DeleteLabelImpl) dryRun(p DeleteLabelParams) (*mcp.CallToolResult, error) {
+	preview, err := impl.preview(p)
+	if err != nil {
+		return nil, err
+	}
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "DELETE",
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/labels/%d", p.Owner, p.Repo, p.ID),
+	})
+}
+
+// preview describes the label to be deleted and how many issues are using it.
+func (impl DeleteLabelImpl) preview(p DeleteLabelParams) (string, error) {
+	label, _, err := impl.Client.GetRepoLabel(p.Owner, p.Repo, int64(p.ID))
+	if err != nil {
+		return "", fmt.Errorf("failed to get label: %w", err)
+	}
+
+	_, resp, err := impl.Client.ListRepoIssues(p.Owner, p.Repo, forgejo.ListIssueOption{
+		ListOptions: forgejo.ListOptions{PageSize: 1},
+		State:       forgejo.StateAll,
+		Type:        forgejo.IssueTypeAll,
+		Labels:      []string{label.Name},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to count labeled issues: %w", err)
+	}
+	count := resp.Header.Get("X-Total-Count")
+	if count == "" {
+		count = "unknown number of"
+	}
+
+	labelWrapper := &types.Label{Label: label}
+	return fmt.Sprintf("About to delete label %s from %s/%s. It will be removed from %s issues and pull requests.",
+		labelWrapper.ToMarkdown(), p.Owner, p.Repo, count), nil
+}
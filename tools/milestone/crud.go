@@ -126,6 +126,8 @@ type CreateMilestoneParams struct {
 	Description string `json:"description,omitempty"`
 	// DueDate is the optional due date for the milestone.
 	DueDate time.Time `json:"due_date,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // CreateMilestoneImpl implements the MCP tool for creating a new milestone.
@@ -172,6 +174,7 @@ func (CreateMilestoneImpl) Definition() *mcp.Tool {
 					Description: "Milestone due date in ISO 8601 format (e.g., '2024-12-31T23:59:59Z') (optional)",
 					Format:      "date-time",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "title"},
 		},
@@ -195,6 +198,12 @@ func (impl CreateMilestoneImpl) Handler() mcp.ToolHandlerFor[CreateMilestonePara
 			opt.Deadline = &p.DueDate
 		}
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, opt)
+			return res, nil, err
+		}
+
 		// Call SDK
 		milestone, _, err := impl.Client.CreateMilestone(p.Owner, p.Repo, opt)
 		if err != nil {
@@ -214,6 +223,30 @@ func (impl CreateMilestoneImpl) Handler() mcp.ToolHandlerFor[CreateMilestonePara
 	}
 }
 
+// dryRun validates opt, checks the title is not taken, and previews the
+// milestone to be created.
+func (impl CreateMilestoneImpl) dryRun(p CreateMilestoneParams, opt forgejo.CreateMilestoneOption) (*mcp.CallToolResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+	if m, _, err := impl.Client.GetMilestoneByName(p.Owner, p.Repo, opt.Title); err == nil && m != nil {
+		return nil, fmt.Errorf("milestone %q already exists in %s/%s (ID %d)", m.Title, p.Owner, p.Repo, m.ID)
+	}
+
+	milestoneWrapper := &types.Milestone{Milestone: &forgejo.Milestone{
+		Title:       opt.Title,
+		Description: opt.Description,
+		State:       forgejo.StateOpen,
+		Deadline:    opt.Deadline,
+	}}
+	preview := fmt.Sprintf("Would create milestone in %s/%s:\n\n%s", p.Owner, p.Repo, milestoneWrapper.ToMarkdown())
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "POST",
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/milestones", p.Owner, p.Repo),
+		Body:     opt,
+	})
+}
+
 // EditMilestoneParams defines the parameters for the edit_milestone tool.
 // It specifies the milestone to edit by ID and the fields to update.
 type EditMilestoneParams struct {
@@ -231,6 +264,8 @@ type EditMilestoneParams struct {
 	DueDate time.Time `json:"due_date,omitempty"`
 	// State is the new state for the milestone (e.g., 'open', 'closed').
 	State string `json:"state,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // EditMilestoneImpl implements the MCP tool for editing an existing milestone.
@@ -285,6 +320,7 @@ func (EditMilestoneImpl) Definition() *mcp.Tool {
 					Description: "New milestone state: 'open' or 'closed' (optional)",
 					Enum:        []any{"open", "closed"},
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "id"},
 		},
@@ -313,6 +349,12 @@ func (impl EditMilestoneImpl) Handler() mcp.ToolHandlerFor[EditMilestoneParams,
 			opt.Deadline = &p.DueDate
 		}
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, opt)
+			return res, nil, err
+		}
+
 		// Call SDK
 		milestone, _, err := impl.Client.EditMilestone(p.Owner, p.Repo, int64(p.ID), opt)
 		if err != nil {
@@ -332,6 +374,40 @@ func (impl EditMilestoneImpl) Handler() mcp.ToolHandlerFor[EditMilestoneParams,
 	}
 }
 
+// dryRun validates opt and previews the milestone before and after the edit.
+func (impl EditMilestoneImpl) dryRun(p EditMilestoneParams, opt forgejo.EditMilestoneOption) (*mcp.CallToolResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+	milestone, _, err := impl.Client.GetMilestone(p.Owner, p.Repo, int64(p.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get milestone: %w", err)
+	}
+
+	before := &types.Milestone{Milestone: milestone}
+	edited := *milestone
+	if opt.Title != "" {
+		edited.Title = opt.Title
+	}
+	if opt.Description != nil {
+		edited.Description = *opt.Description
+	}
+	if opt.State != nil {
+		edited.State = *opt.State
+	}
+	if opt.Deadline != nil {
+		edited.Deadline = opt.Deadline
+	}
+	after := &types.Milestone{Milestone: &edited}
+
+	preview := fmt.Sprintf("Would edit milestone in %s/%s.\n\n### Before\n\n%s\n\n### After\n\n%s", p.Owner, p.Repo, before.ToMarkdown(), after.ToMarkdown())
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "PATCH",
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/milestones/%d", p.Owner, p.Repo, p.ID),
+		Body:     opt,
+	})
+}
+
 // DeleteMilestoneParams defines the parameters for the delete_milestone tool.
 // It specifies the milestone to be deleted by its ID.
 type DeleteMilestoneParams struct {
@@ -341,20 +417,25 @@ type DeleteMilestoneParams struct {
 	Repo string `json:"repo"`
 	// ID is the unique identifier of the milestone to delete.
 	ID int `json:"id"`
+	// ConfirmToken confirms the operation, see tools.Confirmer.
+	ConfirmToken string `json:"confirm_token,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // DeleteMilestoneImpl implements the destructive MCP tool for deleting a milestone.
 // This is an idempotent but irreversible operation that removes a milestone from
 // a repository using the Forgejo SDK.
 type DeleteMilestoneImpl struct {
-	Client *tools.Client
+	Client  *tools.Client
+	Confirm *tools.Confirmer
 }
 
 // Definition describes the `delete_milestone` tool. It requires `owner`, `repo`,
 // and the milestone `id`. It is marked as a destructive operation to ensure
 // clients can warn the user before execution.
-func (DeleteMilestoneImpl) Definition() *mcp.Tool {
-	return &mcp.Tool{
+func (impl DeleteMilestoneImpl) Definition() *mcp.Tool {
+	def := &mcp.Tool{
 		Name:        "delete_milestone",
 		Title:       "Delete Milestone",
 		Description: "Delete a milestone from a repository.",
@@ -378,10 +459,15 @@ func (DeleteMilestoneImpl) Definition() *mcp.Tool {
 					Type:        "integer",
 					Description: "Milestone ID to delete",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "id"},
 		},
 	}
+	if impl.Confirm != nil {
+		def.InputSchema.Properties["confirm_token"] = tools.ConfirmTokenSchema()
+	}
+	return def
 }
 
 // Handler implements the logic for deleting a milestone. It calls the Forgejo SDK's
@@ -391,8 +477,23 @@ func (impl DeleteMilestoneImpl) Handler() mcp.ToolHandlerFor[DeleteMilestonePara
 	return func(ctx context.Context, req *mcp.CallToolRequest, args DeleteMilestoneParams) (*mcp.CallToolResult, any, error) {
 		p := args
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p)
+			return res, nil, err
+		}
+
+		// Ask for confirmation if required
+		action := fmt.Sprintf("delete_milestone:%s/%s:%d", p.Owner, p.Repo, p.ID)
+		res, err := impl.Confirm.Check(ctx, req, action, p.ConfirmToken, func() (string, error) {
+			return impl.preview(p)
+		})
+		if err != nil || res != nil {
+			return res, nil, err
+		}
+
 		// Call SDK
-		_, err := impl.Client.DeleteMilestone(p.Owner, p.Repo, int64(p.ID))
+		_, err = impl.Client.DeleteMilestone(p.Owner, p.Repo, int64(p.ID))
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to delete milestone: %w", err)
 		}
@@ -409,3 +510,27 @@ func (impl DeleteMilestoneImpl) Handler() mcp.ToolHandlerFor[DeleteMilestonePara
 		}, nil, nil
 	}
 }
+
+// dryRun previews the milestone to be deleted.
+func (impl DeleteMilestoneImpl) dryRun(p DeleteMilestoneParams) (*mcp.CallToolResult, error) {
+	preview, err := impl.preview(p)
+	if err != nil {
+		return nil, err
+	}
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "DELETE",
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/milestones/%d", p.Owner, p.Repo, p.ID),
+	})
+}
+
+// preview describes the milestone to be deleted and the issues assigned to it.
+func (impl DeleteMilestoneImpl) preview(p DeleteMilestoneParams) (string, error) {
+	milestone, _, err := impl.Client.GetMilestone(p.Owner, p.Repo, int64(p.ID))
+	if err != nil {
+		return "", fmt.Errorf("failed to get milestone: %w", err)
+	}
+
+	milestoneWrapper := &types.Milestone{Milestone: milestone}
+	return fmt.Sprintf("About to delete milestone from %s/%s:\n\n%s\n\n%d open and %d closed issues will be removed from this milestone.",
+		p.Owner, p.Repo, milestoneWrapper.ToMarkdown(), milestone.OpenIssues, milestone.ClosedIssues), nil
+}
@@ -0,0 +1,6 @@
+// Package notification provides MCP tools for working through the Forgejo
+// notification inbox of the authenticated user.
+//
+// It includes tools for listing notification threads, getting a specific
+// thread, and marking threads as read, unread or pinned.
+package notification
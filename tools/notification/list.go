@@ -0,0 +1,254 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// ListNotificationsParams defines the parameters for the list_notifications tool.
+// All filters are optional.
+type ListNotificationsParams struct {
+	// Owner is the owner of the repository to list notifications of.
+	Owner string `json:"owner,omitempty"`
+	// Repo is the name of the repository to list notifications of.
+	Repo string `json:"repo,omitempty"`
+	// Status filters notifications by 'unread', 'read', 'pinned' or 'all'.
+	Status string `json:"status,omitempty"`
+	// SubjectType filters notifications by 'Issue', 'Pull', 'Commit' or 'Repository'.
+	SubjectType string `json:"subject_type,omitempty"`
+	// Since is a timestamp in RFC 3339 format to show only notifications updated after this time.
+	Since *string `json:"since,omitempty"`
+	// Before is a timestamp in RFC 3339 format to show only notifications updated before this time.
+	Before *string `json:"before,omitempty"`
+	// Page is the page number for pagination.
+	Page int `json:"page,omitempty"`
+	// Limit is the number of notifications to return per page.
+	Limit int `json:"limit,omitempty"`
+}
+
+// ListNotificationsImpl implements the read-only MCP tool for listing the
+// notification threads of the authenticated user. This is a safe, idempotent
+// operation that uses the Forgejo SDK.
+type ListNotificationsImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `list_notifications` tool. All parameters are
+// optional. It is marked as a safe, read-only operation.
+func (ListNotificationsImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "list_notifications",
+		Title:       "List Notifications",
+		Description: "List notification threads of the authenticated user, optionally of a single repository. Filter by status, subject type and update time. Returns thread IDs, subject titles, states and links.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner, requires repo (optional)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Only list notifications of this repository, requires owner (optional)",
+				},
+				"status": {
+					Type:        "string",
+					Description: "Notification status filter (optional, defaults to unread and pinned)",
+					Enum:        []any{"unread", "read", "pinned", "all"},
+				},
+				"subject_type": {
+					Type:        "string",
+					Description: "Subject type filter (optional)",
+					Enum:        []any{"Issue", "Pull", "Commit", "Repository"},
+				},
+				"since": {
+					Type:        "string",
+					Description: "Only show notifications updated after this time (RFC 3339 format, optional)",
+					Format:      "date-time",
+				},
+				"before": {
+					Type:        "string",
+					Description: "Only show notifications updated before this time (RFC 3339 format, optional)",
+					Format:      "date-time",
+				},
+				"page": {
+					Type:        "integer",
+					Description: "Page number for pagination (optional, defaults to 1)",
+					Minimum:     tools.Float64Ptr(1),
+				},
+				"limit": {
+					Type:        "integer",
+					Description: "Number of notifications per page (optional, defaults to 20, max 50)",
+					Minimum:     tools.Float64Ptr(1),
+					Maximum:     tools.Float64Ptr(50),
+				},
+			},
+		},
+	}
+}
+
+// Handler implements the logic for listing notifications. It calls the Forgejo
+// SDK's `ListNotifications` or `ListRepoNotifications` function and formats the
+// results into a markdown list.
+func (impl ListNotificationsImpl) Handler() mcp.ToolHandlerFor[ListNotificationsParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args ListNotificationsParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		if (p.Owner == "") != (p.Repo == "") {
+			return nil, nil, errors.New("owner and repo must be given together")
+		}
+
+		page := max(p.Page, 1)
+		limit := p.Limit
+		if limit <= 0 {
+			limit = 20
+		}
+
+		// Build options for SDK call
+		opt := forgejo.ListNotificationOptions{
+			ListOptions: forgejo.ListOptions{Page: page, PageSize: limit},
+			Status:      statusFilter(p.Status),
+		}
+		if p.SubjectType != "" {
+			opt.SubjectTypes = []forgejo.NotifySubjectType{forgejo.NotifySubjectType(p.SubjectType)}
+		}
+
+		// Handle time-based filters
+		if p.Since != nil {
+			since, err := time.Parse(time.RFC3339, *p.Since)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid since timestamp format (expected RFC 3339): %w", err)
+			}
+			opt.Since = since
+		}
+		if p.Before != nil {
+			before, err := time.Parse(time.RFC3339, *p.Before)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid before timestamp format (expected RFC 3339): %w", err)
+			}
+			opt.Before = before
+		}
+
+		// Call SDK
+		var threads []*forgejo.NotificationThread
+		var err error
+		if p.Repo != "" {
+			threads, _, err = impl.Client.ListRepoNotifications(p.Owner, p.Repo, opt)
+		} else {
+			threads, _, err = impl.Client.ListNotifications(opt)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list notifications: %w", err)
+		}
+
+		// Convert to our types and format
+		list := types.NotificationList(threads)
+		content := fmt.Sprintf("Found %d notifications on page %d\n\n%s", len(threads), page, list.ToMarkdown())
+		if len(threads) >= limit {
+			content += fmt.Sprintf("\nMore notifications may be available, use page %d to see them.", page+1)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: content,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// statusFilter converts the status argument to the status types to filter
+// by. Empty status leaves the server default.
+func statusFilter(status string) []forgejo.NotifyStatus {
+	switch status {
+	case "":
+		return nil
+	case "all":
+		return []forgejo.NotifyStatus{forgejo.NotifyStatusUnread, forgejo.NotifyStatusRead, forgejo.NotifyStatusPinned}
+	default:
+		return []forgejo.NotifyStatus{forgejo.NotifyStatus(status)}
+	}
+}
+
+// GetNotificationThreadParams defines the parameters for the
+// get_notification_thread tool. It specifies the thread by ID.
+type GetNotificationThreadParams struct {
+	// ID is the ID of the notification thread.
+	ID int `json:"id"`
+}
+
+// GetNotificationThreadImpl implements the read-only MCP tool for getting a
+// notification thread. This is a safe, idempotent operation that uses the
+// Forgejo SDK. It does not mark the thread as read.
+type GetNotificationThreadImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `get_notification_thread` tool. It requires the
+// thread `id`. It is marked as a safe, read-only operation.
+func (GetNotificationThreadImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "get_notification_thread",
+		Title:       "Get Notification Thread",
+		Description: "Get a notification thread by ID, including its repository, subject title, state and links. Does not mark it as read.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"id": {
+					Type:        "integer",
+					Description: "Notification thread ID, see list_notifications",
+				},
+			},
+			Required: []string{"id"},
+		},
+	}
+}
+
+// Handler implements the logic for getting a notification thread. It calls the
+// Forgejo SDK's `GetNotification` function and formats the result into markdown.
+func (impl GetNotificationThreadImpl) Handler() mcp.ToolHandlerFor[GetNotificationThreadParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args GetNotificationThreadParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		// Call SDK
+		thread, _, err := impl.Client.GetNotification(int64(p.ID))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get notification thread: %w", err)
+		}
+
+		// Convert to our type and format
+		wrapper := &types.NotificationThread{NotificationThread: thread}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: wrapper.ToMarkdown(),
+				},
+			},
+		}, nil, nil
+	}
+}
@@ -0,0 +1,274 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+	"github.com/raohwork/forgejo-mcp/types"
+)
+
+// MarkNotificationThreadParams defines the parameters for the
+// mark_notification_thread tool. It specifies the thread and its new status.
+type MarkNotificationThreadParams struct {
+	// ID is the ID of the notification thread.
+	ID int `json:"id"`
+	// Status is the new status: 'read', 'unread' or 'pinned'.
+	Status string `json:"status,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// MarkNotificationThreadImpl implements the MCP tool for marking a notification
+// thread as read, unread or pinned. This is an idempotent operation that uses
+// the Forgejo SDK.
+type MarkNotificationThreadImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `mark_notification_thread` tool. It requires the
+// thread `id`. It is marked as idempotent.
+func (MarkNotificationThreadImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "mark_notification_thread",
+		Title:       "Mark Notification Thread",
+		Description: "Mark a notification thread as read, unread or pinned.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"id": {
+					Type:        "integer",
+					Description: "Notification thread ID, see list_notifications",
+				},
+				"status": {
+					Type:        "string",
+					Description: "New status (optional, defaults to 'read')",
+					Enum:        []any{"read", "unread", "pinned"},
+				},
+				"dry_run": tools.DryRunSchema(),
+			},
+			Required: []string{"id"},
+		},
+	}
+}
+
+// Handler implements the logic for marking a notification thread. It calls the
+// Forgejo SDK's `ReadNotification` function and formats the updated thread
+// into markdown.
+func (impl MarkNotificationThreadImpl) Handler() mcp.ToolHandlerFor[MarkNotificationThreadParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args MarkNotificationThreadParams) (*mcp.CallToolResult, any, error) {
+		p := args
+		status := forgejo.NotifyStatusRead
+		if p.Status != "" {
+			status = forgejo.NotifyStatus(p.Status)
+		}
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, status)
+			return res, nil, err
+		}
+
+		// Call SDK
+		thread, _, err := impl.Client.ReadNotification(int64(p.ID), status)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to mark notification thread: %w", err)
+		}
+
+		// Older servers do not return the thread
+		content := types.EmptyResponse{}.ToMarkdown()
+		if thread != nil && thread.ID != 0 {
+			content = (&types.NotificationThread{NotificationThread: thread}).ToMarkdown()
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: content,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// dryRun previews the thread to be marked.
+func (impl MarkNotificationThreadImpl) dryRun(p MarkNotificationThreadParams, status forgejo.NotifyStatus) (*mcp.CallToolResult, error) {
+	thread, _, err := impl.Client.GetNotification(int64(p.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification thread: %w", err)
+	}
+	preview := fmt.Sprintf("About to mark as %s: %s", status, (&types.NotificationThread{NotificationThread: thread}).ToMarkdown())
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "PATCH",
+		Endpoint: fmt.Sprintf("/api/v1/notifications/threads/%d?to-status=%s", p.ID, status),
+	})
+}
+
+// MarkNotificationsParams defines the parameters for the mark_notifications
+// tool. It selects the notifications to mark and their new status.
+type MarkNotificationsParams struct {
+	// Owner is the owner of the repository to mark notifications of.
+	Owner string `json:"owner,omitempty"`
+	// Repo is the name of the repository to mark notifications of.
+	Repo string `json:"repo,omitempty"`
+	// Status is the new status: 'read' or 'pinned'.
+	Status string `json:"status,omitempty"`
+	// LastReadAt is a timestamp in RFC 3339 format; only notifications updated
+	// before it are marked.
+	LastReadAt *string `json:"last_read_at,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// MarkNotificationsImpl implements the MCP tool for marking all unread
+// notifications, or those of a repository, as read or pinned. This is an
+// idempotent operation that uses the Forgejo SDK.
+type MarkNotificationsImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `mark_notifications` tool. All parameters are
+// optional. It is marked as idempotent.
+func (MarkNotificationsImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "mark_notifications",
+		Title:       "Mark All Notifications",
+		Description: "Mark all unread notifications of the authenticated user, or those of a single repository, as read or pinned. Use last_read_at to leave newer notifications untouched.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner, requires repo (optional)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Only mark notifications of this repository, requires owner (optional)",
+				},
+				"status": {
+					Type:        "string",
+					Description: "New status (optional, defaults to 'read')",
+					Enum:        []any{"read", "pinned"},
+				},
+				"last_read_at": {
+					Type:        "string",
+					Description: "Only mark notifications updated before this time (RFC 3339 format, optional, defaults to now)",
+					Format:      "date-time",
+				},
+				"dry_run": tools.DryRunSchema(),
+			},
+		},
+	}
+}
+
+// Handler implements the logic for marking notifications. It calls the Forgejo
+// SDK's `ReadNotifications` or `ReadRepoNotifications` function and formats the
+// marked threads into a markdown list.
+func (impl MarkNotificationsImpl) Handler() mcp.ToolHandlerFor[MarkNotificationsParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args MarkNotificationsParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		if (p.Owner == "") != (p.Repo == "") {
+			return nil, nil, errors.New("owner and repo must be given together")
+		}
+
+		// Build options for SDK call
+		opt := forgejo.MarkNotificationOptions{
+			ToStatus: forgejo.NotifyStatusRead,
+		}
+		if p.Status != "" {
+			opt.ToStatus = forgejo.NotifyStatus(p.Status)
+		}
+		if p.LastReadAt != nil {
+			lastReadAt, err := time.Parse(time.RFC3339, *p.LastReadAt)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid last_read_at timestamp format (expected RFC 3339): %w", err)
+			}
+			opt.LastReadAt = lastReadAt
+		}
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, opt)
+			return res, nil, err
+		}
+
+		// Call SDK
+		var threads []*forgejo.NotificationThread
+		var err error
+		if p.Repo != "" {
+			threads, _, err = impl.Client.ReadRepoNotifications(p.Owner, p.Repo, opt)
+		} else {
+			threads, _, err = impl.Client.ReadNotifications(opt)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to mark notifications: %w", err)
+		}
+
+		// Older servers do not return the threads
+		content := types.EmptyResponse{}.ToMarkdown()
+		if len(threads) > 0 {
+			content = fmt.Sprintf("Marked %d notifications as %s\n\n%s", len(threads), opt.ToStatus, types.NotificationList(threads).ToMarkdown())
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: content,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// dryRun previews the unread notifications to be marked.
+func (impl MarkNotificationsImpl) dryRun(p MarkNotificationsParams, opt forgejo.MarkNotificationOptions) (*mcp.CallToolResult, error) {
+	listOpt := forgejo.ListNotificationOptions{
+		ListOptions: forgejo.ListOptions{Page: 1, PageSize: 50},
+		Status:      []forgejo.NotifyStatus{forgejo.NotifyStatusUnread},
+		Before:      opt.LastReadAt,
+	}
+	endpoint := "/api/v1/notifications"
+	var threads []*forgejo.NotificationThread
+	var err error
+	if p.Repo != "" {
+		endpoint = fmt.Sprintf("/api/v1/repos/%s/%s/notifications", p.Owner, p.Repo)
+		threads, _, err = impl.Client.ListRepoNotifications(p.Owner, p.Repo, listOpt)
+	} else {
+		threads, _, err = impl.Client.ListNotifications(listOpt)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	preview := fmt.Sprintf("About to mark unread notifications as %s:\n\n%s", opt.ToStatus, types.NotificationList(threads).ToMarkdown())
+	if len(threads) >= listOpt.PageSize {
+		preview += "\nOnly the first page is shown, more notifications may be marked."
+	}
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "PUT",
+		Endpoint: endpoint + "?" + opt.QueryEncode(),
+	})
+}
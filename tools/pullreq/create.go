@@ -9,6 +9,7 @@ package pullreq
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
@@ -44,6 +45,8 @@ type CreatePullRequestParams struct {
 	Labels []int `json:"labels,omitempty"`
 	// DueDate is the optional deadline for the pull request.
 	DueDate time.Time `json:"due_date"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // CreatePullRequestImpl implements the MCP tool for creating a new pull request.
@@ -118,6 +121,7 @@ func (CreatePullRequestImpl) Definition() *mcp.Tool {
 					Description: "Pull request due date in ISO 8601 format (e.g., '2024-12-31T23:59:59Z') (optional)",
 					Format:      "date-time",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "head", "base", "title"},
 		},
@@ -154,6 +158,12 @@ func (impl CreatePullRequestImpl) Handler() mcp.ToolHandlerFor[CreatePullRequest
 			opt.Deadline = &p.DueDate
 		}
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, opt)
+			return res, nil, err
+		}
+
 		pr, _, err := impl.Client.CreatePullRequest(p.Owner, p.Repo, opt)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to create pull request: %w", err)
@@ -170,3 +180,62 @@ func (impl CreatePullRequestImpl) Handler() mcp.ToolHandlerFor[CreatePullRequest
 		}, nil, nil
 	}
 }
+
+// dryRun checks that the branches, labels, milestone and assignees exist, and
+// previews the pull request to be created.
+func (impl CreatePullRequestImpl) dryRun(p CreatePullRequestParams, opt forgejo.CreatePullRequestOption) (*mcp.CallToolResult, error) {
+	if strings.TrimSpace(opt.Title) == "" {
+		return nil, fmt.Errorf("title is empty")
+	}
+	if _, _, err := impl.Client.GetRepoBranch(p.Owner, p.Repo, opt.Base); err != nil {
+		return nil, fmt.Errorf("base branch %q not found: %w", opt.Base, err)
+	}
+	// head in another fork (owner:branch) is checked by Forgejo
+	if !strings.Contains(opt.Head, ":") {
+		if _, _, err := impl.Client.GetRepoBranch(p.Owner, p.Repo, opt.Head); err != nil {
+			return nil, fmt.Errorf("head branch %q not found: %w", opt.Head, err)
+		}
+	}
+
+	preview := fmt.Sprintf("Would create pull request in %s/%s:\n\n# %s\n- **Branches**: %s → %s\n", p.Owner, p.Repo, opt.Title, opt.Head, opt.Base)
+	assignees := opt.Assignees
+	if opt.Assignee != "" {
+		assignees = append([]string{opt.Assignee}, assignees...)
+	}
+	if len(assignees) > 0 {
+		if _, err := impl.Client.ResolveUsers(assignees); err != nil {
+			return nil, err
+		}
+		preview += "- **Assignees**: " + strings.Join(assignees, ", ") + "\n"
+	}
+	if len(opt.Labels) > 0 {
+		labels, err := impl.Client.ResolveLabels(p.Owner, p.Repo, opt.Labels)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, len(labels))
+		for i, l := range labels {
+			names[i] = l.Name
+		}
+		preview += "- **Labels**: " + strings.Join(names, ", ") + "\n"
+	}
+	if opt.Milestone > 0 {
+		m, _, err := impl.Client.GetMilestone(p.Owner, p.Repo, opt.Milestone)
+		if err != nil {
+			return nil, fmt.Errorf("milestone %d not found in %s/%s: %w", opt.Milestone, p.Owner, p.Repo, err)
+		}
+		preview += "- **Milestone**: " + m.Title + "\n"
+	}
+	if opt.Deadline != nil {
+		preview += "- **Due Date**: " + opt.Deadline.Format("2006-01-02") + "\n"
+	}
+	if opt.Body != "" {
+		preview += "\n" + opt.Body
+	}
+
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "POST",
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/pulls", p.Owner, p.Repo),
+		Body:     opt,
+	})
+}
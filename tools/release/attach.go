@@ -118,6 +118,8 @@ type EditReleaseAttachmentParams struct {
 	AttachmentID int `json:"attachment_id"`
 	// Name is the new display name for the attachment.
 	Name string `json:"name"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // EditReleaseAttachmentImpl implements the MCP tool for editing a release attachment.
@@ -162,6 +164,7 @@ func (EditReleaseAttachmentImpl) Definition() *mcp.Tool {
 					Type:        "string",
 					Description: "New display name for the attachment",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "release_id", "attachment_id", "name"},
 		},
@@ -180,6 +183,12 @@ func (impl EditReleaseAttachmentImpl) Handler() mcp.ToolHandlerFor[EditReleaseAt
 			Name: p.Name,
 		}
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, opt)
+			return res, nil, err
+		}
+
 		// Call SDK
 		attachment, _, err := impl.Client.EditReleaseAttachment(p.Owner, p.Repo, int64(p.ReleaseID), int64(p.AttachmentID), opt)
 		if err != nil {
@@ -199,6 +208,21 @@ func (impl EditReleaseAttachmentImpl) Handler() mcp.ToolHandlerFor[EditReleaseAt
 	}
 }
 
+// dryRun previews the renaming of the attachment.
+func (impl EditReleaseAttachmentImpl) dryRun(p EditReleaseAttachmentParams, opt forgejo.EditAttachmentOptions) (*mcp.CallToolResult, error) {
+	attachment, _, err := impl.Client.GetReleaseAttachment(p.Owner, p.Repo, int64(p.ReleaseID), int64(p.AttachmentID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release attachment: %w", err)
+	}
+
+	preview := fmt.Sprintf("Would rename attachment of release %d in %s/%s:\n\n- **Name**: %s → %s", p.ReleaseID, p.Owner, p.Repo, attachment.Name, opt.Name)
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "PATCH",
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/releases/%d/assets/%d", p.Owner, p.Repo, p.ReleaseID, p.AttachmentID),
+		Body:     opt,
+	})
+}
+
 // DeleteReleaseAttachmentParams defines the parameters for deleting a release attachment.
 // It specifies the attachment to be deleted by its ID.
 type DeleteReleaseAttachmentParams struct {
@@ -210,6 +234,8 @@ type DeleteReleaseAttachmentParams struct {
 	ReleaseID int `json:"release_id"`
 	// AttachmentID is the unique identifier of the attachment to delete.
 	AttachmentID int `json:"attachment_id"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // DeleteReleaseAttachmentImpl implements the destructive MCP tool for deleting a release attachment.
@@ -251,6 +277,7 @@ func (DeleteReleaseAttachmentImpl) Definition() *mcp.Tool {
 					Type:        "integer",
 					Description: "Attachment ID to delete",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "release_id", "attachment_id"},
 		},
@@ -264,6 +291,12 @@ func (impl DeleteReleaseAttachmentImpl) Handler() mcp.ToolHandlerFor[DeleteRelea
 	return func(ctx context.Context, req *mcp.CallToolRequest, args DeleteReleaseAttachmentParams) (*mcp.CallToolResult, any, error) {
 		p := args
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p)
+			return res, nil, err
+		}
+
 		// Call SDK
 		_, err := impl.Client.DeleteReleaseAttachment(p.Owner, p.Repo, int64(p.ReleaseID), int64(p.AttachmentID))
 		if err != nil {
@@ -282,3 +315,18 @@ func (impl DeleteReleaseAttachmentImpl) Handler() mcp.ToolHandlerFor[DeleteRelea
 		}, nil, nil
 	}
 }
+
+// dryRun previews the attachment to be deleted.
+func (impl DeleteReleaseAttachmentImpl) dryRun(p DeleteReleaseAttachmentParams) (*mcp.CallToolResult, error) {
+	attachment, _, err := impl.Client.GetReleaseAttachment(p.Owner, p.Repo, int64(p.ReleaseID), int64(p.AttachmentID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release attachment: %w", err)
+	}
+
+	attachmentWrapper := &types.Attachment{Attachment: attachment}
+	preview := fmt.Sprintf("Would delete attachment of release %d in %s/%s:\n\n%s", p.ReleaseID, p.Owner, p.Repo, attachmentWrapper.ToMarkdown())
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "DELETE",
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/releases/%d/assets/%d", p.Owner, p.Repo, p.ReleaseID, p.AttachmentID),
+	})
+}
@@ -142,6 +142,8 @@ type CreateReleaseParams struct {
 	Draft bool `json:"draft,omitempty"`
 	// Prerelease indicates whether the release is a pre-release.
 	Prerelease bool `json:"prerelease,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // CreateReleaseImpl implements the MCP tool for creating a new release.
@@ -199,6 +201,7 @@ func (CreateReleaseImpl) Definition() *mcp.Tool {
 					Type:        "boolean",
 					Description: "Whether this is a prerelease (optional, defaults to false)",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "tag_name", "name"},
 		},
@@ -221,6 +224,12 @@ func (impl CreateReleaseImpl) Handler() mcp.ToolHandlerFor[CreateReleaseParams,
 			IsPrerelease: p.Prerelease,
 		}
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, opt)
+			return res, nil, err
+		}
+
 		// Call SDK
 		release, _, err := impl.Client.CreateRelease(p.Owner, p.Repo, opt)
 		if err != nil {
@@ -240,6 +249,40 @@ func (impl CreateReleaseImpl) Handler() mcp.ToolHandlerFor[CreateReleaseParams,
 	}
 }
 
+// dryRun validates opt, checks the tag has no release yet, and previews the
+// release to be created.
+func (impl CreateReleaseImpl) dryRun(p CreateReleaseParams, opt forgejo.CreateReleaseOption) (*mcp.CallToolResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+	if r, _, err := impl.Client.GetReleaseByTag(p.Owner, p.Repo, opt.TagName); err == nil && r != nil {
+		return nil, fmt.Errorf("release for tag %q already exists in %s/%s (ID %d)", opt.TagName, p.Owner, p.Repo, r.ID)
+	}
+
+	tag := fmt.Sprintf("Tag `%s` exists and will be used.", opt.TagName)
+	if _, _, err := impl.Client.GetTag(p.Owner, p.Repo, opt.TagName); err != nil {
+		target := opt.Target
+		if target == "" {
+			target = "the default branch"
+		}
+		tag = fmt.Sprintf("Tag `%s` does not exist and will be created from %s.", opt.TagName, target)
+	}
+
+	releaseWrapper := &types.Release{Release: &forgejo.Release{
+		TagName:      opt.TagName,
+		Title:        opt.Title,
+		Note:         opt.Note,
+		IsDraft:      opt.IsDraft,
+		IsPrerelease: opt.IsPrerelease,
+	}}
+	preview := fmt.Sprintf("Would create release in %s/%s:\n\n%s\n\n%s", p.Owner, p.Repo, releaseWrapper.ToMarkdown(), tag)
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "POST",
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/releases", p.Owner, p.Repo),
+		Body:     opt,
+	})
+}
+
 // EditReleaseParams defines the parameters for the edit_release tool.
 // It specifies the release to edit by ID and the fields to update.
 type EditReleaseParams struct {
@@ -261,6 +304,8 @@ type EditReleaseParams struct {
 	Draft bool `json:"draft,omitempty"`
 	// Prerelease indicates whether the release should be marked as a pre-release.
 	Prerelease bool `json:"prerelease,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // EditReleaseImpl implements the MCP tool for editing an existing release.
@@ -322,6 +367,7 @@ func (EditReleaseImpl) Definition() *mcp.Tool {
 					Type:        "boolean",
 					Description: "Whether this is a prerelease (optional)",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "id"},
 		},
@@ -353,6 +399,12 @@ func (impl EditReleaseImpl) Handler() mcp.ToolHandlerFor[EditReleaseParams, any]
 		opt.IsDraft = &p.Draft
 		opt.IsPrerelease = &p.Prerelease
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, opt)
+			return res, nil, err
+		}
+
 		// Call SDK
 		release, _, err := impl.Client.EditRelease(p.Owner, p.Repo, int64(p.ID), opt)
 		if err != nil {
@@ -372,6 +424,40 @@ func (impl EditReleaseImpl) Handler() mcp.ToolHandlerFor[EditReleaseParams, any]
 	}
 }
 
+// dryRun previews the release before and after the edit.
+func (impl EditReleaseImpl) dryRun(p EditReleaseParams, opt forgejo.EditReleaseOption) (*mcp.CallToolResult, error) {
+	release, _, err := impl.Client.GetRelease(p.Owner, p.Repo, int64(p.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release: %w", err)
+	}
+
+	before := &types.Release{Release: release}
+	edited := *release
+	if opt.TagName != "" {
+		edited.TagName = opt.TagName
+	}
+	if opt.Title != "" {
+		edited.Title = opt.Title
+	}
+	if opt.Note != "" {
+		edited.Note = opt.Note
+	}
+	if opt.IsDraft != nil {
+		edited.IsDraft = *opt.IsDraft
+	}
+	if opt.IsPrerelease != nil {
+		edited.IsPrerelease = *opt.IsPrerelease
+	}
+	after := &types.Release{Release: &edited}
+
+	preview := fmt.Sprintf("Would edit release in %s/%s.\n\n### Before\n\n%s\n\n### After\n\n%s", p.Owner, p.Repo, before.ToMarkdown(), after.ToMarkdown())
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "PATCH",
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/releases/%d", p.Owner, p.Repo, p.ID),
+		Body:     opt,
+	})
+}
+
 // DeleteReleaseParams defines the parameters for the delete_release tool.
 // It specifies the release to be deleted by its ID.
 type DeleteReleaseParams struct {
@@ -381,20 +467,25 @@ type DeleteReleaseParams struct {
 	Repo string `json:"repo"`
 	// ID is the unique identifier of the release to delete.
 	ID int `json:"id"`
+	// ConfirmToken confirms the operation, see tools.Confirmer.
+	ConfirmToken string `json:"confirm_token,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // DeleteReleaseImpl implements the destructive MCP tool for deleting a release.
 // This is an idempotent but irreversible operation that removes both the release
 // object and its associated git tag. It uses the Forgejo SDK.
 type DeleteReleaseImpl struct {
-	Client *tools.Client
+	Client  *tools.Client
+	Confirm *tools.Confirmer
 }
 
 // Definition describes the `delete_release` tool. It requires `owner`, `repo`,
 // and the release `id`. It is marked as a destructive operation to ensure
 // clients can warn the user before execution.
-func (DeleteReleaseImpl) Definition() *mcp.Tool {
-	return &mcp.Tool{
+func (impl DeleteReleaseImpl) Definition() *mcp.Tool {
+	def := &mcp.Tool{
 		Name:        "delete_release",
 		Title:       "Delete Release",
 		Description: "Delete a release from a repository.",
@@ -418,10 +509,15 @@ func (DeleteReleaseImpl) Definition() *mcp.Tool {
 					Type:        "integer",
 					Description: "Release ID to delete",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "id"},
 		},
 	}
+	if impl.Confirm != nil {
+		def.InputSchema.Properties["confirm_token"] = tools.ConfirmTokenSchema()
+	}
+	return def
 }
 
 // Handler implements the logic for deleting a release. It calls the Forgejo SDK's
@@ -431,8 +527,23 @@ func (impl DeleteReleaseImpl) Handler() mcp.ToolHandlerFor[DeleteReleaseParams,
 	return func(ctx context.Context, req *mcp.CallToolRequest, args DeleteReleaseParams) (*mcp.CallToolResult, any, error) {
 		p := args
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p)
+			return res, nil, err
+		}
+
+		// Ask for confirmation if required
+		action := fmt.Sprintf("delete_release:%s/%s:%d", p.Owner, p.Repo, p.ID)
+		res, err := impl.Confirm.Check(ctx, req, action, p.ConfirmToken, func() (string, error) {
+			return impl.preview(p)
+		})
+		if err != nil || res != nil {
+			return res, nil, err
+		}
+
 		// Call SDK
-		_, err := impl.Client.DeleteRelease(p.Owner, p.Repo, int64(p.ID))
+		_, err = impl.Client.DeleteRelease(p.Owner, p.Repo, int64(p.ID))
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to delete release: %w", err)
 		}
@@ -449,3 +560,27 @@ func (impl DeleteReleaseImpl) Handler() mcp.ToolHandlerFor[DeleteReleaseParams,
 		}, nil, nil
 	}
 }
+
+// dryRun previews the release to be deleted.
+func (impl DeleteReleaseImpl) dryRun(p DeleteReleaseParams) (*mcp.CallToolResult, error) {
+	preview, err := impl.preview(p)
+	if err != nil {
+		return nil, err
+	}
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "DELETE",
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/releases/%d", p.Owner, p.Repo, p.ID),
+	})
+}
+
+// preview describes the release to be deleted and its attachments.
+func (impl DeleteReleaseImpl) preview(p DeleteReleaseParams) (string, error) {
+	release, _, err := impl.Client.GetRelease(p.Owner, p.Repo, int64(p.ID))
+	if err != nil {
+		return "", fmt.Errorf("failed to get release: %w", err)
+	}
+
+	releaseWrapper := &types.Release{Release: release}
+	return fmt.Sprintf("About to delete release from %s/%s:\n\n%s\n\n%d attached files will be deleted. The git tag `%s` is kept.",
+		p.Owner, p.Repo, releaseWrapper.ToMarkdown(), len(release.Attachments), release.TagName), nil
+}
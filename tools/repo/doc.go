@@ -1,5 +1,6 @@
 // Package repo provides MCP tools for interacting with Forgejo repositories.
 //
 // It includes tools for searching repositories, listing repositories owned by the
-// authenticated user or an organization, and getting detailed information about a specific repository.
+// authenticated user or an organization, getting detailed information about a specific repository,
+// and watching repositories.
 package repo
@@ -0,0 +1,246 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/raohwork/forgejo-mcp/tools"
+)
+
+// CheckRepoWatchParams defines the parameters for the check_repo_watch tool.
+// It specifies the repository.
+type CheckRepoWatchParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+}
+
+// CheckRepoWatchImpl implements the read-only MCP tool for checking whether
+// the authenticated user is watching a repository. This is a safe, idempotent
+// operation that uses the Forgejo SDK.
+type CheckRepoWatchImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `check_repo_watch` tool. It requires `owner` and
+// `repo`. It is marked as a safe, read-only operation.
+func (CheckRepoWatchImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "check_repo_watch",
+		Title:       "Check Repository Watch",
+		Description: "Check whether you are watching a repository, i.e. notified about all its issues and pull requests.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization name)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+			},
+			Required: []string{"owner", "repo"},
+		},
+	}
+}
+
+// Handler implements the logic for checking the watch status. It calls the
+// Forgejo SDK's `CheckRepoWatch` function.
+func (impl CheckRepoWatchImpl) Handler() mcp.ToolHandlerFor[CheckRepoWatchParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args CheckRepoWatchParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		// Call SDK
+		watching, _, err := impl.Client.CheckRepoWatch(p.Owner, p.Repo)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to check repository watch: %w", err)
+		}
+
+		text := fmt.Sprintf("You are not watching %s/%s.", p.Owner, p.Repo)
+		if watching {
+			text = fmt.Sprintf("You are watching %s/%s.", p.Owner, p.Repo)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: text,
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// RepoWatchParams defines the parameters for the watch_repo and unwatch_repo
+// tools. It specifies the repository.
+type RepoWatchParams struct {
+	// Owner is the username or organization name that owns the repository.
+	Owner string `json:"owner"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// watchSchema returns the input schema shared by the watch_repo and
+// unwatch_repo tools.
+func watchSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"owner": {
+				Type:        "string",
+				Description: "Repository owner (username or organization name)",
+			},
+			"repo": {
+				Type:        "string",
+				Description: "Repository name",
+			},
+			"dry_run": tools.DryRunSchema(),
+		},
+		Required: []string{"owner", "repo"},
+	}
+}
+
+// watchDryRun previews watching (watch is true) or unwatching a repository.
+func watchDryRun(cl *tools.Client, p RepoWatchParams, watch bool) (*mcp.CallToolResult, error) {
+	watching, _, err := cl.CheckRepoWatch(p.Owner, p.Repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check repository watch: %w", err)
+	}
+
+	ref := p.Owner + "/" + p.Repo
+	method := "PUT"
+	var preview string
+	switch {
+	case watch && watching:
+		preview = fmt.Sprintf("You are already watching %s, nothing would change", ref)
+	case watch:
+		preview = "Would watch " + ref
+	case watching:
+		method = "DELETE"
+		preview = "Would unwatch " + ref
+	default:
+		method = "DELETE"
+		preview = fmt.Sprintf("You are not watching %s, nothing would change", ref)
+	}
+
+	return cl.DryRunResult(preview, tools.APIRequest{
+		Method:   method,
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/subscription", p.Owner, p.Repo),
+	})
+}
+
+// WatchRepoImpl implements the MCP tool for watching a repository. This is an
+// idempotent operation that uses the Forgejo SDK.
+type WatchRepoImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `watch_repo` tool. It requires `owner` and `repo`.
+// It is marked as idempotent.
+func (WatchRepoImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "watch_repo",
+		Title:       "Watch Repository",
+		Description: "Watch a repository to be notified about all its issues and pull requests.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  true,
+		},
+		InputSchema: watchSchema(),
+	}
+}
+
+// Handler implements the logic for watching a repository. It calls the
+// Forgejo SDK's `WatchRepo` function.
+func (impl WatchRepoImpl) Handler() mcp.ToolHandlerFor[RepoWatchParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args RepoWatchParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := watchDryRun(impl.Client, p, true)
+			return res, nil, err
+		}
+
+		_, err := impl.Client.WatchRepo(p.Owner, p.Repo)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to watch repository: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("You are watching %s/%s.", p.Owner, p.Repo),
+				},
+			},
+		}, nil, nil
+	}
+}
+
+// UnwatchRepoImpl implements the MCP tool for unwatching a repository. This
+// is an idempotent operation that uses the Forgejo SDK.
+type UnwatchRepoImpl struct {
+	Client *tools.Client
+}
+
+// Definition describes the `unwatch_repo` tool. It requires `owner` and
+// `repo`. It is marked as idempotent.
+func (UnwatchRepoImpl) Definition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "unwatch_repo",
+		Title:       "Unwatch Repository",
+		Description: "Stop watching a repository. You are still notified about issues and pull requests you are subscribed to.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: tools.BoolPtr(false),
+			IdempotentHint:  true,
+		},
+		InputSchema: watchSchema(),
+	}
+}
+
+// Handler implements the logic for unwatching a repository. It calls the
+// Forgejo SDK's `UnWatchRepo` function.
+func (impl UnwatchRepoImpl) Handler() mcp.ToolHandlerFor[RepoWatchParams, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args RepoWatchParams) (*mcp.CallToolResult, any, error) {
+		p := args
+
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := watchDryRun(impl.Client, p, false)
+			return res, nil, err
+		}
+
+		_, err := impl.Client.UnWatchRepo(p.Owner, p.Repo)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to unwatch repository: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("You are not watching %s/%s.", p.Owner, p.Repo),
+				},
+			},
+		}, nil, nil
+	}
+}
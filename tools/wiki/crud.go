@@ -10,6 +10,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"strings"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -111,6 +112,8 @@ type CreateWikiPageParams struct {
 	Content string `json:"content"`
 	// Message is an optional commit message for the creation.
 	Message string `json:"message,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // CreateWikiPageImpl implements the MCP tool for creating a new wiki page.
@@ -157,6 +160,7 @@ func (CreateWikiPageImpl) Definition() *mcp.Tool {
 					Type:        "string",
 					Description: "Optional commit message (defaults to 'Create page {title}')",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "title", "content"},
 		},
@@ -177,6 +181,12 @@ func (impl CreateWikiPageImpl) Handler() mcp.ToolHandlerFor[CreateWikiPageParams
 			Message:       p.Message,
 		}
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, options)
+			return res, nil, err
+		}
+
 		// Call custom client method
 		page, err := impl.Client.MyCreateWikiPage(p.Owner, p.Repo, options)
 		if err != nil {
@@ -198,6 +208,28 @@ func (impl CreateWikiPageImpl) Handler() mcp.ToolHandlerFor[CreateWikiPageParams
 	}
 }
 
+// dryRun checks the page does not exist yet and previews the page to be
+// created.
+func (impl CreateWikiPageImpl) dryRun(p CreateWikiPageParams, options types.MyCreateWikiPageOptions) (*mcp.CallToolResult, error) {
+	if strings.TrimSpace(p.Title) == "" {
+		return nil, fmt.Errorf("empty title not allowed")
+	}
+	if page, err := impl.Client.MyGetWikiPage(p.Owner, p.Repo, p.Title); err == nil && page != nil {
+		return nil, fmt.Errorf("wiki page %q already exists in %s/%s", page.Title, p.Owner, p.Repo)
+	}
+
+	wikiPage := &types.WikiPage{MyWikiPage: &types.MyWikiPage{
+		Title:         options.Title,
+		ContentBase64: options.ContentBase64,
+	}}
+	preview := fmt.Sprintf("Would create wiki page in %s/%s:\n\n%s", p.Owner, p.Repo, wikiPage.ToMarkdown())
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "POST",
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/wiki/new", p.Owner, p.Repo),
+		Body:     options,
+	})
+}
+
 // EditWikiPageParams defines the parameters for the edit_wiki_page tool.
 // It specifies the page to edit and the new content.
 type EditWikiPageParams struct {
@@ -213,6 +245,8 @@ type EditWikiPageParams struct {
 	Content string `json:"content"`
 	// Message is an optional commit message for the update.
 	Message string `json:"message,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // EditWikiPageImpl implements the MCP tool for editing an existing wiki page.
@@ -261,6 +295,7 @@ func (EditWikiPageImpl) Definition() *mcp.Tool {
 					Type:        "string",
 					Description: "Optional commit message (defaults to 'Update page {page_name}')",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "page_name", "content"},
 		},
@@ -285,6 +320,12 @@ func (impl EditWikiPageImpl) Handler() mcp.ToolHandlerFor[EditWikiPageParams, an
 			Message:       p.Message,
 		}
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p, options)
+			return res, nil, err
+		}
+
 		// Call custom client method
 		page, err := impl.Client.MyEditWikiPage(p.Owner, p.Repo, p.PageName, options)
 		if err != nil {
@@ -306,6 +347,38 @@ func (impl EditWikiPageImpl) Handler() mcp.ToolHandlerFor[EditWikiPageParams, an
 	}
 }
 
+// dryRun previews the changes to the wiki page.
+func (impl EditWikiPageImpl) dryRun(p EditWikiPageParams, options types.MyCreateWikiPageOptions) (*mcp.CallToolResult, error) {
+	page, err := impl.Client.MyGetWikiPage(p.Owner, p.Repo, p.PageName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wiki page: %w", err)
+	}
+	current, err := base64.StdEncoding.DecodeString(page.ContentBase64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode wiki page: %w", err)
+	}
+
+	preview := fmt.Sprintf("Would edit wiki page **%s** in %s/%s:\n\n", page.Title, p.Owner, p.Repo)
+	if options.Title != page.Title {
+		preview += fmt.Sprintf("- **Title**: %s → %s\n", page.Title, options.Title)
+	}
+	preview += fmt.Sprintf("- **Content**: %d lines → %d lines\n\n### New Content\n\n%s",
+		countLines(string(current)), countLines(p.Content), p.Content)
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "PATCH",
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/wiki/page/%s", p.Owner, p.Repo, p.PageName),
+		Body:     options,
+	})
+}
+
+// countLines counts the lines of s.
+func countLines(s string) int {
+	if s == "" {
+		return 0
+	}
+	return strings.Count(strings.TrimSuffix(s, "\n"), "\n") + 1
+}
+
 // DeleteWikiPageParams defines the parameters for the delete_wiki_page tool.
 // It specifies the page to be deleted.
 type DeleteWikiPageParams struct {
@@ -315,20 +388,25 @@ type DeleteWikiPageParams struct {
 	Repo string `json:"repo"`
 	// PageName is the title of the wiki page to delete.
 	PageName string `json:"page_name"`
+	// ConfirmToken confirms the operation, see tools.Confirmer.
+	ConfirmToken string `json:"confirm_token,omitempty"`
+	// DryRun previews the change without sending it, see tools.Client.IsDryRun.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // DeleteWikiPageImpl implements the destructive MCP tool for deleting a wiki page.
 // This is an idempotent but irreversible operation. Note: This feature is not
 // supported by the official Forgejo SDK and requires a custom HTTP implementation.
 type DeleteWikiPageImpl struct {
-	Client *tools.Client
+	Client  *tools.Client
+	Confirm *tools.Confirmer
 }
 
 // Definition describes the `delete_wiki_page` tool. It requires `owner`, `repo`,
 // and `page_name`. It is marked as a destructive operation to ensure clients
 // can warn the user before execution.
-func (DeleteWikiPageImpl) Definition() *mcp.Tool {
-	return &mcp.Tool{
+func (impl DeleteWikiPageImpl) Definition() *mcp.Tool {
+	def := &mcp.Tool{
 		Name:        "delete_wiki_page",
 		Title:       "Delete Wiki Page",
 		Description: "Delete a wiki page from the repository.",
@@ -352,10 +430,15 @@ func (DeleteWikiPageImpl) Definition() *mcp.Tool {
 					Type:        "string",
 					Description: "Wiki page name to delete",
 				},
+				"dry_run": tools.DryRunSchema(),
 			},
 			Required: []string{"owner", "repo", "page_name"},
 		},
 	}
+	if impl.Confirm != nil {
+		def.InputSchema.Properties["confirm_token"] = tools.ConfirmTokenSchema()
+	}
+	return def
 }
 
 // Handler implements the logic for deleting a wiki page. It performs a custom
@@ -365,8 +448,23 @@ func (impl DeleteWikiPageImpl) Handler() mcp.ToolHandlerFor[DeleteWikiPageParams
 	return func(ctx context.Context, req *mcp.CallToolRequest, args DeleteWikiPageParams) (*mcp.CallToolResult, any, error) {
 		p := args
 
+		// Preview only in dry-run mode
+		if impl.Client.IsDryRun(p.DryRun) {
+			res, err := impl.dryRun(p)
+			return res, nil, err
+		}
+
+		// Ask for confirmation if required
+		action := fmt.Sprintf("delete_wiki_page:%s/%s:%s", p.Owner, p.Repo, p.PageName)
+		res, err := impl.Confirm.Check(ctx, req, action, p.ConfirmToken, func() (string, error) {
+			return impl.preview(p)
+		})
+		if err != nil || res != nil {
+			return res, nil, err
+		}
+
 		// Call custom client method
-		err := impl.Client.MyDeleteWikiPage(p.Owner, p.Repo, p.PageName)
+		err = impl.Client.MyDeleteWikiPage(p.Owner, p.Repo, p.PageName)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to delete wiki page: %w", err)
 		}
@@ -383,3 +481,26 @@ func (impl DeleteWikiPageImpl) Handler() mcp.ToolHandlerFor[DeleteWikiPageParams
 		}, nil, nil
 	}
 }
+
+// dryRun previews the wiki page to be deleted.
+func (impl DeleteWikiPageImpl) dryRun(p DeleteWikiPageParams) (*mcp.CallToolResult, error) {
+	preview, err := impl.preview(p)
+	if err != nil {
+		return nil, err
+	}
+	return impl.Client.DryRunResult(preview, tools.APIRequest{
+		Method:   "DELETE",
+		Endpoint: fmt.Sprintf("/api/v1/repos/%s/%s/wiki/page/%s", p.Owner, p.Repo, p.PageName),
+	})
+}
+
+// preview describes the wiki page to be deleted.
+func (impl DeleteWikiPageImpl) preview(p DeleteWikiPageParams) (string, error) {
+	page, err := impl.Client.MyGetWikiPage(p.Owner, p.Repo, p.PageName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get wiki page: %w", err)
+	}
+
+	return fmt.Sprintf("About to delete wiki page **%s** from %s/%s, which has %d revisions.",
+		page.Title, p.Owner, p.Repo, page.CommitCount), nil
+}
@@ -121,3 +121,143 @@ func TestActionTaskList_ToMarkdown(t *testing.T) {
 		})
 	}
 }
+
+func TestMyActionRun_ToMarkdown(t *testing.T) {
+	started := testTime()
+
+	tests := []struct {
+		name     string
+		run      *MyActionRun
+		required []string
+	}{
+		{
+			name: "completed run",
+			run: &MyActionRun{
+				ID:           345,
+				DisplayTitle: "Fix login",
+				Path:         "ci.yml",
+				Event:        "push",
+				RunNumber:    12,
+				RunAttempt:   2,
+				HeadBranch:   "main",
+				HeadSHA:      "1a2b3c4d5e6f7a8b",
+				Status:       "completed",
+				Conclusion:   "failure",
+				StartedAt:    started,
+				CompletedAt:  started.Add(2 * time.Minute),
+			},
+			required: []string{"Fix login", "`failure`", "Run #12 (ID: 345)", "attempt 2", "push on main", "@ 1a2b3c4d", "ci.yml", "Started: 2024-01-15 14:30", "Duration: 2m0s"},
+		},
+		{
+			name: "queued run",
+			run: &MyActionRun{
+				ID:           346,
+				DisplayTitle: "Add docs",
+				RunNumber:    13,
+				Status:       "queued",
+			},
+			required: []string{"Add docs", "`queued`", "Run #13 (ID: 346)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := tt.run.ToMarkdown()
+			assertContains(t, output, tt.required)
+		})
+	}
+}
+
+func TestActionRunList_ToMarkdown(t *testing.T) {
+	list := ActionRunList{
+		MyActionRunResponse: &MyActionRunResponse{
+			TotalCount: 2,
+			WorkflowRuns: []*MyActionRun{
+				{ID: 1, DisplayTitle: "First", RunNumber: 1, Status: "completed", Conclusion: "success"},
+				{ID: 2, DisplayTitle: "Second", RunNumber: 2, Status: "in_progress"},
+			},
+		},
+	}
+	assertContains(t, list.ToMarkdown(), []string{"1. **First** `success`", "2. **Second** `in_progress`"})
+	assertContains(t, ActionRunList{}.ToMarkdown(), []string{"No workflow runs found"})
+}
+
+func TestActionJobList_ToMarkdown(t *testing.T) {
+	started := testTime()
+	list := ActionJobList{
+		MyActionJobResponse: &MyActionJobResponse{
+			TotalCount: 1,
+			Jobs: []*MyActionJob{
+				{
+					ID:          789,
+					Name:        "test",
+					Status:      "completed",
+					Conclusion:  "failure",
+					RunnerName:  "runner-1",
+					Labels:      []string{"docker"},
+					StartedAt:   started,
+					CompletedAt: started.Add(90 * time.Second),
+					Steps: []*MyActionJobStep{
+						{Number: 1, Name: "Set up job", Status: "completed", Conclusion: "success"},
+						{Number: 2, Name: "Run tests", Status: "completed", Conclusion: "failure"},
+					},
+				},
+			},
+		},
+	}
+	assertContains(t, list.ToMarkdown(), []string{
+		"### test `failure` (Job ID: 789)",
+		"Runner: runner-1", "Labels: docker", "Duration: 1m30s",
+		"1. Set up job `success`", "2. Run tests `failure`",
+	})
+	assertContains(t, ActionJobList{}.ToMarkdown(), []string{"No jobs found"})
+}
+
+func TestSecretList_ToMarkdown(t *testing.T) {
+	list := SecretList{{Name: "DEPLOY_KEY", CreatedAt: testTime()}, {Name: "TOKEN"}}
+	assertContains(t, list.ToMarkdown(), []string{"- `DEPLOY_KEY` | Created: 2024-01-15 14:30", "- `TOKEN`"})
+	assertContains(t, SecretList{}.ToMarkdown(), []string{"No secrets found"})
+}
+
+func TestActionVariableList_ToMarkdown(t *testing.T) {
+	list := ActionVariableList{
+		{Name: "NODE_VERSION", Data: "20"},
+		{Name: "HOSTS", Data: "a\nb", Description: "deploy targets"},
+	}
+	assertContains(t, list.ToMarkdown(), []string{"- `NODE_VERSION`: `20`", "`HOSTS`: \n```\na\nb\n```", "deploy targets"})
+	assertContains(t, ActionVariableList{}.ToMarkdown(), []string{"No variables found"})
+}
+
+func TestActionArtifactList_ToMarkdown(t *testing.T) {
+	list := ActionArtifactList{
+		MyActionArtifactResponse: &MyActionArtifactResponse{
+			TotalCount: 2,
+			Artifacts: []*MyActionArtifact{
+				{ID: 12, Name: "coverage", SizeInBytes: 1536, CreatedAt: testTime(), ExpiresAt: testTime().AddDate(0, 0, 90)},
+				{ID: 13, Name: "old-report", SizeInBytes: 10, Expired: true},
+			},
+		},
+	}
+	assertContains(t, list.ToMarkdown(), []string{
+		"**coverage** (ID: 12) | 1536 bytes", "Created: 2024-01-15 14:30", "Expires: 2024-04-14 14:30",
+		"**old-report** (ID: 13)", "`expired`",
+	})
+	assertContains(t, ActionArtifactList{}.ToMarkdown(), []string{"No artifacts found"})
+}
+
+func TestActionRunnerList_ToMarkdown(t *testing.T) {
+	list := ActionRunnerList{
+		MyActionRunnerResponse: &MyActionRunnerResponse{
+			TotalCount: 2,
+			Runners: []*MyActionRunner{
+				{ID: 3, Name: "runner-1", Status: "online", Busy: true, Labels: []*MyActionRunnerLabel{{Name: "docker"}, {Name: "ubuntu-latest"}}},
+				{ID: 4, Name: "runner-2", Status: "offline", Disabled: true},
+			},
+		},
+	}
+	assertContains(t, list.ToMarkdown(), []string{
+		"- **runner-1** `online` busy (ID: 3) | Labels: docker, ubuntu-latest",
+		"- **runner-2** `offline` disabled (ID: 4)",
+	})
+	assertContains(t, ActionRunnerList{}.ToMarkdown(), []string{"No runners found"})
+}
@@ -8,7 +8,10 @@ package types
 
 import (
 	"fmt"
+	"strings"
 	"time"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
 )
 
 // ActionTaskList represents a list of action tasks response
@@ -81,3 +84,437 @@ type MyActionTaskResponse struct {
 	TotalCount   int64           `json:"total_count"`
 	WorkflowRuns []*MyActionTask `json:"workflow_runs"`
 }
+
+// MyActionRun represents a workflow run.
+type MyActionRun struct {
+	ID           int64         `json:"id"`
+	DisplayTitle string        `json:"display_title"`
+	Path         string        `json:"path"` // workflow file
+	Event        string        `json:"event"`
+	RunNumber    int64         `json:"run_number"`
+	RunAttempt   int64         `json:"run_attempt"`
+	HeadBranch   string        `json:"head_branch"`
+	HeadSHA      string        `json:"head_sha"`
+	Status       string        `json:"status"`     // queued, in_progress, completed, ...
+	Conclusion   string        `json:"conclusion"` // success, failure, cancelled, ... when completed
+	HTMLURL      string        `json:"html_url"`
+	Actor        *forgejo.User `json:"actor"`
+	StartedAt    time.Time     `json:"started_at"`
+	CompletedAt  time.Time     `json:"completed_at"`
+}
+
+// State returns the conclusion of a completed run, or its status otherwise.
+func (r *MyActionRun) State() string {
+	if r.Conclusion != "" {
+		return r.Conclusion
+	}
+	return r.Status
+}
+
+// Duration returns how long the run took, or 0 if it is not completed.
+func (r *MyActionRun) Duration() time.Duration {
+	if r.StartedAt.IsZero() || r.CompletedAt.IsZero() {
+		return 0
+	}
+	return r.CompletedAt.Sub(r.StartedAt)
+}
+
+// ToMarkdown renders workflow run with title, state, trigger and timing
+// Example: **Fix login** `failure` - Run #12 (ID: 345) | push on main @ 1a2b3c4d | ci.yml | Started: 2024-01-15 14:30 | Duration: 2m15s
+func (r *MyActionRun) ToMarkdown() string {
+	markdown := fmt.Sprintf("**%s** `%s` - Run #%d (ID: %d)", r.DisplayTitle, r.State(), r.RunNumber, r.ID)
+	if r.RunAttempt > 1 {
+		markdown += fmt.Sprintf(" attempt %d", r.RunAttempt)
+	}
+	if r.Event != "" {
+		markdown += " | " + r.Event
+		if r.HeadBranch != "" {
+			markdown += " on " + r.HeadBranch
+		}
+	}
+	if r.HeadSHA != "" {
+		markdown += " @ " + shortSHA(r.HeadSHA)
+	}
+	if r.Path != "" {
+		markdown += " | " + r.Path
+	}
+	if !r.StartedAt.IsZero() {
+		markdown += " | Started: " + r.StartedAt.Format("2006-01-02 15:04")
+	}
+	if d := r.Duration(); d > 0 {
+		markdown += " | Duration: " + d.String()
+	}
+	return markdown
+}
+
+// MyActionRunResponse represents the response for listing workflow runs.
+type MyActionRunResponse struct {
+	TotalCount   int64          `json:"total_count"`
+	WorkflowRuns []*MyActionRun `json:"workflow_runs"`
+}
+
+// ActionRunList represents a list of workflow runs response
+// Used by endpoints:
+// - GET /repos/{owner}/{repo}/actions/runs
+type ActionRunList struct {
+	*MyActionRunResponse
+}
+
+// ToMarkdown renders workflow runs as a numbered list
+// Example:
+// 1. **Fix login** `failure` - Run #12 (ID: 345) | push on main @ 1a2b3c4d
+// 2. **Add docs** `success` - Run #11 (ID: 344) | pull_request on docs @ 5e6f7a8b
+func (rl ActionRunList) ToMarkdown() string {
+	if rl.MyActionRunResponse == nil || len(rl.WorkflowRuns) == 0 {
+		return "*No workflow runs found*"
+	}
+	markdown := ""
+	for i, run := range rl.WorkflowRuns {
+		markdown += fmt.Sprintf("%d. %s\n", i+1, run.ToMarkdown())
+	}
+	return markdown
+}
+
+// MyActionJobStep represents a step of a workflow job.
+type MyActionJobStep struct {
+	Name        string    `json:"name"`
+	Number      int64     `json:"number"`
+	Status      string    `json:"status"`
+	Conclusion  string    `json:"conclusion"`
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// State returns the conclusion of a completed step, or its status otherwise.
+func (s *MyActionJobStep) State() string {
+	if s.Conclusion != "" {
+		return s.Conclusion
+	}
+	return s.Status
+}
+
+// MyActionJob represents a job of a workflow run.
+type MyActionJob struct {
+	ID          int64              `json:"id"`
+	RunID       int64              `json:"run_id"`
+	Name        string             `json:"name"`
+	Status      string             `json:"status"`
+	Conclusion  string             `json:"conclusion"`
+	HeadBranch  string             `json:"head_branch"`
+	HeadSHA     string             `json:"head_sha"`
+	Labels      []string           `json:"labels"`
+	RunnerName  string             `json:"runner_name"`
+	HTMLURL     string             `json:"html_url"`
+	Steps       []*MyActionJobStep `json:"steps"`
+	StartedAt   time.Time          `json:"started_at"`
+	CompletedAt time.Time          `json:"completed_at"`
+}
+
+// State returns the conclusion of a completed job, or its status otherwise.
+func (j *MyActionJob) State() string {
+	if j.Conclusion != "" {
+		return j.Conclusion
+	}
+	return j.Status
+}
+
+// Duration returns how long the job took, or 0 if it is not completed.
+func (j *MyActionJob) Duration() time.Duration {
+	if j.StartedAt.IsZero() || j.CompletedAt.IsZero() {
+		return 0
+	}
+	return j.CompletedAt.Sub(j.StartedAt)
+}
+
+// ToMarkdown renders job with state, runner and steps
+// Example: ### test `failure` (Job ID: 789)
+// Runner: runner-1 | Duration: 1m30s
+//
+//  1. Set up job `success`
+//  2. Run tests `failure`
+func (j *MyActionJob) ToMarkdown() string {
+	markdown := fmt.Sprintf("### %s `%s` (Job ID: %d)\n", j.Name, j.State(), j.ID)
+	info := []string{}
+	if j.RunnerName != "" {
+		info = append(info, "Runner: "+j.RunnerName)
+	}
+	if len(j.Labels) > 0 {
+		info = append(info, "Labels: "+strings.Join(j.Labels, ", "))
+	}
+	if d := j.Duration(); d > 0 {
+		info = append(info, "Duration: "+d.String())
+	}
+	if len(info) > 0 {
+		markdown += strings.Join(info, " | ") + "\n"
+	}
+	if len(j.Steps) > 0 {
+		markdown += "\n"
+		for _, s := range j.Steps {
+			markdown += fmt.Sprintf("%d. %s `%s`\n", s.Number, s.Name, s.State())
+		}
+	}
+	return markdown
+}
+
+// MyActionJobResponse represents the response for listing jobs of a run.
+type MyActionJobResponse struct {
+	TotalCount int64          `json:"total_count"`
+	Jobs       []*MyActionJob `json:"jobs"`
+}
+
+// ActionJobList represents a list of workflow jobs response
+// Used by endpoints:
+// - GET /repos/{owner}/{repo}/actions/runs/{run}/jobs
+type ActionJobList struct {
+	*MyActionJobResponse
+}
+
+// ToMarkdown renders every job with its steps
+func (jl ActionJobList) ToMarkdown() string {
+	if jl.MyActionJobResponse == nil || len(jl.Jobs) == 0 {
+		return "*No jobs found*"
+	}
+	parts := make([]string, len(jl.Jobs))
+	for i, job := range jl.Jobs {
+		parts[i] = job.ToMarkdown()
+	}
+	return strings.Join(parts, "\n")
+}
+
+// shortSHA returns the first 8 characters of a commit SHA.
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
+
+// MyDispatchedRun represents the run created by dispatching a workflow.
+type MyDispatchedRun struct {
+	ID        int64    `json:"id"`
+	RunNumber int64    `json:"run_number"`
+	Jobs      []string `json:"jobs"`
+}
+
+// ToMarkdown renders dispatched run with its ID and jobs
+// Example: Run #12 (ID: 345) | Jobs: build, test
+func (r *MyDispatchedRun) ToMarkdown() string {
+	markdown := fmt.Sprintf("Run #%d (ID: %d)", r.RunNumber, r.ID)
+	if len(r.Jobs) > 0 {
+		markdown += " | Jobs: " + strings.Join(r.Jobs, ", ")
+	}
+	return markdown
+}
+
+// MySecret represents an Actions secret. The value is never returned.
+type MySecret struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SecretList represents a list of Actions secrets response
+// Used by endpoints:
+// - GET /repos/{owner}/{repo}/actions/secrets
+// - GET /orgs/{org}/actions/secrets
+// - GET /user/actions/secrets
+type SecretList []*MySecret
+
+// ToMarkdown renders secret names with creation time
+// Example:
+// - `DEPLOY_KEY` | Created: 2024-01-15 14:30
+func (sl SecretList) ToMarkdown() string {
+	if len(sl) == 0 {
+		return "*No secrets found*"
+	}
+	markdown := ""
+	for _, s := range sl {
+		markdown += fmt.Sprintf("- `%s`", s.Name)
+		if !s.CreatedAt.IsZero() {
+			markdown += " | Created: " + s.CreatedAt.Format("2006-01-02 15:04")
+		}
+		markdown += "\n"
+	}
+	return markdown
+}
+
+// MyActionVariable represents an Actions variable.
+type MyActionVariable struct {
+	OwnerID     int64  `json:"owner_id"`
+	RepoID      int64  `json:"repo_id"`
+	Name        string `json:"name"`
+	Data        string `json:"data"`
+	Description string `json:"description"`
+}
+
+// ToMarkdown renders variable name and value
+// Example: `NODE_VERSION`: `20`
+func (v *MyActionVariable) ToMarkdown() string {
+	markdown := fmt.Sprintf("`%s`: ", v.Name)
+	if strings.Contains(v.Data, "\n") {
+		markdown += "\n```\n" + v.Data + "\n```"
+	} else {
+		markdown += "`" + v.Data + "`"
+	}
+	if v.Description != "" {
+		markdown += " - " + v.Description
+	}
+	return markdown
+}
+
+// ActionVariableList represents a list of Actions variables response
+// Used by endpoints:
+// - GET /repos/{owner}/{repo}/actions/variables
+// - GET /orgs/{org}/actions/variables
+// - GET /user/actions/variables
+type ActionVariableList []*MyActionVariable
+
+// ToMarkdown renders variables as a bullet list
+func (vl ActionVariableList) ToMarkdown() string {
+	if len(vl) == 0 {
+		return "*No variables found*"
+	}
+	markdown := ""
+	for _, v := range vl {
+		markdown += "- " + v.ToMarkdown() + "\n"
+	}
+	return markdown
+}
+
+// MyActionArtifact represents an artifact uploaded by a workflow run.
+type MyActionArtifact struct {
+	ID                 int64     `json:"id"`
+	Name               string    `json:"name"`
+	SizeInBytes        int64     `json:"size_in_bytes"`
+	URL                string    `json:"url"`
+	ArchiveDownloadURL string    `json:"archive_download_url"`
+	Expired            bool      `json:"expired"`
+	CreatedAt          time.Time `json:"created_at"`
+	ExpiresAt          time.Time `json:"expires_at"`
+	WorkflowRun        *struct {
+		ID         int64  `json:"id"`
+		HeadBranch string `json:"head_branch"`
+		HeadSHA    string `json:"head_sha"`
+	} `json:"workflow_run"`
+}
+
+// ToMarkdown renders artifact with name, size, run and expiration
+// Example: **coverage** (ID: 12) | 1536 bytes | Run ID: 345 @ 1a2b3c4d | Created: 2024-01-15 14:30 | Expires: 2024-04-14 14:30
+func (a *MyActionArtifact) ToMarkdown() string {
+	markdown := fmt.Sprintf("**%s** (ID: %d) | %d bytes", a.Name, a.ID, a.SizeInBytes)
+	if a.WorkflowRun != nil {
+		markdown += fmt.Sprintf(" | Run ID: %d", a.WorkflowRun.ID)
+		if a.WorkflowRun.HeadSHA != "" {
+			markdown += " @ " + shortSHA(a.WorkflowRun.HeadSHA)
+		}
+	}
+	if !a.CreatedAt.IsZero() {
+		markdown += " | Created: " + a.CreatedAt.Format("2006-01-02 15:04")
+	}
+	if a.Expired {
+		markdown += " | `expired`"
+	} else if !a.ExpiresAt.IsZero() {
+		markdown += " | Expires: " + a.ExpiresAt.Format("2006-01-02 15:04")
+	}
+	return markdown
+}
+
+// MyActionArtifactResponse represents the response for listing artifacts.
+type MyActionArtifactResponse struct {
+	TotalCount int64               `json:"total_count"`
+	Artifacts  []*MyActionArtifact `json:"artifacts"`
+}
+
+// ActionArtifactList represents a list of artifacts response
+// Used by endpoints:
+// - GET /repos/{owner}/{repo}/actions/artifacts
+// - GET /repos/{owner}/{repo}/actions/runs/{run}/artifacts
+type ActionArtifactList struct {
+	*MyActionArtifactResponse
+}
+
+// ToMarkdown renders artifacts as a bullet list
+func (al ActionArtifactList) ToMarkdown() string {
+	if al.MyActionArtifactResponse == nil || len(al.Artifacts) == 0 {
+		return "*No artifacts found*"
+	}
+	markdown := ""
+	for _, a := range al.Artifacts {
+		markdown += "- " + a.ToMarkdown() + "\n"
+	}
+	return markdown
+}
+
+// MyActionRunnerLabel represents a label of a runner.
+type MyActionRunnerLabel struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"` // custom or read-only
+}
+
+// MyActionRunner represents an Actions runner.
+type MyActionRunner struct {
+	ID        int64                  `json:"id"`
+	Name      string                 `json:"name"`
+	Status    string                 `json:"status"` // online, offline, idle, active
+	Busy      bool                   `json:"busy"`
+	Disabled  bool                   `json:"disabled"`
+	Ephemeral bool                   `json:"ephemeral"`
+	Labels    []*MyActionRunnerLabel `json:"labels"`
+}
+
+// ToMarkdown renders runner with status and labels
+// Example: **runner-1** `online` busy (ID: 3) | Labels: docker, ubuntu-latest
+func (r *MyActionRunner) ToMarkdown() string {
+	markdown := fmt.Sprintf("**%s** `%s`", r.Name, r.Status)
+	if r.Busy {
+		markdown += " busy"
+	}
+	if r.Disabled {
+		markdown += " disabled"
+	}
+	if r.Ephemeral {
+		markdown += " ephemeral"
+	}
+	markdown += fmt.Sprintf(" (ID: %d)", r.ID)
+	if len(r.Labels) > 0 {
+		names := make([]string, len(r.Labels))
+		for i, l := range r.Labels {
+			names[i] = l.Name
+		}
+		markdown += " | Labels: " + strings.Join(names, ", ")
+	}
+	return markdown
+}
+
+// MyActionRunnerResponse represents the response for listing runners.
+type MyActionRunnerResponse struct {
+	TotalCount int64             `json:"total_count"`
+	Runners    []*MyActionRunner `json:"runners"`
+}
+
+// ActionRunnerList represents a list of runners response
+// Used by endpoints:
+// - GET /repos/{owner}/{repo}/actions/runners
+// - GET /orgs/{org}/actions/runners
+// - GET /user/actions/runners
+// - GET /admin/actions/runners
+type ActionRunnerList struct {
+	*MyActionRunnerResponse
+}
+
+// ToMarkdown renders runners as a bullet list
+func (rl ActionRunnerList) ToMarkdown() string {
+	if rl.MyActionRunnerResponse == nil || len(rl.Runners) == 0 {
+		return "*No runners found*"
+	}
+	markdown := ""
+	for _, r := range rl.Runners {
+		markdown += "- " + r.ToMarkdown() + "\n"
+	}
+	return markdown
+}
+
+// MyRunnerRegistrationToken represents a token to register runners.
+type MyRunnerRegistrationToken struct {
+	Token string `json:"token"`
+}
@@ -0,0 +1,143 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Node colors of dependency graphs by issue state, as fill and stroke.
+var graphColors = map[string][2]string{
+	"open":   {"#dafbe1", "#1a7f37"},
+	"closed": {"#fbefff", "#8250df"},
+}
+
+// GraphNode is an issue in a dependency graph.
+type GraphNode struct {
+	Owner string
+	Repo  string
+	Index int64
+	Title string
+	State string
+	// Seed marks the issues the graph was built from.
+	Seed bool
+}
+
+// Ref returns the reference of the node, e.g. "owner/repo#12".
+func (n *GraphNode) Ref() string {
+	return IssueRef(n.Owner, n.Repo, n.Index)
+}
+
+// GraphEdge is a dependency: From blocks To, To depends on From.
+type GraphEdge struct {
+	From, To string
+}
+
+// DependencyGraph is a graph of issues and their dependencies, built by the
+// get_dependency_graph tool. Nodes are keyed by GraphNode.Ref.
+type DependencyGraph struct {
+	// Owner and Repo are the repository of the seeds. Its issues are labeled
+	// "#12", issues of other repositories "owner/repo#12".
+	Owner string
+	Repo  string
+	Nodes []*GraphNode
+	Edges []GraphEdge
+	// Truncated is set if the walk stopped at the node limit.
+	Truncated bool
+}
+
+// label returns the text of node n, its short reference and title.
+func (g *DependencyGraph) label(n *GraphNode) string {
+	ref := n.Ref()
+	if strings.EqualFold(n.Owner, g.Owner) && strings.EqualFold(n.Repo, g.Repo) {
+		ref = fmt.Sprintf("#%d", n.Index)
+	}
+	return ref + "\n" + n.Title
+}
+
+// ids assigns the identifiers n0, n1, ... to nodes in order.
+func (g *DependencyGraph) ids() map[string]string {
+	ret := make(map[string]string, len(g.Nodes))
+	for i, n := range g.Nodes {
+		ret[n.Ref()] = fmt.Sprintf("n%d", i)
+	}
+	return ret
+}
+
+// Mermaid renders the graph as a Mermaid flowchart. Arrows point from an
+// issue to the issues it blocks, nodes are colored by state and seeds have a
+// thick border.
+// Example:
+// flowchart LR
+//
+//	n0["#12<br/>Fix login bug"]
+//	n1["org/lib#7<br/>Refactor auth"]
+//	n1 --> n0
+//	classDef open fill:#dafbe1,stroke:#1a7f37
+//	class n0 open
+func (g *DependencyGraph) Mermaid() string {
+	ids := g.ids()
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, n := range g.Nodes {
+		label := strings.NewReplacer(`"`, "#quot;", "\n", "<br/>", "<", "#lt;", ">", "#gt;").Replace(g.label(n))
+		fmt.Fprintf(&b, "    %s[\"%s\"]\n", ids[n.Ref()], label)
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "    %s --> %s\n", ids[e.From], ids[e.To])
+	}
+
+	byState := map[string][]string{}
+	var seeds []string
+	for _, n := range g.Nodes {
+		byState[n.State] = append(byState[n.State], ids[n.Ref()])
+		if n.Seed {
+			seeds = append(seeds, ids[n.Ref()])
+		}
+	}
+	for _, state := range []string{"open", "closed"} {
+		if len(byState[state]) == 0 {
+			continue
+		}
+		c := graphColors[state]
+		fmt.Fprintf(&b, "    classDef %s fill:%s,stroke:%s\n", state, c[0], c[1])
+		fmt.Fprintf(&b, "    class %s %s\n", strings.Join(byState[state], ","), state)
+	}
+	if len(seeds) > 0 {
+		b.WriteString("    classDef seed stroke-width:3px\n")
+		fmt.Fprintf(&b, "    class %s seed\n", strings.Join(seeds, ","))
+	}
+	return b.String()
+}
+
+// DOT renders the graph in the Graphviz DOT language. Arrows point from an
+// issue to the issues it blocks, nodes are colored by state and seeds have a
+// bold border.
+func (g *DependencyGraph) DOT() string {
+	ids := g.ids()
+	var b strings.Builder
+	b.WriteString("digraph dependencies {\n")
+	b.WriteString("    rankdir=LR;\n")
+	b.WriteString("    node [shape=box, style=\"rounded,filled\"];\n")
+	for _, n := range g.Nodes {
+		label := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(g.label(n))
+		attrs := fmt.Sprintf("label=\"%s\"", label)
+		if c, ok := graphColors[n.State]; ok {
+			attrs += fmt.Sprintf(", fillcolor=\"%s\", color=\"%s\"", c[0], c[1])
+		}
+		if n.Seed {
+			attrs += ", penwidth=3"
+		}
+		fmt.Fprintf(&b, "    %s [%s];\n", ids[n.Ref()], attrs)
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "    %s -> %s;\n", ids[e.From], ids[e.To])
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,45 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package types
+
+import "testing"
+
+func testGraph() *DependencyGraph {
+	return &DependencyGraph{
+		Owner: "owner",
+		Repo:  "repo",
+		Nodes: []*GraphNode{
+			{Owner: "owner", Repo: "repo", Index: 12, Title: `Fix "login" <bug>`, State: "open", Seed: true},
+			{Owner: "org", Repo: "lib", Index: 7, Title: `Refactor \auth`, State: "closed"},
+		},
+		Edges: []GraphEdge{{From: "org/lib#7", To: "owner/repo#12"}},
+	}
+}
+
+func TestDependencyGraphMermaid(t *testing.T) {
+	output := testGraph().Mermaid()
+	assertContains(t, output, []string{
+		"flowchart LR\n",
+		`n0["#12<br/>Fix #quot;login#quot; #lt;bug#gt;"]`,
+		`n1["org/lib#7<br/>Refactor \auth"]`,
+		"n1 --> n0",
+		"classDef open fill:#dafbe1,stroke:#1a7f37",
+		"class n0 open",
+		"class n1 closed",
+		"class n0 seed",
+	})
+}
+
+func TestDependencyGraphDOT(t *testing.T) {
+	output := testGraph().DOT()
+	assertContains(t, output, []string{
+		"digraph dependencies {",
+		`n0 [label="#12\nFix \"login\" <bug>", fillcolor="#dafbe1", color="#1a7f37", penwidth=3];`,
+		`n1 [label="org/lib#7\nRefactor \\auth", fillcolor="#fbefff", color="#8250df"];`,
+		"n1 -> n0;",
+	})
+}
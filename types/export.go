@@ -0,0 +1,335 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package types
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Export formats supported by IssueExport.Write and ReadIssueExport.
+const (
+	ExportJSON  = "json"
+	ExportJSONL = "jsonl"
+	ExportCSV   = "csv"
+	// ExportGitHub is only read: a JSON array of issues from the GitHub REST
+	// API or `gh issue list --json`, see readGitHub.
+	ExportGitHub = "github"
+)
+
+// IssueExport is a snapshot of the issue tracker of a repository, as written
+// by the export_issues tool.
+type IssueExport struct {
+	Owner      string               `json:"owner"`
+	Repo       string               `json:"repo"`
+	ExportedAt time.Time            `json:"exported_at"`
+	Labels     []*ExportedLabel     `json:"labels"`
+	Milestones []*ExportedMilestone `json:"milestones"`
+	Issues     []*ExportedIssue     `json:"issues"`
+}
+
+// ExportedLabel is a label of an exported repository.
+type ExportedLabel struct {
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description,omitempty"`
+}
+
+// ExportedMilestone is a milestone of an exported repository.
+type ExportedMilestone struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	State       string     `json:"state"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+}
+
+// ExportedIssue is an issue with its comments and dependency edges. Labels
+// and the milestone are referenced by name, issues by "owner/repo#index".
+type ExportedIssue struct {
+	Index     int64      `json:"index"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	State     string     `json:"state"`
+	Author    string     `json:"author"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	ClosedAt  *time.Time `json:"closed_at,omitempty"`
+	DueDate   *time.Time `json:"due_date,omitempty"`
+	Labels    []string   `json:"labels"`
+	Milestone string     `json:"milestone,omitempty"`
+	Assignees []string   `json:"assignees"`
+	// DependsOn are the issues that must be closed before this one.
+	DependsOn []string `json:"depends_on,omitempty"`
+	// Blocks are the issues that depend on this one.
+	Blocks   []string           `json:"blocks,omitempty"`
+	Comments []*ExportedComment `json:"comments,omitempty"`
+}
+
+// ExportedComment is a comment of an exported issue.
+type ExportedComment struct {
+	Author    string    `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+	Body      string    `json:"body"`
+}
+
+// IssueRef formats the reference to an issue used in exports, e.g.
+// "owner/repo#12".
+func IssueRef(owner, repo string, index int64) string {
+	return fmt.Sprintf("%s/%s#%d", owner, repo, index)
+}
+
+// ExportCSVHeader is the header row of CSV exports. Comments are only counted,
+// use JSON or JSONL to export their content.
+var ExportCSVHeader = []string{
+	"index", "title", "state", "author", "created_at", "updated_at", "closed_at", "due_date",
+	"labels", "milestone", "assignees", "depends_on", "blocks", "comments", "body",
+}
+
+// Write writes the export in format: a JSON document, one JSON issue per line,
+// or a CSV table of issues with ExportCSVHeader.
+func (e *IssueExport) Write(w io.Writer, format string) error {
+	switch format {
+	case ExportJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(e)
+	case ExportJSONL:
+		enc := json.NewEncoder(w)
+		for _, issue := range e.Issues {
+			if err := enc.Encode(issue); err != nil {
+				return err
+			}
+		}
+		return nil
+	case ExportCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(ExportCSVHeader); err != nil {
+			return err
+		}
+		for _, issue := range e.Issues {
+			if err := cw.Write(issue.csvRecord()); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+	return fmt.Errorf("unsupported export format %q, use json, jsonl or csv", format)
+}
+
+// csvRecord renders the issue as a row of ExportCSVHeader. Lists are joined
+// with ", " and times use RFC 3339.
+func (i *ExportedIssue) csvRecord() []string {
+	return []string{
+		strconv.FormatInt(i.Index, 10),
+		i.Title,
+		i.State,
+		i.Author,
+		i.CreatedAt.Format(time.RFC3339),
+		i.UpdatedAt.Format(time.RFC3339),
+		csvTime(i.ClosedAt),
+		csvTime(i.DueDate),
+		strings.Join(i.Labels, ", "),
+		i.Milestone,
+		strings.Join(i.Assignees, ", "),
+		strings.Join(i.DependsOn, ", "),
+		strings.Join(i.Blocks, ", "),
+		strconv.Itoa(len(i.Comments)),
+		i.Body,
+	}
+}
+
+// csvTime formats an optional time for CSV, "" if not set.
+func csvTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// Summary describes the content of the export, e.g.
+// "owner/repo: 12 issues, 30 comments, 4 dependency edges, 5 labels, 2 milestones".
+func (e *IssueExport) Summary() string {
+	comments, edges := 0, 0
+	for _, i := range e.Issues {
+		comments += len(i.Comments)
+		edges += len(i.DependsOn)
+	}
+	return fmt.Sprintf("%s/%s: %d issues, %d comments, %d dependency edges, %d labels, %d milestones",
+		e.Owner, e.Repo, len(e.Issues), comments, edges, len(e.Labels), len(e.Milestones))
+}
+
+// ReadIssueExport reads issues in format. JSON is a document written by
+// IssueExport.Write or an array of issues, JSONL is one issue per line, and
+// CSV needs a header row with a "title" column and any other columns of
+// ExportCSVHeader. Owner and Repo are only set by JSON documents.
+func ReadIssueExport(r io.Reader, format string) (*IssueExport, error) {
+	switch format {
+	case ExportJSON:
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		export := &IssueExport{}
+		if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+			err = json.Unmarshal(data, &export.Issues)
+		} else {
+			err = json.Unmarshal(data, export)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		return export, nil
+	case ExportJSONL:
+		export := &IssueExport{}
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(nil, 16<<20)
+		for line := 1; scanner.Scan(); line++ {
+			if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+				continue
+			}
+			var issue ExportedIssue
+			if err := json.Unmarshal(scanner.Bytes(), &issue); err != nil {
+				return nil, fmt.Errorf("invalid JSON at line %d: %w", line, err)
+			}
+			export.Issues = append(export.Issues, &issue)
+		}
+		return export, scanner.Err()
+	case ExportCSV:
+		return readCSV(r)
+	case ExportGitHub:
+		return readGitHub(r)
+	}
+	return nil, fmt.Errorf("unsupported import format %q, use json, jsonl, csv or github", format)
+}
+
+// readCSV reads issues from CSV with a header row. Issues without an index
+// are numbered by their row.
+func readCSV(r io.Reader) (*IssueExport, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("empty CSV")
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, fmt.Errorf("CSV has no title column")
+	}
+
+	export := &IssueExport{}
+	for n, record := range records[1:] {
+		row := n + 2
+		get := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		getTime := func(name string) (*time.Time, error) {
+			v := get(name)
+			if v == "" {
+				return nil, nil
+			}
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: invalid %s %q (expected RFC 3339)", row, name, v)
+			}
+			return &t, nil
+		}
+
+		issue := &ExportedIssue{
+			Index:     int64(n + 1),
+			Title:     get("title"),
+			Body:      get("body"),
+			State:     strings.ToLower(get("state")),
+			Author:    get("author"),
+			Labels:    splitList(get("labels")),
+			Milestone: get("milestone"),
+			Assignees: splitList(get("assignees")),
+			DependsOn: splitList(get("depends_on")),
+			Blocks:    splitList(get("blocks")),
+		}
+		if issue.Title == "" {
+			return nil, fmt.Errorf("row %d: empty title", row)
+		}
+		if v := get("index"); v != "" {
+			if issue.Index, err = strconv.ParseInt(strings.TrimPrefix(v, "#"), 10, 64); err != nil {
+				return nil, fmt.Errorf("row %d: invalid index %q", row, v)
+			}
+		}
+		if issue.ClosedAt, err = getTime("closed_at"); err != nil {
+			return nil, err
+		}
+		if issue.DueDate, err = getTime("due_date"); err != nil {
+			return nil, err
+		}
+		created, err := getTime("created_at")
+		if err != nil {
+			return nil, err
+		}
+		if created != nil {
+			issue.CreatedAt = *created
+		}
+		export.Issues = append(export.Issues, issue)
+	}
+	return export, nil
+}
+
+// splitList splits a comma-separated list, dropping empty items.
+func splitList(s string) []string {
+	ret := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			ret = append(ret, item)
+		}
+	}
+	return ret
+}
+
+// LabelNames returns the names of the labels of the export and of its issues,
+// sorted and without duplicates.
+func (e *IssueExport) LabelNames() []string {
+	var names []string
+	for _, l := range e.Labels {
+		names = append(names, l.Name)
+	}
+	for _, i := range e.Issues {
+		names = append(names, i.Labels...)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// MilestoneTitles returns the titles of the milestones of the export and of
+// its issues, sorted and without duplicates.
+func (e *IssueExport) MilestoneTitles() []string {
+	var titles []string
+	for _, m := range e.Milestones {
+		titles = append(titles, m.Title)
+	}
+	for _, i := range e.Issues {
+		if i.Milestone != "" {
+			titles = append(titles, i.Milestone)
+		}
+	}
+	slices.Sort(titles)
+	return slices.Compact(titles)
+}
@@ -0,0 +1,166 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// githubUser is a user in GitHub issues, `login` in both formats.
+type githubUser struct {
+	Login string `json:"login"`
+}
+
+// githubLabel is a label in GitHub issues.
+type githubLabel struct {
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+// githubMilestone is a milestone in GitHub issues. The REST API uses
+// `due_on`, `gh` uses `dueOn`.
+type githubMilestone struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	State       string     `json:"state"`
+	DueOn       *time.Time `json:"due_on"`
+	GHDueOn     *time.Time `json:"dueOn"`
+}
+
+// githubComment is a comment in `gh issue list --json comments`.
+type githubComment struct {
+	Author    *githubUser `json:"author"`
+	Body      string      `json:"body"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+// githubIssue decodes issues of both the GitHub REST API (snake_case, `user`,
+// `comments` is a count) and `gh issue list --json` (camelCase, `author`,
+// `comments` is a list).
+type githubIssue struct {
+	Number      int64            `json:"number"`
+	Title       string           `json:"title"`
+	Body        string           `json:"body"`
+	State       string           `json:"state"`
+	User        *githubUser      `json:"user"`
+	Author      *githubUser      `json:"author"`
+	Labels      []*githubLabel   `json:"labels"`
+	Milestone   *githubMilestone `json:"milestone"`
+	Assignees   []*githubUser    `json:"assignees"`
+	CreatedAt   *time.Time       `json:"created_at"`
+	GHCreatedAt *time.Time       `json:"createdAt"`
+	UpdatedAt   *time.Time       `json:"updated_at"`
+	GHUpdatedAt *time.Time       `json:"updatedAt"`
+	ClosedAt    *time.Time       `json:"closed_at"`
+	GHClosedAt  *time.Time       `json:"closedAt"`
+	Comments    json.RawMessage  `json:"comments"`
+	PullRequest json.RawMessage  `json:"pull_request"`
+}
+
+// readGitHub reads a JSON array of GitHub issues, see githubIssue. Pull
+// requests are skipped, labels and milestones are collected with their
+// colors and due dates. Comments are only available in the `gh` format.
+func readGitHub(r io.Reader) (*IssueExport, error) {
+	var issues []*githubIssue
+	if err := json.NewDecoder(r).Decode(&issues); err != nil {
+		return nil, fmt.Errorf("invalid GitHub issues JSON: %w", err)
+	}
+
+	export := &IssueExport{}
+	labels := map[string]bool{}
+	milestones := map[string]bool{}
+	for _, gi := range issues {
+		if len(gi.PullRequest) > 0 && string(gi.PullRequest) != "null" {
+			continue
+		}
+
+		issue := &ExportedIssue{
+			Index:     gi.Number,
+			Title:     gi.Title,
+			Body:      gi.Body,
+			State:     strings.ToLower(gi.State),
+			Author:    githubLogin(gi.User, gi.Author),
+			CreatedAt: firstTime(gi.CreatedAt, gi.GHCreatedAt),
+			UpdatedAt: firstTime(gi.UpdatedAt, gi.GHUpdatedAt),
+			Labels:    []string{},
+			Assignees: []string{},
+		}
+		if t := firstTime(gi.ClosedAt, gi.GHClosedAt); !t.IsZero() {
+			issue.ClosedAt = &t
+		}
+		for _, l := range gi.Labels {
+			issue.Labels = append(issue.Labels, l.Name)
+			if !labels[l.Name] {
+				labels[l.Name] = true
+				export.Labels = append(export.Labels, &ExportedLabel{Name: l.Name, Color: l.Color, Description: l.Description})
+			}
+		}
+		for _, a := range gi.Assignees {
+			issue.Assignees = append(issue.Assignees, a.Login)
+		}
+		if m := gi.Milestone; m != nil {
+			issue.Milestone = m.Title
+			if !milestones[m.Title] {
+				milestones[m.Title] = true
+				due := m.DueOn
+				if due == nil {
+					due = m.GHDueOn
+				}
+				export.Milestones = append(export.Milestones, &ExportedMilestone{
+					Title:       m.Title,
+					Description: m.Description,
+					State:       strings.ToLower(m.State),
+					DueDate:     due,
+				})
+			}
+		}
+
+		if bytes.HasPrefix(bytes.TrimSpace(gi.Comments), []byte("[")) {
+			var comments []*githubComment
+			if err := json.Unmarshal(gi.Comments, &comments); err != nil {
+				return nil, fmt.Errorf("invalid comments of issue #%d: %w", gi.Number, err)
+			}
+			for _, c := range comments {
+				issue.Comments = append(issue.Comments, &ExportedComment{
+					Author:    githubLogin(c.Author, nil),
+					CreatedAt: c.CreatedAt,
+					Body:      c.Body,
+				})
+			}
+		}
+
+		export.Issues = append(export.Issues, issue)
+	}
+	return export, nil
+}
+
+// githubLogin returns the login of the first non-nil user, or "ghost" for
+// deleted users.
+func githubLogin(users ...*githubUser) string {
+	for _, u := range users {
+		if u != nil && u.Login != "" {
+			return u.Login
+		}
+	}
+	return "ghost"
+}
+
+// firstTime returns the first non-nil time, or the zero time.
+func firstTime(times ...*time.Time) time.Time {
+	for _, t := range times {
+		if t != nil {
+			return *t
+		}
+	}
+	return time.Time{}
+}
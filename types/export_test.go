@@ -0,0 +1,221 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package types
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func testExport() *IssueExport {
+	created := testTime()
+	return &IssueExport{
+		Owner:      "owner",
+		Repo:       "repo",
+		ExportedAt: created,
+		Labels:     []*ExportedLabel{{Name: "bug", Color: "ee0701"}},
+		Milestones: []*ExportedMilestone{{Title: "v1.0", State: "open"}},
+		Issues: []*ExportedIssue{
+			{
+				Index:     12,
+				Title:     "Fix login bug",
+				Body:      "Login fails,\nsee \"logs\"",
+				State:     "closed",
+				Author:    "testuser",
+				CreatedAt: created,
+				UpdatedAt: created,
+				ClosedAt:  &created,
+				Labels:    []string{"bug", "urgent"},
+				Milestone: "v1.0",
+				Assignees: []string{"alice"},
+				DependsOn: []string{"owner/repo#3", "org/lib#7"},
+				Comments:  []*ExportedComment{{Author: "alice", CreatedAt: created, Body: "Fixed"}},
+			},
+			{
+				Index:     13,
+				Title:     "Add search",
+				State:     "open",
+				Author:    "testuser",
+				CreatedAt: created,
+				UpdatedAt: created,
+				Labels:    []string{},
+				Assignees: []string{},
+				Blocks:    []string{"owner/repo#12"},
+			},
+		},
+	}
+}
+
+func TestIssueExport_WriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testExport().Write(&buf, ExportJSON); err != nil {
+		t.Fatal(err)
+	}
+
+	var got IssueExport
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(got.Issues) != 2 || got.Issues[0].Comments[0].Body != "Fixed" || got.Labels[0].Name != "bug" {
+		t.Errorf("unexpected round trip: %s", buf.String())
+	}
+	assertContains(t, buf.String(), []string{`"depends_on": [`, `"org/lib#7"`, `"closed_at": "2024-01-15T14:30:00Z"`})
+}
+
+func TestIssueExport_WriteJSONL(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testExport().Write(&buf, ExportJSONL); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %s", len(lines), buf.String())
+	}
+	var issue ExportedIssue
+	if err := json.Unmarshal([]byte(lines[1]), &issue); err != nil {
+		t.Fatalf("invalid JSON line: %v", err)
+	}
+	if issue.Index != 13 || len(issue.Blocks) != 1 {
+		t.Errorf("unexpected issue: %+v", issue)
+	}
+}
+
+func TestIssueExport_WriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testExport().Write(&buf, ExportCSV); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected header and 2 rows, got %d", len(records))
+	}
+	want := []string{
+		"12", "Fix login bug", "closed", "testuser", "2024-01-15T14:30:00Z", "2024-01-15T14:30:00Z", "2024-01-15T14:30:00Z", "",
+		"bug, urgent", "v1.0", "alice", "owner/repo#3, org/lib#7", "", "1", "Login fails,\nsee \"logs\"",
+	}
+	for i, v := range want {
+		if records[1][i] != v {
+			t.Errorf("column %s = %q, want %q", ExportCSVHeader[i], records[1][i], v)
+		}
+	}
+}
+
+func TestIssueExport_WriteUnsupported(t *testing.T) {
+	if err := testExport().Write(&bytes.Buffer{}, "xml"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestIssueExport_Summary(t *testing.T) {
+	want := "owner/repo: 2 issues, 1 comments, 2 dependency edges, 1 labels, 1 milestones"
+	if got := testExport().Summary(); got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+}
+
+func TestReadIssueExport_RoundTrip(t *testing.T) {
+	for _, format := range []string{ExportJSON, ExportJSONL, ExportCSV} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := testExport().Write(&buf, format); err != nil {
+				t.Fatal(err)
+			}
+			got, err := ReadIssueExport(&buf, format)
+			if err != nil {
+				t.Fatalf("ReadIssueExport() error = %v", err)
+			}
+			if len(got.Issues) != 2 {
+				t.Fatalf("expected 2 issues, got %d", len(got.Issues))
+			}
+			issue := got.Issues[0]
+			if issue.Index != 12 || issue.Title != "Fix login bug" || issue.State != "closed" || issue.Milestone != "v1.0" {
+				t.Errorf("unexpected issue: %+v", issue)
+			}
+			if issue.Body != "Login fails,\nsee \"logs\"" || issue.ClosedAt == nil || !issue.CreatedAt.Equal(testTime()) {
+				t.Errorf("unexpected body or times: %+v", issue)
+			}
+			if strings.Join(issue.Labels, "|") != "bug|urgent" || strings.Join(issue.DependsOn, "|") != "owner/repo#3|org/lib#7" {
+				t.Errorf("unexpected lists: %+v", issue)
+			}
+			if strings.Join(got.Issues[1].Blocks, "|") != "owner/repo#12" {
+				t.Errorf("unexpected blocks: %+v", got.Issues[1])
+			}
+		})
+	}
+}
+
+func TestReadIssueExport_CSV(t *testing.T) {
+	data := "Title,Labels,due_date\nFirst,\"bug, ui\",2024-02-01T00:00:00Z\nSecond,,\n"
+	got, err := ReadIssueExport(strings.NewReader(data), ExportCSV)
+	if err != nil {
+		t.Fatalf("ReadIssueExport() error = %v", err)
+	}
+	if len(got.Issues) != 2 || got.Issues[1].Index != 2 || got.Issues[1].Title != "Second" {
+		t.Fatalf("unexpected issues: %+v", got.Issues)
+	}
+	if strings.Join(got.Issues[0].Labels, "|") != "bug|ui" || got.Issues[0].DueDate == nil {
+		t.Errorf("unexpected first issue: %+v", got.Issues[0])
+	}
+	if strings.Join(got.LabelNames(), "|") != "bug|ui" {
+		t.Errorf("LabelNames() = %v", got.LabelNames())
+	}
+
+	for _, data := range []string{"body\nno title\n", "title,index\nX,abc\n", "title,closed_at\nX,yesterday\n", "title\n\"\"\n"} {
+		if _, err := ReadIssueExport(strings.NewReader(data), ExportCSV); err == nil {
+			t.Errorf("expected error for %q", data)
+		}
+	}
+}
+
+func TestReadIssueExport_GitHub(t *testing.T) {
+	// The REST API format, with a pull request to skip
+	rest := `[
+		{"number": 1, "title": "Crash", "body": "It crashes", "state": "open", "user": {"login": "alice"},
+		 "labels": [{"name": "bug", "color": "d73a4a", "description": "Something is broken"}],
+		 "milestone": {"title": "v1", "state": "open", "due_on": "2024-03-01T00:00:00Z"},
+		 "assignees": [{"login": "bob"}], "created_at": "2024-01-15T14:30:00Z", "comments": 2},
+		{"number": 2, "title": "PR", "state": "open", "pull_request": {"url": "x"}}
+	]`
+	got, err := ReadIssueExport(strings.NewReader(rest), ExportGitHub)
+	if err != nil {
+		t.Fatalf("ReadIssueExport() error = %v", err)
+	}
+	if len(got.Issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d", len(got.Issues))
+	}
+	issue := got.Issues[0]
+	if issue.Author != "alice" || issue.Milestone != "v1" || !issue.CreatedAt.Equal(testTime()) || len(issue.Comments) != 0 {
+		t.Errorf("unexpected issue: %+v", issue)
+	}
+	if len(got.Labels) != 1 || got.Labels[0].Color != "d73a4a" || len(got.Milestones) != 1 || got.Milestones[0].DueDate == nil {
+		t.Errorf("unexpected labels or milestones: %+v %+v", got.Labels, got.Milestones)
+	}
+
+	// The gh CLI format
+	gh := `[{"number": 5, "title": "Docs", "state": "CLOSED", "author": {"login": "carol"},
+		"createdAt": "2024-01-15T14:30:00Z", "closedAt": "2024-01-16T00:00:00Z",
+		"comments": [{"author": {"login": "dave"}, "body": "Done", "createdAt": "2024-01-16T00:00:00Z"}]}]`
+	got, err = ReadIssueExport(strings.NewReader(gh), ExportGitHub)
+	if err != nil {
+		t.Fatalf("ReadIssueExport() error = %v", err)
+	}
+	issue = got.Issues[0]
+	if issue.State != "closed" || issue.Author != "carol" || issue.ClosedAt == nil || !issue.CreatedAt.Equal(testTime()) {
+		t.Errorf("unexpected issue: %+v", issue)
+	}
+	if len(issue.Comments) != 1 || issue.Comments[0].Author != "dave" || issue.Comments[0].Body != "Done" {
+		t.Errorf("unexpected comments: %+v", issue.Comments)
+	}
+}
@@ -104,6 +104,42 @@ func TestIssueList_ToMarkdown(t *testing.T) {
 	}
 }
 
+func TestSearchIssueList_ToMarkdown(t *testing.T) {
+	updated := testTime()
+	list := SearchIssueList{
+		&forgejo.Issue{
+			Index:      12,
+			Title:      "Fix login bug",
+			State:      "open",
+			Repository: &forgejo.RepositoryMeta{FullName: "owner/app"},
+			Updated:    updated,
+		},
+		&forgejo.Issue{
+			Index:       34,
+			Title:       "Add search",
+			State:       "open",
+			Repository:  &forgejo.RepositoryMeta{FullName: "org/lib"},
+			PullRequest: &forgejo.PullRequestMeta{},
+			Updated:     updated,
+		},
+	}
+	assertContains(t, list.ToMarkdown(), []string{
+		"owner/app#12", "Fix login bug", "org/lib#34 [PR]", "Add search", "2024-01-15",
+	})
+	assertContains(t, SearchIssueList{}.ToMarkdown(), []string{"No issues found"})
+}
+
+func TestPinnedIssueList_ToMarkdown(t *testing.T) {
+	list := PinnedIssueList{
+		&forgejo.Issue{Index: 12, Title: "Release tracker", State: "open", Updated: testTime()},
+		&forgejo.Issue{Index: 34, Title: "Add search", State: "open", PullRequest: &forgejo.PullRequestMeta{}},
+	}
+	assertContains(t, list.ToMarkdown(), []string{
+		"1. #12 Release tracker (open) | 2024-01-15", "2. #34 [PR] Add search (open)",
+	})
+	assertContains(t, PinnedIssueList{}.ToMarkdown(), []string{"No pinned issues"})
+}
+
 func TestComment_ToMarkdown(t *testing.T) {
 	created := testTime()
 	tests := []struct {
@@ -136,3 +172,34 @@ func TestComment_ToMarkdown(t *testing.T) {
 		})
 	}
 }
+
+func TestReactionList_ToMarkdown(t *testing.T) {
+	other := &forgejo.User{UserName: "otheruser"}
+	tests := []struct {
+		name     string
+		list     ReactionList
+		required []string
+	}{
+		{
+			name: "reactions grouped by content",
+			list: ReactionList{
+				{User: testUser(), Reaction: "+1"},
+				{User: testUser(), Reaction: "heart"},
+				{User: other, Reaction: "+1"},
+			},
+			required: []string{"- **+1** (2): testuser, otheruser", "- **heart** (1): testuser"},
+		},
+		{
+			name:     "no reactions",
+			list:     ReactionList{},
+			required: []string{"No reactions"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := tt.list.ToMarkdown()
+			assertContains(t, output, tt.required)
+		})
+	}
+}
@@ -109,37 +109,103 @@ func (il IssueList) ToMarkdown() string {
 		if issue == nil {
 			continue
 		}
+		markdown += issueLine(fmt.Sprintf("#%d", issue.Index), issue) + "\n"
+	}
 
-		// Index, Title, and State
-		line := fmt.Sprintf("#%d %s (%s)", issue.Index, issue.Title, issue.State)
+	return markdown
+}
 
-		// Assignees
-		if len(issue.Assignees) > 0 {
-			assigneeNames := make([]string, len(issue.Assignees))
-			for i, assignee := range issue.Assignees {
-				assigneeNames[i] = assignee.UserName
-			}
-			line += " | [" + strings.Join(assigneeNames, " ") + "]"
+// issueLine renders an issue in a single line, ref identifies the issue.
+func issueLine(ref string, issue *forgejo.Issue) string {
+	// Index, Title, and State
+	line := fmt.Sprintf("%s %s (%s)", ref, issue.Title, issue.State)
+
+	// Assignees
+	if len(issue.Assignees) > 0 {
+		assigneeNames := make([]string, len(issue.Assignees))
+		for i, assignee := range issue.Assignees {
+			assigneeNames[i] = assignee.UserName
 		}
+		line += " | [" + strings.Join(assigneeNames, " ") + "]"
+	}
 
-		// Labels
-		if len(issue.Labels) > 0 {
-			labelNames := make([]string, len(issue.Labels))
-			for i, label := range issue.Labels {
-				labelNames[i] = label.Name
-			}
-			line += " | [" + strings.Join(labelNames, " ") + "]"
+	// Labels
+	if len(issue.Labels) > 0 {
+		labelNames := make([]string, len(issue.Labels))
+		for i, label := range issue.Labels {
+			labelNames[i] = label.Name
 		}
+		line += " | [" + strings.Join(labelNames, " ") + "]"
+	}
+
+	// Updated time
+	if !issue.Updated.IsZero() {
+		line += " | " + issue.Updated.Format("2006-01-02")
+	}
+
+	// Comments count
+	line += fmt.Sprintf(" | %d", issue.Comments)
 
-		// Updated time
-		if !issue.Updated.IsZero() {
-			line += " | " + issue.Updated.Format("2006-01-02")
+	return line
+}
+
+// SearchIssueList represents issues found across repositories
+// Used by endpoints:
+// - GET /repos/issues/search
+type SearchIssueList []*forgejo.Issue
+
+// ToMarkdown renders issues like IssueList, prefixed with their repository and
+// marking pull requests
+// Example per issue:
+// owner/repo#123 [PR] Fix login bug (open) | [testuser] | [bug] | 2024-01-15 | 5
+func (sl SearchIssueList) ToMarkdown() string {
+	if len(sl) == 0 {
+		return "*No issues found*"
+	}
+
+	markdown := ""
+	for _, issue := range sl {
+		if issue == nil {
+			continue
+		}
+		ref := fmt.Sprintf("#%d", issue.Index)
+		if issue.Repository != nil {
+			ref = issue.Repository.FullName + ref
 		}
+		if issue.PullRequest != nil {
+			ref += " [PR]"
+		}
+		markdown += issueLine(ref, issue) + "\n"
+	}
 
-		// Comments count
-		line += fmt.Sprintf(" | %d", issue.Comments)
+	return markdown
+}
+
+// PinnedIssueList represents the pinned issues or pull requests of a
+// repository, in pin order
+// Used by endpoints:
+// - GET /repos/{owner}/{repo}/issues/pinned
+// - GET /repos/{owner}/{repo}/pulls/pinned
+type PinnedIssueList []*forgejo.Issue
 
-		markdown += line + "\n"
+// ToMarkdown renders pinned issues like IssueList, numbered by pin position
+// Example per issue:
+// 1. #123 Fix login bug (open) | [testuser] | [bug] | 2024-01-15 | 5
+func (pl PinnedIssueList) ToMarkdown() string {
+	if len(pl) == 0 {
+		return "*No pinned issues*"
+	}
+
+	markdown := ""
+	for i, issue := range pl {
+		if issue == nil {
+			continue
+		}
+		ref := fmt.Sprintf("#%d", issue.Index)
+		if issue.PullRequest != nil {
+			ref += " [PR]"
+		}
+		markdown += fmt.Sprintf("%d. %s\n", i+1, issueLine(ref, issue))
 	}
 
 	return markdown
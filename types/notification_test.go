@@ -0,0 +1,86 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package types
+
+import (
+	"testing"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+)
+
+func TestNotificationThread_ToMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		thread   *NotificationThread
+		required []string
+	}{
+		{
+			name: "complete notification with all fields",
+			thread: &NotificationThread{
+				NotificationThread: &forgejo.NotificationThread{
+					ID:         42,
+					Repository: &forgejo.Repository{FullName: "owner/repo"},
+					Subject: &forgejo.NotificationSubject{
+						Title:                "Fix login bug",
+						HTMLURL:              "https://git.example.com/owner/repo/issues/1",
+						LatestCommentHTMLURL: "https://git.example.com/owner/repo/issues/1#issuecomment-7",
+						Type:                 forgejo.NotifySubjectIssue,
+						State:                forgejo.NotifySubjectOpen,
+					},
+					Unread:    true,
+					Pinned:    true,
+					UpdatedAt: testTime(),
+				},
+			},
+			required: []string{
+				"#42", "[Issue]", "owner/repo:", "Fix login bug (open)", "UNREAD", "PINNED", "2024-01-15 14:30",
+				"[View](https://git.example.com/owner/repo/issues/1)", "[Latest Comment](https://git.example.com/owner/repo/issues/1#issuecomment-7)",
+			},
+		},
+		{
+			name:     "nil notification",
+			thread:   &NotificationThread{NotificationThread: nil},
+			required: []string{"Invalid notification"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := tt.thread.ToMarkdown()
+			assertContains(t, output, tt.required)
+		})
+	}
+}
+
+func TestNotificationList_ToMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		list     NotificationList
+		required []string
+	}{
+		{
+			name: "notifications of different subjects",
+			list: NotificationList{
+				{ID: 1, Subject: &forgejo.NotificationSubject{Title: "Add search", Type: forgejo.NotifySubjectPull, State: forgejo.NotifySubjectMerged}},
+				{ID: 2, Subject: &forgejo.NotificationSubject{Title: "v1.0.0", Type: forgejo.NotifySubjectRepository}},
+			},
+			required: []string{"- **#1** [Pull]", "Add search (merged)", "- **#2** [Repository]", "v1.0.0"},
+		},
+		{
+			name:     "empty notification list",
+			list:     NotificationList{},
+			required: []string{"No notifications found"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := tt.list.ToMarkdown()
+			assertContains(t, output, tt.required)
+		})
+	}
+}
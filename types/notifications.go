@@ -0,0 +1,87 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package types
+
+import (
+	"fmt"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+)
+
+// NotificationThread represents a notification thread response with embedded
+// SDK notification thread
+// Used by endpoints:
+// - GET /notifications/threads/{id}
+// - PATCH /notifications/threads/{id}
+type NotificationThread struct {
+	*forgejo.NotificationThread
+}
+
+// ToMarkdown renders the notification with its subject, status and links
+// Example: **#42** [Issue] owner/repo: Fix login bug (open) `UNREAD` `PINNED`
+// Updated: 2024-01-15 14:30 | [View](https://git.example.com/owner/repo/issues/1)
+func (n *NotificationThread) ToMarkdown() string {
+	if n.NotificationThread == nil {
+		return "*Invalid notification*"
+	}
+	markdown := fmt.Sprintf("**#%d**", n.ID)
+	title := "(no subject)"
+	if s := n.Subject; s != nil {
+		markdown += fmt.Sprintf(" [%s]", s.Type)
+		title = s.Title
+		if s.State != "" {
+			title += fmt.Sprintf(" (%s)", s.State)
+		}
+	}
+	if n.Repository != nil {
+		markdown += " " + n.Repository.FullName + ":"
+	}
+	markdown += " " + title
+	if n.Unread {
+		markdown += " `UNREAD`"
+	}
+	if n.Pinned {
+		markdown += " `PINNED`"
+	}
+	markdown += "\nUpdated: " + n.UpdatedAt.Format("2006-01-02 15:04")
+	if s := n.Subject; s != nil {
+		if s.HTMLURL != "" {
+			markdown += " | [View](" + s.HTMLURL + ")"
+		}
+		if s.LatestCommentHTMLURL != "" && s.LatestCommentHTMLURL != s.HTMLURL {
+			markdown += " | [Latest Comment](" + s.LatestCommentHTMLURL + ")"
+		}
+	}
+	return markdown
+}
+
+// NotificationList represents a list of notification threads response
+// Used by endpoints:
+// - GET /notifications
+// - GET /repos/{owner}/{repo}/notifications
+// - PUT /notifications
+// - PUT /repos/{owner}/{repo}/notifications
+type NotificationList []*forgejo.NotificationThread
+
+// ToMarkdown renders notifications as a list
+// Example:
+// - **#42** [Issue] owner/repo: Fix login bug (open) `UNREAD`
+// Updated: 2024-01-15 14:30 | [View](https://git.example.com/owner/repo/issues/1)
+func (nl NotificationList) ToMarkdown() string {
+	if len(nl) == 0 {
+		return "*No notifications found*"
+	}
+
+	markdown := ""
+	for _, n := range nl {
+		if n == nil {
+			continue
+		}
+		markdown += "- " + (&NotificationThread{NotificationThread: n}).ToMarkdown() + "\n"
+	}
+	return markdown
+}
@@ -0,0 +1,63 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+)
+
+// ReactionList represents a list of reactions response
+// Used by endpoints:
+// - GET /repos/{owner}/{repo}/issues/{index}/reactions
+// - GET /repos/{owner}/{repo}/issues/comments/{id}/reactions
+type ReactionList []*forgejo.Reaction
+
+// Counts returns the number of reactions per content, e.g. "+1".
+func (rl ReactionList) Counts() map[string]int {
+	ret := map[string]int{}
+	for _, r := range rl {
+		if r != nil {
+			ret[r.Reaction]++
+		}
+	}
+	return ret
+}
+
+// ToMarkdown renders reactions grouped by content in order of first use, with
+// the users who reacted
+// Example:
+// - **+1** (2): testuser, otheruser
+// - **heart** (1): testuser
+func (rl ReactionList) ToMarkdown() string {
+	var order []string
+	users := map[string][]string{}
+	for _, r := range rl {
+		if r == nil {
+			continue
+		}
+		if _, ok := users[r.Reaction]; !ok {
+			order = append(order, r.Reaction)
+		}
+		name := "(unknown)"
+		if r.User != nil {
+			name = r.User.UserName
+		}
+		users[r.Reaction] = append(users[r.Reaction], name)
+	}
+	if len(order) == 0 {
+		return "*No reactions*"
+	}
+
+	markdown := ""
+	for _, content := range order {
+		markdown += fmt.Sprintf("- **%s** (%d): %s\n", content, len(users[content]), strings.Join(users[content], ", "))
+	}
+	return markdown
+}
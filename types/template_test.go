@@ -0,0 +1,73 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package types
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestIssueTemplateList_ToMarkdown(t *testing.T) {
+	var list IssueTemplateList
+	err := json.Unmarshal([]byte(`[
+		{
+			"name": "Bug Report", "about": "Report something that is broken", "title": "[Bug]: ",
+			"labels": ["bug"], "assignees": ["testuser"], "file_name": "bug.yaml",
+			"body": [
+				{"type": "markdown", "attributes": {"value": "Thanks!"}},
+				{"type": "textarea", "id": "what", "attributes": {"label": "What happened?", "description": "Tell us"}, "validations": {"required": true}},
+				{"type": "dropdown", "id": "version", "attributes": {"label": "Version", "options": ["1.0", "2.0"]}},
+				{"type": "checkboxes", "id": "terms", "attributes": {"label": "Terms", "options": [{"label": "I searched", "required": true}]}}
+			]
+		},
+		{"name": "Feature Request", "file_name": "feature.md", "content": "## Motivation\n"}
+	]`), &list)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := list.ToMarkdown()
+	assertContains(t, output, []string{
+		"## Bug Report (`bug.yaml`, form)\nReport something that is broken\n",
+		"- **Title prefix**: [Bug]:",
+		"- **Labels**: bug",
+		"- **Assignees**: testuser",
+		"- `what` (textarea, required): What happened? - Tell us",
+		"- `version` (dropdown): Version, options: 1.0, 2.0",
+		"- `terms` (checkboxes): Terms, options: I searched (required)",
+		"## Feature Request (`feature.md`, markdown)",
+		"```markdown\n## Motivation\n```",
+	})
+	if strings.Contains(output, "Thanks!") {
+		t.Errorf("markdown elements of forms should be omitted:\n%s", output)
+	}
+	assertContains(t, IssueTemplateList{}.ToMarkdown(), []string{"No issue templates found"})
+}
+
+func TestParseTemplateAssignees(t *testing.T) {
+	for _, tc := range []struct {
+		name, data string
+		want       []string
+	}{
+		{"bug.md", "---\nname: Bug\nassignees: alice, bob\n---\nbody", []string{"alice", "bob"}},
+		{"bug.md", "---\r\nname: Bug\r\nassignees:\r\n  - alice\r\n---\r\nbody", []string{"alice"}},
+		{"bug.md", "no front matter", nil},
+		{"bug.yaml", "name: Bug\nassignees: [alice]\nbody: []\n", []string{"alice"}},
+		{"bug.yml", "name: Bug\n", nil},
+	} {
+		got, err := ParseTemplateAssignees(tc.name, []byte(tc.data))
+		if err != nil || !slices.Equal(got, tc.want) {
+			t.Errorf("ParseTemplateAssignees(%q, %q) = %v, %v, want %v", tc.name, tc.data, got, err, tc.want)
+		}
+	}
+
+	if _, err := ParseTemplateAssignees("bug.md", []byte("---\nassignees: a\n")); err == nil {
+		t.Error("expected error for unterminated front matter")
+	}
+}
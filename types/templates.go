@@ -0,0 +1,287 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MyIssueTemplate represents a markdown issue template or a YAML issue form.
+// The SDK's IssueTemplate cannot decode the options of checkboxes, so this
+// type is used instead.
+type MyIssueTemplate struct {
+	Name   string   `json:"name"`
+	Title  string   `json:"title"`
+	About  string   `json:"about"`
+	Labels []string `json:"labels"`
+	// Assignees are not returned by the API, read them from the template
+	// file with ParseTemplateAssignees.
+	Assignees []string `json:"assignees"`
+	Ref       string   `json:"ref"`
+	// Content is the body of markdown templates.
+	Content string `json:"content"`
+	// Fields are the elements of issue forms, nil for markdown templates.
+	Fields   []*MyIssueFormField `json:"body"`
+	FileName string              `json:"file_name"`
+}
+
+// IsForm reports whether the template is an issue form.
+func (t *MyIssueTemplate) IsForm() bool {
+	return len(t.Fields) > 0
+}
+
+// MyIssueFormField represents an element of an issue form. Attributes and
+// validations depend on Type, use the helper methods to read them.
+type MyIssueFormField struct {
+	Type        string         `json:"type"`
+	ID          string         `json:"id"`
+	Attributes  map[string]any `json:"attributes"`
+	Validations map[string]any `json:"validations"`
+}
+
+// MyIssueFormOption is an option of a dropdown or checkboxes field.
+type MyIssueFormOption struct {
+	Label string
+	// Required is only used by checkboxes, the option must be checked.
+	Required bool
+}
+
+// attrString returns a string attribute, or "" if not set.
+func (f *MyIssueFormField) attrString(key string) string {
+	s, _ := f.Attributes[key].(string)
+	return s
+}
+
+// Key returns the ID of the field, or its label if it has no ID.
+func (f *MyIssueFormField) Key() string {
+	if f.ID != "" {
+		return f.ID
+	}
+	return f.Label()
+}
+
+// Label returns the label of the field.
+func (f *MyIssueFormField) Label() string {
+	return f.attrString("label")
+}
+
+// Description returns the description of the field.
+func (f *MyIssueFormField) Description() string {
+	return f.attrString("description")
+}
+
+// Value returns the pre-filled value of markdown, input and textarea fields.
+func (f *MyIssueFormField) Value() string {
+	return f.attrString("value")
+}
+
+// Render returns the language textarea values are rendered as, or "" if they
+// are rendered as plain markdown.
+func (f *MyIssueFormField) Render() string {
+	return f.attrString("render")
+}
+
+// Multiple reports whether multiple options of a dropdown can be selected.
+func (f *MyIssueFormField) Multiple() bool {
+	b, _ := f.Attributes["multiple"].(bool)
+	return b
+}
+
+// Default returns the index of the default option of a dropdown, or -1.
+func (f *MyIssueFormField) Default() int {
+	if n, ok := f.Attributes["default"].(float64); ok {
+		return int(n)
+	}
+	return -1
+}
+
+// Options returns the options of dropdown and checkboxes fields. Dropdown
+// options are strings, checkboxes options are objects with a label.
+func (f *MyIssueFormField) Options() []MyIssueFormOption {
+	raw, _ := f.Attributes["options"].([]any)
+	ret := make([]MyIssueFormOption, 0, len(raw))
+	for _, o := range raw {
+		switch v := o.(type) {
+		case string:
+			ret = append(ret, MyIssueFormOption{Label: v})
+		case map[string]any:
+			label, _ := v["label"].(string)
+			required, _ := v["required"].(bool)
+			ret = append(ret, MyIssueFormOption{Label: label, Required: required})
+		}
+	}
+	return ret
+}
+
+// Required reports whether the field must be filled.
+func (f *MyIssueFormField) Required() bool {
+	b, _ := f.Validations["required"].(bool)
+	return b
+}
+
+// IsNumber reports whether an input field only accepts numbers.
+func (f *MyIssueFormField) IsNumber() bool {
+	b, _ := f.Validations["is_number"].(bool)
+	return b
+}
+
+// Regex returns the pattern values of an input field must match, if any.
+func (f *MyIssueFormField) Regex() string {
+	s, _ := f.Validations["regex"].(string)
+	return s
+}
+
+// IssueTemplateList represents the issue templates of a repository
+// Used by endpoints:
+// - GET /repos/{owner}/{repo}/issue_templates
+type IssueTemplateList []*MyIssueTemplate
+
+// ToMarkdown renders templates with their defaults, and the fields of issue
+// forms or the content of markdown templates
+// Example:
+// ## Bug Report (`bug.yaml`, form)
+// Report something that is broken
+// - **Title prefix**: [Bug]:
+// - **Labels**: bug
+//
+// Fields:
+// - `description` (textarea, required): What happened?
+// - `version` (dropdown): Version, options: 1.0, 2.0
+func (tl IssueTemplateList) ToMarkdown() string {
+	if len(tl) == 0 {
+		return "*No issue templates found*"
+	}
+
+	var b strings.Builder
+	for i, t := range tl {
+		if t == nil {
+			continue
+		}
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		kind := "markdown"
+		if t.IsForm() {
+			kind = "form"
+		}
+		fmt.Fprintf(&b, "## %s (`%s`, %s)\n", t.Name, t.FileName, kind)
+		if t.About != "" {
+			b.WriteString(t.About + "\n")
+		}
+		if t.Title != "" {
+			fmt.Fprintf(&b, "- **Title prefix**: %s\n", t.Title)
+		}
+		if len(t.Labels) > 0 {
+			fmt.Fprintf(&b, "- **Labels**: %s\n", strings.Join(t.Labels, ", "))
+		}
+		if len(t.Assignees) > 0 {
+			fmt.Fprintf(&b, "- **Assignees**: %s\n", strings.Join(t.Assignees, ", "))
+		}
+		if t.Ref != "" {
+			fmt.Fprintf(&b, "- **Ref**: %s\n", t.Ref)
+		}
+
+		if !t.IsForm() {
+			if t.Content != "" {
+				fmt.Fprintf(&b, "\n```markdown\n%s\n```\n", strings.TrimRight(t.Content, "\n"))
+			}
+			continue
+		}
+
+		b.WriteString("\nFields:\n")
+		for _, f := range t.Fields {
+			if f == nil || f.Type == "markdown" {
+				continue
+			}
+			b.WriteString(fieldLine(f) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// fieldLine renders a form field for filling, e.g.
+// "- `version` (dropdown, required): Version, options: 1.0, 2.0".
+func fieldLine(f *MyIssueFormField) string {
+	flags := []string{f.Type}
+	if f.Required() {
+		flags = append(flags, "required")
+	}
+	if f.Multiple() {
+		flags = append(flags, "multiple")
+	}
+	if f.IsNumber() {
+		flags = append(flags, "number")
+	}
+
+	line := fmt.Sprintf("- `%s` (%s): %s", f.Key(), strings.Join(flags, ", "), f.Label())
+	if d := f.Description(); d != "" {
+		line += " - " + strings.ReplaceAll(strings.TrimSpace(d), "\n", " ")
+	}
+	if opts := f.Options(); len(opts) > 0 {
+		labels := make([]string, len(opts))
+		for i, o := range opts {
+			labels[i] = o.Label
+			if o.Required {
+				labels[i] += " (required)"
+			}
+		}
+		line += ", options: " + strings.Join(labels, ", ")
+	}
+	if r := f.Regex(); r != "" {
+		line += fmt.Sprintf(", must match `%s`", r)
+	}
+	return line
+}
+
+// ParseTemplateAssignees reads the default assignees of the issue template
+// file name: the front matter of markdown templates, or the top level of
+// YAML issue forms. Assignees are a list or a comma-separated string.
+func ParseTemplateAssignees(name string, data []byte) ([]string, error) {
+	if ext := path.Ext(name); ext != ".yaml" && ext != ".yml" {
+		data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+		rest, ok := bytes.CutPrefix(data, []byte("---\n"))
+		if !ok {
+			return nil, nil
+		}
+		end := bytes.Index(rest, []byte("\n---"))
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated front matter")
+		}
+		data = rest[:end]
+	}
+
+	var t struct {
+		Assignees any `yaml:"assignees"`
+	}
+	if err := yaml.Unmarshal(data, &t); err != nil {
+		return nil, err
+	}
+	var ret []string
+	switch v := t.Assignees.(type) {
+	case nil:
+	case string:
+		for _, a := range strings.Split(v, ",") {
+			if a = strings.TrimSpace(a); a != "" {
+				ret = append(ret, a)
+			}
+		}
+	case []any:
+		for _, a := range v {
+			if s := strings.TrimSpace(fmt.Sprint(a)); s != "" {
+				ret = append(ret, s)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("assignees must be a list or a comma-separated string")
+	}
+	return ret, nil
+}
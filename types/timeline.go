@@ -0,0 +1,263 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+)
+
+// MyTimelineEvent represents an event in the timeline of an issue or pull
+// request. Type tells which of the other fields are set, e.g. Label for
+// "label" events. This type is not available in the Forgejo SDK.
+type MyTimelineEvent struct {
+	ID              int64                `json:"id"`
+	Type            string               `json:"type"`
+	HTMLURL         string               `json:"html_url"`
+	Poster          *forgejo.User        `json:"user"`
+	Body            string               `json:"body"`
+	Created         time.Time            `json:"created_at"`
+	Updated         time.Time            `json:"updated_at"`
+	OldMilestone    *forgejo.Milestone   `json:"old_milestone"`
+	Milestone       *forgejo.Milestone   `json:"milestone"`
+	TrackedTime     *forgejo.TrackedTime `json:"tracked_time"`
+	OldTitle        string               `json:"old_title"`
+	NewTitle        string               `json:"new_title"`
+	OldRef          string               `json:"old_ref"`
+	NewRef          string               `json:"new_ref"`
+	RefIssue        *forgejo.Issue       `json:"ref_issue"`
+	RefComment      *forgejo.Comment     `json:"ref_comment"`
+	RefAction       string               `json:"ref_action"`
+	RefCommitSHA    string               `json:"ref_commit_sha"`
+	ReviewID        int64                `json:"review_id"`
+	Label           *forgejo.Label       `json:"label"`
+	Assignee        *forgejo.User        `json:"assignee"`
+	AssigneeTeam    *forgejo.Team        `json:"assignee_team"`
+	RemovedAssignee bool                 `json:"removed_assignee"`
+	DependentIssue  *forgejo.Issue       `json:"dependent_issue"`
+}
+
+// htmlTagRe matches HTML tags in the body of commit reference events.
+var htmlTagRe = regexp.MustCompile(`<[^>]*>`)
+
+// Summary describes the event in one line without the actor, e.g.
+// "added label **bug**". The second result is a body to quote below the line,
+// if any.
+func (e *MyTimelineEvent) Summary() (string, string) {
+	switch e.Type {
+	case "comment":
+		return "commented", e.Body
+	case "review":
+		return "reviewed", e.Body
+	case "code":
+		return "commented on the code", e.Body
+	case "close":
+		return "closed", ""
+	case "reopen":
+		return "reopened", ""
+	case "merge_pull":
+		return "merged", ""
+	case "label":
+		if e.Label == nil {
+			return "changed labels", ""
+		}
+		if e.Body == "1" {
+			return fmt.Sprintf("added label **%s**", e.Label.Name), ""
+		}
+		return fmt.Sprintf("removed label **%s**", e.Label.Name), ""
+	case "milestone":
+		switch {
+		case e.Milestone != nil && e.OldMilestone != nil:
+			return fmt.Sprintf("changed milestone from **%s** to **%s**", e.OldMilestone.Title, e.Milestone.Title), ""
+		case e.Milestone != nil:
+			return fmt.Sprintf("set milestone **%s**", e.Milestone.Title), ""
+		case e.OldMilestone != nil:
+			return fmt.Sprintf("removed milestone **%s**", e.OldMilestone.Title), ""
+		}
+		return "changed the milestone", ""
+	case "assignees":
+		name := "someone"
+		if e.Assignee != nil {
+			name = e.Assignee.UserName
+		} else if e.AssigneeTeam != nil {
+			name = "team " + e.AssigneeTeam.Name
+		}
+		if e.RemovedAssignee {
+			return fmt.Sprintf("unassigned **%s**", name), ""
+		}
+		return fmt.Sprintf("assigned **%s**", name), ""
+	case "review_request":
+		name := "someone"
+		if e.Assignee != nil {
+			name = e.Assignee.UserName
+		} else if e.AssigneeTeam != nil {
+			name = "team " + e.AssigneeTeam.Name
+		}
+		if e.RemovedAssignee {
+			return fmt.Sprintf("removed the review request for **%s**", name), ""
+		}
+		return fmt.Sprintf("requested review from **%s**", name), ""
+	case "change_title":
+		return fmt.Sprintf("changed the title from **%s** to **%s**", e.OldTitle, e.NewTitle), ""
+	case "change_target_branch":
+		return fmt.Sprintf("changed the target branch from `%s` to `%s`", e.OldRef, e.NewRef), ""
+	case "delete_branch":
+		return fmt.Sprintf("deleted branch `%s`", e.OldRef), ""
+	case "commit_ref":
+		msg := strings.TrimSpace(htmlTagRe.ReplaceAllString(e.Body, ""))
+		return fmt.Sprintf("referenced this in commit `%s`", shortCommit(e.RefCommitSHA)), msg
+	case "issue_ref", "comment_ref", "pull_ref":
+		return "referenced this from " + refIssue(e.RefIssue), ""
+	case "change_issue_ref":
+		return fmt.Sprintf("changed the reference from `%s` to `%s`", e.OldRef, e.NewRef), ""
+	case "pull_push":
+		var push struct {
+			IsForcePush bool     `json:"is_force_push"`
+			CommitIDs   []string `json:"commit_ids"`
+		}
+		if json.Unmarshal([]byte(e.Body), &push) != nil {
+			return "pushed commits", ""
+		}
+		if push.IsForcePush && len(push.CommitIDs) == 2 {
+			return fmt.Sprintf("force-pushed from `%s` to `%s`", shortCommit(push.CommitIDs[0]), shortCommit(push.CommitIDs[1])), ""
+		}
+		ids := make([]string, len(push.CommitIDs))
+		for i, id := range push.CommitIDs {
+			ids[i] = "`" + shortCommit(id) + "`"
+		}
+		return fmt.Sprintf("pushed %d commits: %s", len(ids), strings.Join(ids, ", ")), ""
+	case "add_dependency":
+		return "added dependency " + refIssue(e.DependentIssue), ""
+	case "remove_dependency":
+		return "removed dependency " + refIssue(e.DependentIssue), ""
+	case "added_deadline":
+		return "set the due date", ""
+	case "modified_deadline":
+		return "changed the due date", ""
+	case "removed_deadline":
+		return "removed the due date", ""
+	case "start_tracking":
+		return "started the stopwatch", ""
+	case "stop_tracking", "add_time_manual":
+		if e.TrackedTime != nil {
+			return "tracked " + FormatSeconds(e.TrackedTime.Time), ""
+		}
+		return "tracked time", ""
+	case "cancel_tracking":
+		return "cancelled the stopwatch", ""
+	case "delete_time_manual":
+		return "deleted tracked time", ""
+	case "dismiss_review":
+		return "dismissed a review", e.Body
+	case "lock":
+		return "locked the conversation", ""
+	case "unlock":
+		return "unlocked the conversation", ""
+	case "pin":
+		return "pinned this", ""
+	case "unpin":
+		return "unpinned this", ""
+	case "project", "project_board":
+		return "changed the project", ""
+	}
+	return fmt.Sprintf("`%s` event", e.Type), e.Body
+}
+
+// shortCommit returns the first 10 characters of a commit SHA.
+func shortCommit(sha string) string {
+	if len(sha) > 10 {
+		return sha[:10]
+	}
+	return sha
+}
+
+// refIssue renders an issue referenced by a timeline event, e.g.
+// "owner/repo#12 **Title** (open)".
+func refIssue(issue *forgejo.Issue) string {
+	if issue == nil {
+		return "an issue"
+	}
+	ref := fmt.Sprintf("#%d", issue.Index)
+	if issue.Repository != nil {
+		ref = issue.Repository.FullName + ref
+	}
+	if issue.PullRequest != nil {
+		ref += " [PR]"
+	}
+	return fmt.Sprintf("%s **%s** (%s)", ref, issue.Title, issue.State)
+}
+
+// IssueTimeline represents the chronological history of an issue, starting
+// with its creation.
+// Used by endpoints:
+// - GET /repos/{owner}/{repo}/issues/{index}
+// - GET /repos/{owner}/{repo}/issues/{index}/timeline
+type IssueTimeline struct {
+	Issue  *forgejo.Issue
+	Events []*MyTimelineEvent
+	// Since omits the opening of the issue if it is older, events are
+	// expected to be filtered by the server.
+	Since time.Time
+}
+
+// ToMarkdown renders the timeline as one paragraph per event, oldest first.
+// Bodies of comments are quoted below their event.
+// Example:
+// # #12 Fix login bug (open)
+//
+// **2024-01-15 14:30** testuser opened this
+// > Login fails with...
+//
+// **2024-01-15 15:00** otheruser added label **bug**
+func (t IssueTimeline) ToMarkdown() string {
+	if t.Issue == nil {
+		return "*Invalid issue*"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "# #%d %s (%s)\n", t.Issue.Index, t.Issue.Title, t.Issue.State)
+
+	if t.Issue.Created.After(t.Since) {
+		writeEvent(&b, t.Issue.Created, t.Issue.Poster, "opened this", t.Issue.Body, "")
+	}
+	for _, e := range t.Events {
+		if e == nil {
+			continue
+		}
+		line, body := e.Summary()
+		link := ""
+		if body != "" && e.HTMLURL != "" {
+			link = e.HTMLURL
+		}
+		writeEvent(&b, e.Created, e.Poster, line, body, link)
+	}
+	return b.String()
+}
+
+// writeEvent writes a timeline paragraph with an optional quoted body.
+func writeEvent(b *strings.Builder, at time.Time, actor *forgejo.User, line, body, link string) {
+	name := "(unknown)"
+	if actor != nil {
+		name = actor.UserName
+	}
+	fmt.Fprintf(b, "\n**%s** %s %s", at.Format("2006-01-02 15:04"), name, line)
+	if link != "" {
+		fmt.Fprintf(b, " ([link](%s))", link)
+	}
+	b.WriteString("\n")
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return
+	}
+	for _, l := range strings.Split(body, "\n") {
+		b.WriteString(strings.TrimRight("> "+l, " ") + "\n")
+	}
+}
@@ -0,0 +1,76 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+)
+
+func TestMyTimelineEvent_Summary(t *testing.T) {
+	other := &forgejo.User{UserName: "otheruser"}
+	ref := &forgejo.Issue{Index: 7, Title: "Refactor auth", State: "open", Repository: &forgejo.RepositoryMeta{FullName: "org/lib"}, PullRequest: &forgejo.PullRequestMeta{}}
+	tests := []struct {
+		name  string
+		event *MyTimelineEvent
+		line  string
+		body  string
+	}{
+		{"comment", &MyTimelineEvent{Type: "comment", Body: "LGTM"}, "commented", "LGTM"},
+		{"label added", &MyTimelineEvent{Type: "label", Body: "1", Label: &forgejo.Label{Name: "bug"}}, "added label **bug**", ""},
+		{"label removed", &MyTimelineEvent{Type: "label", Label: &forgejo.Label{Name: "bug"}}, "removed label **bug**", ""},
+		{"milestone changed", &MyTimelineEvent{Type: "milestone", OldMilestone: &forgejo.Milestone{Title: "v1"}, Milestone: &forgejo.Milestone{Title: "v2"}}, "changed milestone from **v1** to **v2**", ""},
+		{"milestone removed", &MyTimelineEvent{Type: "milestone", OldMilestone: &forgejo.Milestone{Title: "v1"}}, "removed milestone **v1**", ""},
+		{"assigned", &MyTimelineEvent{Type: "assignees", Assignee: other}, "assigned **otheruser**", ""},
+		{"unassigned", &MyTimelineEvent{Type: "assignees", Assignee: other, RemovedAssignee: true}, "unassigned **otheruser**", ""},
+		{"closed", &MyTimelineEvent{Type: "close"}, "closed", ""},
+		{"title", &MyTimelineEvent{Type: "change_title", OldTitle: "Old", NewTitle: "New"}, "changed the title from **Old** to **New**", ""},
+		{"commit ref", &MyTimelineEvent{Type: "commit_ref", RefCommitSHA: "0123456789abcdef", Body: `<a href="/c/0123">fix login</a>`}, "referenced this in commit `0123456789`", "fix login"},
+		{"cross reference", &MyTimelineEvent{Type: "pull_ref", RefIssue: ref}, "referenced this from org/lib#7 [PR] **Refactor auth** (open)", ""},
+		{"force push", &MyTimelineEvent{Type: "pull_push", Body: `{"is_force_push":true,"commit_ids":["aaaaaaaaaaaa","bbbbbbbbbbbb"]}`}, "force-pushed from `aaaaaaaaaa` to `bbbbbbbbbb`", ""},
+		{"push", &MyTimelineEvent{Type: "pull_push", Body: `{"commit_ids":["aaaaaaaaaaaa"]}`}, "pushed 1 commits: `aaaaaaaaaa`", ""},
+		{"tracked", &MyTimelineEvent{Type: "add_time_manual", TrackedTime: &forgejo.TrackedTime{Time: 5400}}, "tracked 1h 30m", ""},
+		{"unknown", &MyTimelineEvent{Type: "future_event"}, "`future_event` event", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, body := tt.event.Summary()
+			if line != tt.line || body != tt.body {
+				t.Errorf("Summary() = %q, %q, want %q, %q", line, body, tt.line, tt.body)
+			}
+		})
+	}
+}
+
+func TestIssueTimeline_ToMarkdown(t *testing.T) {
+	created := testTime()
+	timeline := IssueTimeline{
+		Issue: &forgejo.Issue{Index: 12, Title: "Fix login bug", State: "closed", Poster: testUser(), Body: "Login fails\nwith 500", Created: created},
+		Events: []*MyTimelineEvent{
+			{Type: "comment", Poster: testUser(), Body: "Looking into it", HTMLURL: "https://git.example.com/c/1", Created: created.Add(60e9)},
+			{Type: "close", Poster: testUser(), Created: created.Add(120e9)},
+		},
+	}
+	output := timeline.ToMarkdown()
+	assertContains(t, output, []string{
+		"# #12 Fix login bug (closed)",
+		"**2024-01-15 14:30** testuser opened this\n> Login fails\n> with 500\n",
+		"**2024-01-15 14:31** testuser commented ([link](https://git.example.com/c/1))\n> Looking into it\n",
+		"**2024-01-15 14:32** testuser closed\n",
+	})
+	if strings.Index(output, "opened") > strings.Index(output, "closed\n") {
+		t.Errorf("events are not chronological:\n%s", output)
+	}
+
+	timeline.Since = created.Add(30e9)
+	if output := timeline.ToMarkdown(); strings.Contains(output, "opened this") {
+		t.Errorf("opening older than since should be omitted:\n%s", output)
+	}
+}
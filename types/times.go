@@ -0,0 +1,109 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package types
+
+import (
+	"fmt"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+)
+
+// FormatSeconds renders a tracked duration in hours and minutes
+// Example: 1h 30m, 45m, 20s
+func FormatSeconds(seconds int64) string {
+	if seconds < 0 {
+		return "-" + FormatSeconds(-seconds)
+	}
+	h, m := seconds/3600, seconds%3600/60
+	switch {
+	case h > 0 && m > 0:
+		return fmt.Sprintf("%dh %dm", h, m)
+	case h > 0:
+		return fmt.Sprintf("%dh", h)
+	case m > 0:
+		return fmt.Sprintf("%dm", m)
+	default:
+		return fmt.Sprintf("%ds", seconds)
+	}
+}
+
+// TrackedTime represents a tracked time response with embedded SDK tracked time
+// Used by endpoints:
+// - POST /repos/{owner}/{repo}/issues/{index}/times
+type TrackedTime struct {
+	*forgejo.TrackedTime
+}
+
+// ToMarkdown renders the tracked time with its user, issue and creation time
+// Example: **ID 7** 1h 30m by testuser on #12 Fix login bug - 2024-01-15 14:30
+func (t *TrackedTime) ToMarkdown() string {
+	if t.TrackedTime == nil {
+		return "*Invalid tracked time*"
+	}
+	markdown := fmt.Sprintf("**ID %d** %s by %s", t.ID, FormatSeconds(t.Time), t.UserName)
+	if t.Issue != nil {
+		markdown += fmt.Sprintf(" on #%d %s", t.Issue.Index, t.Issue.Title)
+	}
+	markdown += " - " + t.Created.Format("2006-01-02 15:04")
+	return markdown
+}
+
+// TrackedTimeList represents a list of tracked times response
+// Used by endpoints:
+// - GET /repos/{owner}/{repo}/issues/{index}/times
+// - GET /repos/{owner}/{repo}/times
+// - GET /user/times
+type TrackedTimeList []*forgejo.TrackedTime
+
+// ToMarkdown renders tracked times as a list followed by their total
+// Example:
+// - **ID 7** 1h 30m by testuser on #12 Fix login bug - 2024-01-15 14:30
+// - **ID 8** 45m by otheruser on #12 Fix login bug - 2024-01-16 09:00
+//
+// Total: 2h 15m
+func (tl TrackedTimeList) ToMarkdown() string {
+	if len(tl) == 0 {
+		return "*No tracked times found*"
+	}
+
+	markdown := ""
+	var total int64
+	for _, t := range tl {
+		if t == nil {
+			continue
+		}
+		total += t.Time
+		markdown += "- " + (&TrackedTime{TrackedTime: t}).ToMarkdown() + "\n"
+	}
+	markdown += "\nTotal: " + FormatSeconds(total)
+	return markdown
+}
+
+// StopWatchList represents a list of running stopwatches response
+// Used by endpoints:
+// - GET /user/stopwatches
+type StopWatchList []*forgejo.StopWatch
+
+// ToMarkdown renders stopwatches as a list with their issue and elapsed time
+// Example:
+// - owner/repo#12 Fix login bug - running 1h 30m since 2024-01-15 14:30
+func (sl StopWatchList) ToMarkdown() string {
+	if len(sl) == 0 {
+		return "*No running stopwatches*"
+	}
+
+	markdown := ""
+	for _, s := range sl {
+		if s == nil {
+			continue
+		}
+		markdown += fmt.Sprintf("- %s/%s#%d %s - running %s since %s\n",
+			s.RepoOwnerName, s.RepoName, s.IssueIndex, s.IssueTitle,
+			FormatSeconds(s.Seconds), s.Created.Format("2006-01-02 15:04"))
+	}
+	return markdown
+}
@@ -0,0 +1,74 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package types
+
+import (
+	"testing"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+)
+
+func TestFormatSeconds(t *testing.T) {
+	tests := []struct {
+		seconds int64
+		want    string
+	}{
+		{5400, "1h 30m"},
+		{7200, "2h"},
+		{2700, "45m"},
+		{20, "20s"},
+		{0, "0s"},
+		{-3600, "-1h"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatSeconds(tt.seconds); got != tt.want {
+			t.Errorf("FormatSeconds(%d) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+}
+
+func TestTrackedTimeList_ToMarkdown(t *testing.T) {
+	issue := &forgejo.Issue{Index: 12, Title: "Fix login bug"}
+	tests := []struct {
+		name     string
+		list     TrackedTimeList
+		required []string
+	}{
+		{
+			name: "tracked times with total",
+			list: TrackedTimeList{
+				{ID: 7, Time: 5400, UserName: "testuser", Issue: issue, Created: testTime()},
+				{ID: 8, Time: 2700, UserName: "otheruser", Issue: issue, Created: testTime()},
+			},
+			required: []string{
+				"**ID 7** 1h 30m by testuser on #12 Fix login bug - 2024-01-15 14:30",
+				"**ID 8** 45m by otheruser", "Total: 2h 15m",
+			},
+		},
+		{
+			name:     "empty list",
+			list:     TrackedTimeList{},
+			required: []string{"No tracked times found"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := tt.list.ToMarkdown()
+			assertContains(t, output, tt.required)
+		})
+	}
+}
+
+func TestStopWatchList_ToMarkdown(t *testing.T) {
+	list := StopWatchList{
+		{Created: testTime(), Seconds: 5400, IssueIndex: 12, IssueTitle: "Fix login bug", RepoOwnerName: "owner", RepoName: "repo"},
+	}
+	assertContains(t, list.ToMarkdown(), []string{"owner/repo#12 Fix login bug", "running 1h 30m", "2024-01-15 14:30"})
+	assertContains(t, StopWatchList{}.ToMarkdown(), []string{"No running stopwatches"})
+}
@@ -0,0 +1,24 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package types
+
+import (
+	"testing"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+)
+
+func TestUserList_ToMarkdown(t *testing.T) {
+	list := UserList{
+		&forgejo.User{UserName: "testuser", FullName: "Test User"},
+		&forgejo.User{UserName: "otheruser"},
+	}
+	assertContains(t, list.ToMarkdown(), []string{
+		"- **testuser** (Test User)\n", "- **otheruser**\n",
+	})
+	assertContains(t, UserList{}.ToMarkdown(), []string{"No users found"})
+}
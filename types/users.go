@@ -0,0 +1,41 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright © 2025 Ronmi Ren <ronmi.ren@gmail.com>
+
+package types
+
+import (
+	"fmt"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+)
+
+// UserList represents a list of users response
+// Used by endpoints:
+// - GET /repos/{owner}/{repo}/issues/{index}/subscriptions
+type UserList []*forgejo.User
+
+// ToMarkdown renders users with their full names, if any
+// Example:
+// - **testuser** (Test User)
+// - **otheruser**
+func (ul UserList) ToMarkdown() string {
+	if len(ul) == 0 {
+		return "*No users found*"
+	}
+
+	markdown := ""
+	for _, u := range ul {
+		if u == nil {
+			continue
+		}
+		markdown += fmt.Sprintf("- **%s**", u.UserName)
+		if u.FullName != "" {
+			markdown += fmt.Sprintf(" (%s)", u.FullName)
+		}
+		markdown += "\n"
+	}
+	return markdown
+}